@@ -0,0 +1,105 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+)
+
+// RequiredServices lists every downstream service the gateway routes to.
+var RequiredServices = []string{
+	"auth-service",
+	"user-service",
+	"product-service",
+	"cart-service",
+	"order-service",
+	"payment-service",
+}
+
+// defaultServiceURLs match the docker-compose service names and are only used
+// when SERVICE_URLS is not set and APP_ENV is "development".
+var defaultServiceURLs = map[string]string{
+	"auth-service":    "http://auth-service:8081",
+	"user-service":    "http://user-service:8085",
+	"product-service": "http://product-service:8082",
+	"cart-service":    "http://cart-service:8086",
+	"order-service":   "http://order-service:8083",
+	"payment-service": "http://payment-service:8087",
+}
+
+// ServiceURLs maps a service name to one or more base URLs.
+type ServiceURLs map[string][]string
+
+// LoadServiceURLs resolves downstream base URLs from SERVICE_URLS, formatted as
+// comma-separated name=url pairs where a service may list failover addresses
+// separated by "|":
+//
+//	SERVICE_URLS="product-service=http://localhost:8082|http://localhost:9082,auth-service=http://localhost:8081,..."
+//
+// Every required service must be present. Outside development an unset
+// SERVICE_URLS is an error rather than a silent fallback to docker DNS names.
+func LoadServiceURLs() (ServiceURLs, error) {
+	raw := strings.TrimSpace(os.Getenv("SERVICE_URLS"))
+	if raw == "" {
+		if os.Getenv("APP_ENV") != "development" {
+			return nil, fmt.Errorf("SERVICE_URLS is not set")
+		}
+		urls := make(ServiceURLs, len(defaultServiceURLs))
+		for name, u := range defaultServiceURLs {
+			urls[name] = []string{u}
+		}
+		return urls, nil
+	}
+
+	urls, err := ParseServiceURLs(raw)
+	if err != nil {
+		return nil, err
+	}
+	if err := urls.Validate(); err != nil {
+		return nil, err
+	}
+	return urls, nil
+}
+
+// ParseServiceURLs parses the SERVICE_URLS format.
+func ParseServiceURLs(raw string) (ServiceURLs, error) {
+	urls := make(ServiceURLs)
+	for _, entry := range strings.Split(raw, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		name, value, ok := strings.Cut(entry, "=")
+		name = strings.TrimSpace(name)
+		if !ok || name == "" {
+			return nil, fmt.Errorf("invalid SERVICE_URLS entry %q, expected name=url", entry)
+		}
+		for _, u := range strings.Split(value, "|") {
+			u = strings.TrimRight(strings.TrimSpace(u), "/")
+			if u == "" {
+				continue
+			}
+			if !strings.HasPrefix(u, "http://") && !strings.HasPrefix(u, "https://") {
+				return nil, fmt.Errorf("invalid URL %q for service %s", u, name)
+			}
+			urls[name] = append(urls[name], u)
+		}
+	}
+	return urls, nil
+}
+
+// Validate checks that every required service has at least one URL.
+func (s ServiceURLs) Validate() error {
+	var missing []string
+	for _, name := range RequiredServices {
+		if len(s[name]) == 0 {
+			missing = append(missing, name)
+		}
+	}
+	if len(missing) > 0 {
+		sort.Strings(missing)
+		return fmt.Errorf("SERVICE_URLS is missing: %s", strings.Join(missing, ", "))
+	}
+	return nil
+}
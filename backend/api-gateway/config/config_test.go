@@ -0,0 +1,64 @@
+package config
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseServiceURLs(t *testing.T) {
+	urls, err := ParseServiceURLs("product-service=http://localhost:8082/|http://localhost:9082, auth-service=http://localhost:8081")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	got := urls["product-service"]
+	if len(got) != 2 || got[0] != "http://localhost:8082" || got[1] != "http://localhost:9082" {
+		t.Fatalf("unexpected product-service urls: %v", got)
+	}
+	if urls["auth-service"][0] != "http://localhost:8081" {
+		t.Fatalf("unexpected auth-service urls: %v", urls["auth-service"])
+	}
+}
+
+func TestParseServiceURLs_Invalid(t *testing.T) {
+	for _, raw := range []string{"product-service", "=http://x", "product-service=localhost:8082"} {
+		if _, err := ParseServiceURLs(raw); err == nil {
+			t.Fatalf("expected error for %q", raw)
+		}
+	}
+}
+
+func TestLoadServiceURLs_RequiresAllServices(t *testing.T) {
+	t.Setenv("SERVICE_URLS", "product-service=http://localhost:8082,auth-service=http://localhost:8081")
+
+	_, err := LoadServiceURLs()
+	if err == nil {
+		t.Fatalf("expected validation error")
+	}
+	for _, name := range []string{"cart-service", "order-service", "payment-service", "user-service"} {
+		if !strings.Contains(err.Error(), name) {
+			t.Fatalf("expected error to name %s, got %v", name, err)
+		}
+	}
+}
+
+func TestLoadServiceURLs_RequiredOutsideDevelopment(t *testing.T) {
+	t.Setenv("SERVICE_URLS", "")
+	t.Setenv("APP_ENV", "production")
+
+	if _, err := LoadServiceURLs(); err == nil {
+		t.Fatalf("expected error when SERVICE_URLS is unset")
+	}
+}
+
+func TestLoadServiceURLs_DefaultsInDevelopment(t *testing.T) {
+	t.Setenv("SERVICE_URLS", "")
+	t.Setenv("APP_ENV", "development")
+
+	urls, err := LoadServiceURLs()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := urls.Validate(); err != nil {
+		t.Fatalf("defaults should cover all services: %v", err)
+	}
+}
@@ -1,76 +1,50 @@
 package main
 
 import (
+	"api-gateway/config"
 	"api-gateway/logger"
+	"api-gateway/middlewares"
 	"api-gateway/routes"
+	"api-gateway/tracing"
 	"context"
 	"net/http"
 	"os"
 	"os/signal"
-	"runtime/debug"
 	"syscall"
 	"time"
 
 	"github.com/gin-gonic/gin"
-	"github.com/gin-contrib/cors"
+	"github.com/redis/go-redis/v9"
+	commonmw "github.com/yashrajoria/common/middleware"
 	"go.uber.org/zap"
-	"strings"
 )
 
-// CORS Middleware - Apply this globally
-func CORSMiddleware() gin.HandlerFunc {
-	// Use gin-contrib/cors with configuration from ALLOWED_ORIGINS
-	allowed := os.Getenv("ALLOWED_ORIGINS")
-	config := cors.Config{
-		AllowCredentials: true,
-		AllowMethods:     []string{"POST", "HEAD", "PATCH", "OPTIONS", "GET", "PUT", "DELETE"},
-		AllowHeaders:     []string{"Content-Type", "Content-Length", "Accept-Encoding", "X-CSRF-Token", "Authorization", "Accept", "Origin", "Cache-Control", "X-Requested-With"},
-	}
-
-	if allowed == "*" {
-		config.AllowAllOrigins = true
-	} else if allowed != "" {
-		var origins []string
-		for _, o := range strings.Split(allowed, ",") {
-			origins = append(origins, strings.TrimSpace(o))
-		}
-		config.AllowOrigins = origins
-	} else {
-		config.AllowOrigins = []string{"http://localhost:3000", "http://localhost:3001"}
-	}
-
-	return cors.New(config)
-}
-
-// CustomRecovery recovers from panics and logs them
-func CustomRecovery(logger *zap.Logger) gin.HandlerFunc {
-	return func(c *gin.Context) {
-		defer func() {
-			if err := recover(); err != nil {
-				stack := debug.Stack()
-				logger.Error("Panic recovered", zap.Any("error", err), zap.ByteString("stack", stack))
-				c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "Internal server error"})
-			}
-		}()
-		c.Next()
-	}
-}
-
 func main() {
 	// Initialize logger
 	logger.InitLogger()
 	defer logger.Sync()
 	logger.Log.Info("Starting API Gateway...")
 
+	shutdownTracing, err := tracing.Init(context.Background(), "api-gateway")
+	if err != nil {
+		logger.Log.Fatal("Tracing init failed", zap.Error(err))
+	}
+
 	r := gin.New()
 
 	// Configure Gin to handle trailing slashes
 	r.RedirectTrailingSlash = true
 
+	r.Use(commonmw.RequestID())
 	r.Use(gin.Logger())
-	r.Use(CustomRecovery(logger.Log))
+	r.Use(commonmw.Recovery(logger.Log, "api-gateway", os.Stdout))
+	r.Use(tracing.Middleware())
 
-	r.Use(CORSMiddleware())
+	corsConfig, err := commonmw.LoadCORSConfig()
+	if err != nil {
+		logger.Log.Fatal("Invalid CORS configuration", zap.Error(err))
+	}
+	r.Use(commonmw.CORS(corsConfig))
 
 	// Health check / Test route for CORS
 	r.GET("/health", func(c *gin.Context) {
@@ -80,20 +54,29 @@ func main() {
 	r.GET("/test-cors", func(c *gin.Context) {
 		c.JSON(http.StatusOK, gin.H{"message": "CORS is working!"})
 	})
-	// Add this debug middleware
-	r.Use(func(c *gin.Context) {
-		logger.Log.Info("🔍 DEBUG: Request received",
-			zap.String("method", c.Request.Method),
-			zap.String("path", c.Request.URL.Path),
-			zap.String("full_url", c.Request.URL.String()),
-		)
-		c.Next()
-		logger.Log.Info("🔍 DEBUG: Response sent",
-			zap.Int("status", c.Writer.Status()),
-		)
-	})
 
-	routes.RegisterAllRoutes(r)
+	// Rate limiting: Redis-backed when REDIS_URL is set so limits are shared
+	// across gateway instances, otherwise per-process.
+	var limitStore middlewares.RateLimitStore
+	var redisClient *redis.Client
+	if redisURL := os.Getenv("REDIS_URL"); redisURL != "" {
+		opts, err := redis.ParseURL(redisURL)
+		if err != nil {
+			logger.Log.Fatal("Invalid REDIS_URL", zap.Error(err))
+		}
+		redisClient = redis.NewClient(opts)
+		limitStore = middlewares.NewRedisRateLimitStore(redisClient)
+	} else {
+		logger.Log.Warn("REDIS_URL not set, using in-memory rate limiting")
+		limitStore = middlewares.NewMemoryRateLimitStore()
+	}
+
+	serviceURLs, err := config.LoadServiceURLs()
+	if err != nil {
+		logger.Log.Fatal("Invalid service URL configuration", zap.Error(err))
+	}
+
+	routes.RegisterAllRoutes(r, serviceURLs, limitStore)
 
 	// Server setup
 	port := os.Getenv("PORT")
@@ -124,6 +107,15 @@ func main() {
 	if err := srv.Shutdown(ctx); err != nil {
 		logger.Log.Fatal("API Gateway forced to shutdown:", zap.Error(err))
 	}
+	if err := shutdownTracing(ctx); err != nil {
+		logger.Log.Warn("Failed to flush traces", zap.Error(err))
+	}
+
+	if redisClient != nil {
+		if err := redisClient.Close(); err != nil {
+			logger.Log.Error("Failed to close Redis", zap.Error(err))
+		}
+	}
 
 	logger.Log.Info("API Gateway exited gracefully")
 }
@@ -0,0 +1,68 @@
+package middlewares
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+// countingTransport fails and counts any outbound HTTP call.
+type countingTransport struct {
+	calls int32
+}
+
+func (t *countingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	atomic.AddInt32(&t.calls, 1)
+	return nil, http.ErrHandlerTimeout
+}
+
+// The admin check must rely solely on the role claim already placed in the
+// context by JWTMiddleware; it should never look the role up over the network.
+func TestAdminRoleMiddleware_NoNetworkCall(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	transport := &countingTransport{}
+	orig := http.DefaultTransport
+	http.DefaultTransport = transport
+	defer func() { http.DefaultTransport = orig }()
+
+	cases := []struct {
+		name string
+		role string
+		set  bool
+		want int
+	}{
+		{name: "admin", role: "admin", set: true, want: http.StatusOK},
+		{name: "customer", role: "customer", set: true, want: http.StatusForbidden},
+		{name: "no role", set: false, want: http.StatusForbidden},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			r := gin.New()
+			r.Use(func(c *gin.Context) {
+				if tc.set {
+					c.Set("user_id", "user-1")
+					c.Set("role", tc.role)
+				}
+				c.Next()
+			})
+			r.Use(AdminRoleMiddleware())
+			r.GET("/admin", func(c *gin.Context) { c.Status(http.StatusOK) })
+
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/admin", nil))
+
+			if w.Code != tc.want {
+				t.Fatalf("expected %d, got %d", tc.want, w.Code)
+			}
+		})
+	}
+
+	if n := atomic.LoadInt32(&transport.calls); n != 0 {
+		t.Fatalf("expected no outbound HTTP calls, got %d", n)
+	}
+}
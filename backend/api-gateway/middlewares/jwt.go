@@ -1,11 +1,13 @@
 package middlewares
 
 import (
+	"errors"
 	"fmt"
 	"log"
 	"net/http"
 	"os"
 	"strings"
+	"sync"
 
 	"api-gateway/logger"
 
@@ -18,21 +20,27 @@ var (
 	secretKey    []byte
 	isProduction bool
 	cookieDomain string
+	jwtInitOnce  sync.Once
 )
 
-func init() {
-	_ = godotenv.Load()
-	secret := strings.TrimSpace(os.Getenv("JWT_SECRET"))
-	if secret == "" {
-		logger.Log.Fatal("JWT_SECRET is not set in env")
-	}
-	secretKey = []byte(secret)
-	isProduction = os.Getenv("ENV") == "production"
-	cookieDomain = os.Getenv("COOKIE_DOMAIN")
+// loadJWTConfig reads the JWT settings once, when the first JWT middleware is
+// built, so packages importing middlewares (e.g. tests) don't require JWT_SECRET.
+func loadJWTConfig() {
+	jwtInitOnce.Do(func() {
+		_ = godotenv.Load()
+		secret := strings.TrimSpace(os.Getenv("JWT_SECRET"))
+		if secret == "" {
+			logger.Log.Fatal("JWT_SECRET is not set in env")
+		}
+		secretKey = []byte(secret)
+		isProduction = os.Getenv("ENV") == "production"
+		cookieDomain = os.Getenv("COOKIE_DOMAIN")
+	})
 }
 
 // JWTMiddleware validates JWT access token and refreshes when needed
 func JWTMiddleware() gin.HandlerFunc {
+	loadJWTConfig()
 	return func(c *gin.Context) {
 		// Log incoming cookies and headers for debugging
 		if v, err := c.Cookie("__session"); err == nil {
@@ -47,13 +55,9 @@ func JWTMiddleware() gin.HandlerFunc {
 			log.Printf("[GATEWAY][JWT] Authorization header=%s", auth)
 		}
 
-		// Accept either __session (set by auth service) or token cookie
-		tokenString, err := c.Cookie("__session")
-		if err != nil || tokenString == "" {
-			tokenString, _ = c.Cookie("token")
-		}
+		tokenString := sessionToken(c)
 		if tokenString == "" {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "Missing authentication token"})
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "Missing authentication token", "code": TokenMissing})
 			c.Abort()
 			return
 		}
@@ -62,30 +66,64 @@ func JWTMiddleware() gin.HandlerFunc {
 		claims, err := parseToken(tokenString, "access")
 		if err != nil {
 			log.Printf("[GATEWAY][JWT] token parse error: %v", err)
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid or expired token"})
+			if errors.Is(err, errTokenExpired) {
+				c.JSON(http.StatusUnauthorized, gin.H{"error": "Token expired", "code": TokenExpired})
+			} else {
+				c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid token", "code": TokenInvalid})
+			}
 			c.Abort()
 			return
 		}
 
-		userID, _ := claims["sub"].(string)
-		email, _ := claims["email"].(string)
-		role, _ := claims["role"].(string)
-
 		// log claims for debugging
 		log.Printf("[GATEWAY][JWT] token claims: %+v", claims)
 
-		c.Set("user_id", userID)
-		c.Set("email", email)
-		c.Set("role", role)
-
-		c.Request.Header.Set("X-User-ID", userID)
-		c.Request.Header.Set("X-User-Email", email)
-		c.Request.Header.Set("X-User-Role", role)
+		setClaims(c, claims)
+		c.Next()
+	}
+}
 
+// OptionalJWTMiddleware identifies the caller on public routes. A valid
+// access token sets the same user context as JWTMiddleware; a missing or
+// invalid one is ignored and the request continues anonymously.
+func OptionalJWTMiddleware() gin.HandlerFunc {
+	loadJWTConfig()
+	return func(c *gin.Context) {
+		if tokenString := sessionToken(c); tokenString != "" {
+			if claims, err := parseToken(tokenString, "access"); err == nil {
+				setClaims(c, claims)
+			}
+		}
 		c.Next()
 	}
 }
 
+// sessionToken returns the access token from the __session cookie (set by
+// the auth service) or the token cookie, or "" if neither is present.
+func sessionToken(c *gin.Context) string {
+	tokenString, err := c.Cookie("__session")
+	if err != nil || tokenString == "" {
+		tokenString, _ = c.Cookie("token")
+	}
+	return tokenString
+}
+
+// setClaims exposes the token's identity to later handlers and, through the
+// X-User-* headers, to upstream services.
+func setClaims(c *gin.Context, claims jwt.MapClaims) {
+	userID, _ := claims["sub"].(string)
+	email, _ := claims["email"].(string)
+	role, _ := claims["role"].(string)
+
+	c.Set("user_id", userID)
+	c.Set("email", email)
+	c.Set("role", role)
+
+	c.Request.Header.Set("X-User-ID", userID)
+	c.Request.Header.Set("X-User-Email", email)
+	c.Request.Header.Set("X-User-Role", role)
+}
+
 // AdminRoleMiddleware restricts access to users with role admin
 func AdminRoleMiddleware() gin.HandlerFunc {
 	return func(c *gin.Context) {
@@ -99,6 +137,29 @@ func AdminRoleMiddleware() gin.HandlerFunc {
 	}
 }
 
+// AdminRoleUnless enforces the admin role except on requests for which skip
+// returns true, for routes that share a wildcard with a non-admin endpoint.
+func AdminRoleUnless(skip func(c *gin.Context) bool) gin.HandlerFunc {
+	admin := AdminRoleMiddleware()
+	return func(c *gin.Context) {
+		if skip(c) {
+			c.Next()
+			return
+		}
+		admin(c)
+	}
+}
+
+// Error codes returned alongside 401s so clients can tell an expired session
+// (refresh and retry) from a bad token (re-authenticate).
+const (
+	TokenMissing = "token_missing"
+	TokenExpired = "token_expired"
+	TokenInvalid = "token_invalid"
+)
+
+var errTokenExpired = errors.New("token expired")
+
 // parseToken validates and extracts claims
 func parseToken(tokenStr, expectedType string) (jwt.MapClaims, error) {
 	token, err := jwt.Parse(tokenStr, func(token *jwt.Token) (interface{}, error) {
@@ -108,8 +169,16 @@ func parseToken(tokenStr, expectedType string) (jwt.MapClaims, error) {
 		return secretKey, nil
 	})
 
-	if err != nil || token == nil || !token.Valid {
-		return nil, fmt.Errorf("invalid or expired token")
+	if err != nil {
+		// Only report expiry when the token is otherwise sound (signature ok)
+		var vErr *jwt.ValidationError
+		if errors.As(err, &vErr) && vErr.Errors == jwt.ValidationErrorExpired {
+			return nil, errTokenExpired
+		}
+		return nil, fmt.Errorf("invalid token: %w", err)
+	}
+	if token == nil || !token.Valid {
+		return nil, fmt.Errorf("invalid token")
 	}
 
 	claims, ok := token.Claims.(jwt.MapClaims)
@@ -0,0 +1,126 @@
+package middlewares
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v4"
+)
+
+const testJWTSecret = "test-secret"
+
+func newJWTRouter(t *testing.T) *gin.Engine {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+	os.Setenv("JWT_SECRET", testJWTSecret)
+	r := gin.New()
+	r.Use(JWTMiddleware())
+	r.GET("/me", func(c *gin.Context) { c.Status(http.StatusOK) })
+	return r
+}
+
+func signToken(t *testing.T, secret string, claims jwt.MapClaims) string {
+	t.Helper()
+	s, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(secret))
+	if err != nil {
+		t.Fatalf("failed to sign token: %v", err)
+	}
+	return s
+}
+
+func requestWithToken(r *gin.Engine, token string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, "/me", nil)
+	if token != "" {
+		req.AddCookie(&http.Cookie{Name: "__session", Value: token})
+	}
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w
+}
+
+func errorCode(t *testing.T, w *httptest.ResponseRecorder) string {
+	t.Helper()
+	var body map[string]string
+	if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+		t.Fatalf("invalid JSON body %q: %v", w.Body.String(), err)
+	}
+	return body["code"]
+}
+
+func TestJWTMiddleware_ErrorCodes(t *testing.T) {
+	r := newJWTRouter(t)
+	valid := jwt.MapClaims{"sub": "user-1", "role": "customer", "typ": "access", "exp": time.Now().Add(time.Hour).Unix()}
+	expired := jwt.MapClaims{"sub": "user-1", "typ": "access", "exp": time.Now().Add(-time.Minute).Unix()}
+
+	cases := []struct {
+		name  string
+		token string
+		code  string
+	}{
+		{name: "missing", token: "", code: TokenMissing},
+		{name: "expired", token: signToken(t, testJWTSecret, expired), code: TokenExpired},
+		{name: "bad signature", token: signToken(t, "other-secret", valid), code: TokenInvalid},
+		{name: "expired with bad signature", token: signToken(t, "other-secret", expired), code: TokenInvalid},
+		{name: "malformed", token: "not.a.jwt", code: TokenInvalid},
+		{name: "wrong type", token: signToken(t, testJWTSecret, jwt.MapClaims{"sub": "user-1", "typ": "refresh", "exp": time.Now().Add(time.Hour).Unix()}), code: TokenInvalid},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			w := requestWithToken(r, tc.token)
+			if w.Code != http.StatusUnauthorized {
+				t.Fatalf("expected 401, got %d", w.Code)
+			}
+			if got := errorCode(t, w); got != tc.code {
+				t.Fatalf("expected code %q, got %q", tc.code, got)
+			}
+		})
+	}
+}
+
+func TestJWTMiddleware_ValidToken(t *testing.T) {
+	r := newJWTRouter(t)
+	token := signToken(t, testJWTSecret, jwt.MapClaims{"sub": "user-1", "typ": "access", "exp": time.Now().Add(time.Hour).Unix()})
+
+	if w := requestWithToken(r, token); w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", w.Code)
+	}
+}
+
+func TestOptionalJWTMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	os.Setenv("JWT_SECRET", testJWTSecret)
+	r := gin.New()
+	r.Use(OptionalJWTMiddleware())
+	r.GET("/me", func(c *gin.Context) { c.String(http.StatusOK, c.GetHeader("X-User-ID")) })
+
+	valid := signToken(t, testJWTSecret, jwt.MapClaims{"sub": "user-1", "typ": "access", "exp": time.Now().Add(time.Hour).Unix()})
+	expired := signToken(t, testJWTSecret, jwt.MapClaims{"sub": "user-1", "typ": "access", "exp": time.Now().Add(-time.Minute).Unix()})
+
+	cases := []struct {
+		name   string
+		token  string
+		userID string
+	}{
+		{name: "valid", token: valid, userID: "user-1"},
+		{name: "missing", token: ""},
+		{name: "expired", token: expired},
+		{name: "malformed", token: "not.a.jwt"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			w := requestWithToken(r, tc.token)
+			if w.Code != http.StatusOK {
+				t.Fatalf("expected 200, got %d", w.Code)
+			}
+			if got := w.Body.String(); got != tc.userID {
+				t.Fatalf("expected X-User-ID %q, got %q", tc.userID, got)
+			}
+		})
+	}
+}
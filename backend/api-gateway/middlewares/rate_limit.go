@@ -0,0 +1,225 @@
+package middlewares
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net/http"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	"api-gateway/logger"
+
+	"github.com/gin-gonic/gin"
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
+)
+
+// RateLimitPolicy describes a token bucket: Burst tokens refilled at
+// Requests per Window.
+type RateLimitPolicy struct {
+	Name     string
+	Requests int
+	Window   time.Duration
+	Burst    int
+}
+
+// ratePerSecond returns the refill rate of the bucket.
+func (p RateLimitPolicy) ratePerSecond() float64 {
+	return float64(p.Requests) / p.Window.Seconds()
+}
+
+// RateLimitConfig holds the per-endpoint-group limits applied by the gateway.
+type RateLimitConfig struct {
+	Auth     RateLimitPolicy
+	Products RateLimitPolicy
+	Default  RateLimitPolicy
+}
+
+// LoadRateLimitConfig reads limits from the environment, falling back to defaults.
+// Auth endpoints get a tighter budget since they are the usual brute-force target.
+func LoadRateLimitConfig() RateLimitConfig {
+	return RateLimitConfig{
+		Auth: RateLimitPolicy{
+			Name:     "auth",
+			Requests: envInt("RATE_LIMIT_AUTH_PER_MIN", 20),
+			Window:   time.Minute,
+			Burst:    envInt("RATE_LIMIT_AUTH_BURST", 10),
+		},
+		Products: RateLimitPolicy{
+			Name:     "products",
+			Requests: envInt("RATE_LIMIT_PRODUCTS_PER_MIN", 300),
+			Window:   time.Minute,
+			Burst:    envInt("RATE_LIMIT_PRODUCTS_BURST", 100),
+		},
+		Default: RateLimitPolicy{
+			Name:     "default",
+			Requests: envInt("RATE_LIMIT_DEFAULT_PER_MIN", 120),
+			Window:   time.Minute,
+			Burst:    envInt("RATE_LIMIT_DEFAULT_BURST", 40),
+		},
+	}
+}
+
+func envInt(key string, def int) int {
+	if v := os.Getenv(key); v != "" {
+		if n, err := strconv.Atoi(v); err == nil && n > 0 {
+			return n
+		}
+	}
+	return def
+}
+
+// RateLimitStore takes a token for key under the given policy. When the bucket
+// is empty it reports how long the caller should wait before retrying.
+type RateLimitStore interface {
+	Take(ctx context.Context, key string, policy RateLimitPolicy) (allowed bool, retryAfter time.Duration, err error)
+}
+
+// RateLimitMiddleware enforces policy per authenticated user, or per client IP
+// for anonymous requests. Store errors fail open so a Redis outage does not
+// take the gateway down with it.
+func RateLimitMiddleware(store RateLimitStore, policy RateLimitPolicy) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if store == nil {
+			c.Next()
+			return
+		}
+
+		key := "ratelimit:" + policy.Name + ":ip:" + c.ClientIP()
+		if userID, ok := c.Get("user_id"); ok {
+			if uid, ok := userID.(string); ok && uid != "" {
+				key = "ratelimit:" + policy.Name + ":user:" + uid
+			}
+		}
+
+		allowed, retryAfter, err := store.Take(c.Request.Context(), key, policy)
+		if err != nil {
+			logger.Log.Warn("Rate limit check failed, allowing request", zap.String("key", key), zap.Error(err))
+			c.Next()
+			return
+		}
+		if !allowed {
+			secs := int(math.Ceil(retryAfter.Seconds()))
+			if secs < 1 {
+				secs = 1
+			}
+			c.Header("Retry-After", strconv.Itoa(secs))
+			c.JSON(http.StatusTooManyRequests, gin.H{"error": "Rate limit exceeded"})
+			c.Abort()
+			return
+		}
+		c.Next()
+	}
+}
+
+// tokenBucketScript refills and takes from a bucket stored as a Redis hash.
+// Returns {allowed, retry_after_ms}.
+var tokenBucketScript = redis.NewScript(`
+local rate = tonumber(ARGV[1])
+local burst = tonumber(ARGV[2])
+local now = tonumber(ARGV[3])
+local state = redis.call("HMGET", KEYS[1], "tokens", "ts")
+local tokens = tonumber(state[1])
+local ts = tonumber(state[2])
+if tokens == nil or ts == nil then
+	tokens = burst
+	ts = now
+end
+tokens = math.min(burst, tokens + math.max(0, now - ts) / 1000 * rate)
+local allowed = 0
+local retry = 0
+if tokens >= 1 then
+	tokens = tokens - 1
+	allowed = 1
+else
+	retry = math.ceil((1 - tokens) / rate * 1000)
+end
+redis.call("HSET", KEYS[1], "tokens", tostring(tokens), "ts", now)
+redis.call("PEXPIRE", KEYS[1], math.ceil(burst / rate * 1000) + 1000)
+return {allowed, retry}
+`)
+
+// RedisRateLimitStore keeps buckets in Redis so limits hold across gateway instances.
+type RedisRateLimitStore struct {
+	client *redis.Client
+}
+
+func NewRedisRateLimitStore(client *redis.Client) *RedisRateLimitStore {
+	return &RedisRateLimitStore{client: client}
+}
+
+func (s *RedisRateLimitStore) Take(ctx context.Context, key string, policy RateLimitPolicy) (bool, time.Duration, error) {
+	res, err := tokenBucketScript.Run(ctx, s.client, []string{key},
+		policy.ratePerSecond(), policy.Burst, time.Now().UnixMilli()).Int64Slice()
+	if err != nil {
+		return false, 0, fmt.Errorf("rate limit script failed: %w", err)
+	}
+	if len(res) != 2 {
+		return false, 0, fmt.Errorf("unexpected rate limit script result: %v", res)
+	}
+	return res[0] == 1, time.Duration(res[1]) * time.Millisecond, nil
+}
+
+// memorySweepInterval bounds how often Take scans for idle buckets.
+const memorySweepInterval = time.Minute
+
+// MemoryRateLimitStore is a process-local store, used when Redis is not configured.
+// Like the Redis keys, a bucket is dropped once it has been idle long enough to
+// refill completely, since a fresh bucket would behave the same.
+type MemoryRateLimitStore struct {
+	mu        sync.Mutex
+	buckets   map[string]*memoryBucket
+	now       func() time.Time
+	lastSweep time.Time
+}
+
+type memoryBucket struct {
+	tokens  float64
+	last    time.Time
+	expires time.Time
+}
+
+func NewMemoryRateLimitStore() *MemoryRateLimitStore {
+	return &MemoryRateLimitStore{buckets: make(map[string]*memoryBucket), now: time.Now}
+}
+
+func (s *MemoryRateLimitStore) Take(ctx context.Context, key string, policy RateLimitPolicy) (bool, time.Duration, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	s.sweep(now)
+
+	rate := policy.ratePerSecond()
+	b, ok := s.buckets[key]
+	if !ok {
+		b = &memoryBucket{tokens: float64(policy.Burst), last: now}
+		s.buckets[key] = b
+	}
+	b.tokens = math.Min(float64(policy.Burst), b.tokens+now.Sub(b.last).Seconds()*rate)
+	b.last = now
+	b.expires = now.Add(time.Duration(float64(policy.Burst) / rate * float64(time.Second)))
+
+	if b.tokens >= 1 {
+		b.tokens--
+		return true, 0, nil
+	}
+	wait := time.Duration((1 - b.tokens) / rate * float64(time.Second))
+	return false, wait, nil
+}
+
+// sweep removes buckets that have fully refilled. Callers must hold s.mu.
+func (s *MemoryRateLimitStore) sweep(now time.Time) {
+	if now.Sub(s.lastSweep) < memorySweepInterval {
+		return
+	}
+	s.lastSweep = now
+	for key, b := range s.buckets {
+		if now.After(b.expires) {
+			delete(s.buckets, key)
+		}
+	}
+}
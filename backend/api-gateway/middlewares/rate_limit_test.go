@@ -0,0 +1,135 @@
+package middlewares
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"api-gateway/logger"
+
+	"github.com/gin-gonic/gin"
+)
+
+func newRateLimitedRouter(store RateLimitStore, policy RateLimitPolicy, userID string) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	if userID != "" {
+		r.Use(func(c *gin.Context) {
+			c.Set("user_id", userID)
+			c.Next()
+		})
+	}
+	r.Use(RateLimitMiddleware(store, policy))
+	r.GET("/ping", func(c *gin.Context) {
+		c.String(http.StatusOK, "pong")
+	})
+	return r
+}
+
+func doGet(r *gin.Engine, remoteAddr string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, "/ping", nil)
+	req.RemoteAddr = remoteAddr
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w
+}
+
+func TestRateLimitMiddleware_UnderLimitPasses(t *testing.T) {
+	policy := RateLimitPolicy{Name: "test", Requests: 60, Window: time.Minute, Burst: 3}
+	r := newRateLimitedRouter(NewMemoryRateLimitStore(), policy, "")
+
+	for i := 0; i < 3; i++ {
+		if w := doGet(r, "10.0.0.1:1234"); w.Code != http.StatusOK {
+			t.Fatalf("request %d: expected 200, got %d", i+1, w.Code)
+		}
+	}
+}
+
+func TestRateLimitMiddleware_OverLimitReturns429(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	store := NewMemoryRateLimitStore()
+	store.now = func() time.Time { return now }
+	policy := RateLimitPolicy{Name: "test", Requests: 60, Window: time.Minute, Burst: 2}
+	r := newRateLimitedRouter(store, policy, "")
+
+	doGet(r, "10.0.0.1:1234")
+	doGet(r, "10.0.0.1:1234")
+	w := doGet(r, "10.0.0.1:1234")
+	if w.Code != http.StatusTooManyRequests {
+		t.Fatalf("expected 429, got %d", w.Code)
+	}
+	if got := w.Header().Get("Retry-After"); got != "1" {
+		t.Fatalf("expected Retry-After 1, got %q", got)
+	}
+
+	// Another client is unaffected
+	if w := doGet(r, "10.0.0.2:1234"); w.Code != http.StatusOK {
+		t.Fatalf("expected other IP to pass, got %d", w.Code)
+	}
+
+	// Bucket refills over time
+	now = now.Add(time.Second)
+	if w := doGet(r, "10.0.0.1:1234"); w.Code != http.StatusOK {
+		t.Fatalf("expected request after refill to pass, got %d", w.Code)
+	}
+}
+
+func TestMemoryRateLimitStore_EvictsIdleBuckets(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	store := NewMemoryRateLimitStore()
+	store.now = func() time.Time { return now }
+	fast := RateLimitPolicy{Name: "fast", Requests: 60, Window: time.Minute, Burst: 2}
+	slow := RateLimitPolicy{Name: "slow", Requests: 1, Window: time.Hour, Burst: 2}
+	ctx := context.Background()
+
+	store.Take(ctx, "a", fast)
+	store.Take(ctx, "b", slow)
+
+	// "a" refills within seconds, "b" needs two hours
+	now = now.Add(memorySweepInterval)
+	store.Take(ctx, "c", fast)
+
+	if _, ok := store.buckets["a"]; ok {
+		t.Fatalf("expected idle bucket to be evicted")
+	}
+	if _, ok := store.buckets["b"]; !ok {
+		t.Fatalf("expected bucket that has not refilled to be kept")
+	}
+}
+
+func TestRateLimitMiddleware_KeysByUser(t *testing.T) {
+	store := NewMemoryRateLimitStore()
+	policy := RateLimitPolicy{Name: "test", Requests: 1, Window: time.Hour, Burst: 1}
+	alice := newRateLimitedRouter(store, policy, "alice")
+	bob := newRateLimitedRouter(store, policy, "bob")
+
+	if w := doGet(alice, "10.0.0.1:1234"); w.Code != http.StatusOK {
+		t.Fatalf("expected first user request to pass, got %d", w.Code)
+	}
+	// Same IP, different user: separate bucket
+	if w := doGet(bob, "10.0.0.1:1234"); w.Code != http.StatusOK {
+		t.Fatalf("expected second user to pass, got %d", w.Code)
+	}
+	if w := doGet(alice, "10.0.0.9:1234"); w.Code != http.StatusTooManyRequests {
+		t.Fatalf("expected first user to be limited from any IP, got %d", w.Code)
+	}
+}
+
+type failingRateLimitStore struct{}
+
+func (failingRateLimitStore) Take(ctx context.Context, key string, policy RateLimitPolicy) (bool, time.Duration, error) {
+	return false, 0, errors.New("redis down")
+}
+
+func TestRateLimitMiddleware_FailsOpen(t *testing.T) {
+	logger.InitLogger()
+	policy := RateLimitPolicy{Name: "test", Requests: 1, Window: time.Minute, Burst: 1}
+	r := newRateLimitedRouter(failingRateLimitStore{}, policy, "")
+
+	if w := doGet(r, "10.0.0.1:1234"); w.Code != http.StatusOK {
+		t.Fatalf("expected request to pass when store fails, got %d", w.Code)
+	}
+}
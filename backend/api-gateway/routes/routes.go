@@ -1,65 +1,123 @@
 package routes
 
 import (
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"api-gateway/config"
 	"api-gateway/middlewares"
 	"api-gateway/utils"
 
 	"github.com/gin-gonic/gin"
 )
 
-func RegisterAllRoutes(r *gin.Engine) {
-	forwardTo := func(targetBase string) gin.HandlerFunc {
+func RegisterAllRoutes(r *gin.Engine, services config.ServiceURLs, limitStore middlewares.RateLimitStore) {
+	limits := middlewares.LoadRateLimitConfig()
+	authLimit := middlewares.RateLimitMiddleware(limitStore, limits.Auth)
+	productLimit := middlewares.RateLimitMiddleware(limitStore, limits.Products)
+	defaultLimit := middlewares.RateLimitMiddleware(limitStore, limits.Default)
+
+	// Each service may list several addresses for failover
+	productService := utils.NewServiceUpstreamPool("product-service", services["product-service"])
+	authService := utils.NewServiceUpstreamPool("auth-service", services["auth-service"])
+	userService := utils.NewServiceUpstreamPool("user-service", services["user-service"])
+	cartService := utils.NewServiceUpstreamPool("cart-service", services["cart-service"])
+	orderService := utils.NewServiceUpstreamPool("order-service", services["order-service"])
+	paymentService := utils.NewServiceUpstreamPool("payment-service", services["payment-service"])
+
+	metrics := utils.NewEMFMetricsSink(os.Stdout, "ShopSwift/Gateway")
+
+	var upstreamTimeout time.Duration
+	if secs, err := strconv.Atoi(os.Getenv("UPSTREAM_TIMEOUT_SECONDS")); err == nil && secs > 0 {
+		upstreamTimeout = time.Duration(secs) * time.Second
+	}
+
+	forwardTo := func(upstreams *utils.UpstreamPool, basePath string) gin.HandlerFunc {
 		return func(c *gin.Context) {
 			utils.ForwardRequest(c, utils.ForwardOptions{
-				TargetBase: targetBase,
+				Upstreams: upstreams,
+				BasePath:  basePath,
+				Service:   upstreams.Name(),
+				Metrics:   metrics,
+				Timeout:   upstreamTimeout,
 			})
 		}
 	}
 
+	// Every GET route also answers HEAD, and every PUT route also answers
+	// PATCH, with the same auth and rate limits.
+
 	// ===== PUBLIC ROUTES =====
 	public := r.Group("/")
 
-	// Products routes - handle both /products and /products/*
-	products := forwardTo("http://product-service:8082/products")
-	public.GET("/products", products)
-	public.GET("/products/*any", products)
+	// Products routes - handle both /products and /products/*. Signed-in
+	// callers are identified so product views can be recorded.
+	products := forwardTo(productService, "/products")
+	optionalAuth := middlewares.OptionalJWTMiddleware()
+	public.GET("/products", productLimit, products)
+	public.GET("/products/*any", optionalAuth, productLimit, products)
+	public.HEAD("/products", productLimit, products)
+	public.HEAD("/products/*any", optionalAuth, productLimit, products)
 
 	// Categories routes - handle both /categories and /categories/*
-	categories := forwardTo("http://product-service:8082/categories")
-	public.GET("/categories", categories)
-	public.GET("/categories/*any", categories)
+	categories := forwardTo(productService, "/categories")
+	public.GET("/categories", productLimit, categories)
+	public.GET("/categories/*any", productLimit, categories)
+	public.HEAD("/categories", productLimit, categories)
+	public.HEAD("/categories/*any", productLimit, categories)
 
 	// ===== AUTH ROUTES (PUBLIC) =====
 	// ===== PROTECTED ROUTES (JWT Required) =====
 	protected := r.Group("/")
 	protected.Use(middlewares.JWTMiddleware())
+	// Limit after JWT so authenticated traffic is bucketed per user
+	protected.Use(defaultLimit)
 	auth := r.Group("/auth")
-	authProxy := forwardTo("http://auth-service:8081/auth")
+	authProxy := forwardTo(authService, "/auth")
 
 	// Auth routes with wildcard
 	protected.GET("/auth/*any", authProxy)
-	auth.POST("/*any", authProxy)
+	protected.HEAD("/auth/*any", authProxy)
+	auth.POST("/*any", authLimit, authProxy)
 
 	// User routes - handle both /users and /users/*
-	users := forwardTo("http://user-service:8085/users")
+	users := forwardTo(userService, "/users")
 	protected.GET("/users", users)
 	protected.GET("/users/*any", users)
+	protected.HEAD("/users", users)
+	protected.HEAD("/users/*any", users)
 	protected.POST("/users/*any", users)
 	protected.PUT("/users/*any", users)
+	protected.PATCH("/users/*any", users)
 	protected.DELETE("/users/*any", users)
 
 	// Cart routes - handle both /cart and /cart/*
-	cart := forwardTo("http://cart-service:8086/cart")
+	cart := forwardTo(cartService, "/cart")
 	protected.GET("/cart", cart)
 	protected.GET("/cart/*any", cart)
+	protected.HEAD("/cart", cart)
+	protected.HEAD("/cart/*any", cart)
 	protected.POST("/cart/*any", cart)
 	protected.PUT("/cart/*any", cart)
+	protected.PATCH("/cart/*any", cart)
+	protected.DELETE("/cart", cart)
 	protected.DELETE("/cart/*any", cart)
 
+	// Wishlist routes are served by cart-service
+	wishlist := forwardTo(cartService, "/wishlist")
+	protected.GET("/wishlist", wishlist)
+	protected.HEAD("/wishlist", wishlist)
+	protected.POST("/wishlist/*any", wishlist)
+	protected.DELETE("/wishlist/*any", wishlist)
+
 	// Order routes - handle both /orders and /orders/*
-	orders := forwardTo("http://order-service:8083/orders")
+	orders := forwardTo(orderService, "/orders")
 	protected.GET("/orders", orders)
 	protected.GET("/orders/*any", orders)
+	protected.HEAD("/orders", orders)
+	protected.HEAD("/orders/*any", orders)
 	protected.POST("/orders", orders)
 	protected.POST("/orders/*any", orders)
 
@@ -69,26 +127,37 @@ func RegisterAllRoutes(r *gin.Engine) {
 
 	// Admin product routes
 	admin.POST("/products", products)
-	admin.POST("/products/*any", products)
+	// Reviews share the product wildcard but are open to any signed-in user
+	protected.POST("/products/*any", middlewares.AdminRoleUnless(isProductReviewPath), products)
 	admin.PUT("/products/*any", products)
+	admin.PATCH("/products/*any", products)
 	admin.DELETE("/products/*any", products)
 
 	// Admin category routes
 	admin.POST("/categories", categories)
 	admin.POST("/categories/*any", categories)
 	admin.PUT("/categories/*any", categories)
+	admin.PATCH("/categories/*any", categories)
 	admin.DELETE("/categories/*any", categories)
 
 	// Admin order routes
 	admin.PUT("/orders/*any", orders)
+	admin.PATCH("/orders/*any", orders)
 	admin.DELETE("/orders/*any", orders)
 
 	// Payment routes (protected)
-	payment := forwardTo("http://payment-service:8087/payment")
+	payment := forwardTo(paymentService, "/payment")
 	protected.POST("/payment", payment)
 	protected.POST("/payment/*any", payment)
 	protected.GET("/payment/*any", payment)
+	protected.HEAD("/payment/*any", payment)
 
 	// Stripe webhook (public)
-	public.POST("/stripe/webhook", forwardTo("http://payment-service:8087/stripe/webhook"))
+	public.POST("/stripe/webhook", forwardTo(paymentService, "/stripe/webhook"))
+}
+
+// isProductReviewPath matches /products/:id/reviews.
+func isProductReviewPath(c *gin.Context) bool {
+	parts := strings.Split(strings.Trim(c.Param("any"), "/"), "/")
+	return len(parts) == 2 && parts[0] != "" && parts[1] == "reviews"
 }
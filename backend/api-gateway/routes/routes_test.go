@@ -0,0 +1,176 @@
+package routes
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"api-gateway/config"
+	"api-gateway/logger"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v4"
+)
+
+func TestRegisterAllRoutes_ForwardsToConfiguredURL(t *testing.T) {
+	logger.InitLogger()
+	gin.SetMode(gin.TestMode)
+	t.Setenv("JWT_SECRET", "test-secret")
+
+	var gotPath string
+	productService := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.Path
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer productService.Close()
+
+	services := config.ServiceURLs{}
+	for _, name := range config.RequiredServices {
+		services[name] = []string{"http://unused.invalid"}
+	}
+	services["product-service"] = []string{productService.URL}
+
+	r := gin.New()
+	RegisterAllRoutes(r, services, nil)
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/products/123", nil))
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200 from configured upstream, got %d", w.Code)
+	}
+	if gotPath != "/products/123" {
+		t.Fatalf("expected upstream path /products/123, got %q", gotPath)
+	}
+}
+
+func TestRegisterAllRoutes_ReviewsOpenToCustomers(t *testing.T) {
+	logger.InitLogger()
+	gin.SetMode(gin.TestMode)
+	t.Setenv("JWT_SECRET", "test-secret")
+
+	var gotUser string
+	productService := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotUser = r.Header.Get("X-User-ID")
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer productService.Close()
+
+	services := config.ServiceURLs{}
+	for _, name := range config.RequiredServices {
+		services[name] = []string{"http://unused.invalid"}
+	}
+	services["product-service"] = []string{productService.URL}
+
+	r := gin.New()
+	RegisterAllRoutes(r, services, nil)
+
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		"sub": "user-1", "role": "customer", "typ": "access", "exp": time.Now().Add(time.Hour).Unix(),
+	}).SignedString([]byte("test-secret"))
+	if err != nil {
+		t.Fatalf("failed to sign token: %v", err)
+	}
+
+	post := func(path string) int {
+		req := httptest.NewRequest(http.MethodPost, path, nil)
+		req.AddCookie(&http.Cookie{Name: "__session", Value: token})
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		return w.Code
+	}
+
+	if code := post("/products/abc/reviews"); code != http.StatusCreated {
+		t.Fatalf("expected customer review to be forwarded, got %d", code)
+	}
+	if gotUser != "user-1" {
+		t.Fatalf("expected X-User-ID to be forwarded, got %q", gotUser)
+	}
+	if code := post("/products/abc/images/presign"); code != http.StatusForbidden {
+		t.Fatalf("expected other product writes to stay admin-only, got %d", code)
+	}
+}
+
+func TestRegisterAllRoutes_PatchProductForwarded(t *testing.T) {
+	logger.InitLogger()
+	gin.SetMode(gin.TestMode)
+	t.Setenv("JWT_SECRET", "test-secret")
+
+	var gotMethod, gotPath, gotBody string
+	productService := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		gotMethod, gotPath, gotBody = r.Method, r.URL.Path, string(body)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer productService.Close()
+
+	services := config.ServiceURLs{}
+	for _, name := range config.RequiredServices {
+		services[name] = []string{"http://unused.invalid"}
+	}
+	services["product-service"] = []string{productService.URL}
+
+	r := gin.New()
+	RegisterAllRoutes(r, services, nil)
+
+	patch := func(role string) int {
+		token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+			"sub": "user-1", "role": role, "typ": "access", "exp": time.Now().Add(time.Hour).Unix(),
+		}).SignedString([]byte("test-secret"))
+		if err != nil {
+			t.Fatalf("failed to sign token: %v", err)
+		}
+		req := httptest.NewRequest(http.MethodPatch, "/products/abc", strings.NewReader(`{"price":12.5}`))
+		req.AddCookie(&http.Cookie{Name: "__session", Value: token})
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		return w.Code
+	}
+
+	if code := patch("admin"); code != http.StatusOK {
+		t.Fatalf("expected admin PATCH to be forwarded, got %d", code)
+	}
+	if gotMethod != http.MethodPatch || gotPath != "/products/abc" || gotBody != `{"price":12.5}` {
+		t.Fatalf("unexpected upstream request %s %s %q", gotMethod, gotPath, gotBody)
+	}
+
+	gotMethod = ""
+	if code := patch("customer"); code != http.StatusForbidden {
+		t.Fatalf("expected PATCH to stay admin-only like PUT, got %d", code)
+	}
+	if gotMethod != "" {
+		t.Fatal("customer PATCH must not reach the upstream")
+	}
+}
+
+func TestRegisterAllRoutes_HeadProductForwarded(t *testing.T) {
+	logger.InitLogger()
+	gin.SetMode(gin.TestMode)
+	t.Setenv("JWT_SECRET", "test-secret")
+
+	var gotMethod string
+	productService := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotMethod = r.Method
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer productService.Close()
+
+	services := config.ServiceURLs{}
+	for _, name := range config.RequiredServices {
+		services[name] = []string{"http://unused.invalid"}
+	}
+	services["product-service"] = []string{productService.URL}
+
+	r := gin.New()
+	RegisterAllRoutes(r, services, nil)
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodHead, "/products/abc", nil))
+
+	if w.Code != http.StatusOK || gotMethod != http.MethodHead {
+		t.Fatalf("expected HEAD to be forwarded, got %d with upstream method %q", w.Code, gotMethod)
+	}
+}
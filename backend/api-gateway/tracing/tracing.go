@@ -0,0 +1,74 @@
+package tracing
+
+import (
+	"context"
+	"os"
+
+	"api-gateway/logger"
+
+	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const tracerName = "api-gateway"
+
+// Init installs the W3C trace-context propagator and, when
+// OTEL_EXPORTER_OTLP_ENDPOINT is set, a tracer provider exporting spans over
+// OTLP/HTTP. Without an endpoint spans are still propagated but not exported.
+// The returned function flushes pending spans on shutdown.
+func Init(ctx context.Context, serviceName string) (func(context.Context) error, error) {
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(
+		propagation.TraceContext{},
+		propagation.Baggage{},
+	))
+
+	if os.Getenv("OTEL_EXPORTER_OTLP_ENDPOINT") == "" {
+		logger.Log.Info("OTEL_EXPORTER_OTLP_ENDPOINT not set, spans will not be exported")
+		return func(context.Context) error { return nil }, nil
+	}
+
+	// Endpoint, headers and TLS are read from the standard OTEL_* env vars
+	exporter, err := otlptracehttp.New(ctx)
+	if err != nil {
+		return nil, err
+	}
+	res := resource.NewSchemaless(attribute.String("service.name", serviceName))
+	tp := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+	)
+	otel.SetTracerProvider(tp)
+	return tp.Shutdown, nil
+}
+
+// Tracer returns the service's tracer from the global provider.
+func Tracer() trace.Tracer {
+	return otel.Tracer(tracerName)
+}
+
+// Middleware starts the server span for each request, continuing the
+// client's trace if it sent one. The forwarder propagates it upstream.
+func Middleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx := otel.GetTextMapPropagator().Extract(c.Request.Context(), propagation.HeaderCarrier(c.Request.Header))
+		ctx, span := Tracer().Start(ctx, c.Request.Method+" "+c.FullPath(),
+			trace.WithSpanKind(trace.SpanKindServer),
+			trace.WithAttributes(
+				attribute.String("http.method", c.Request.Method),
+				attribute.String("http.route", c.FullPath()),
+			),
+		)
+		defer span.End()
+
+		c.Request = c.Request.WithContext(ctx)
+		c.Next()
+
+		span.SetAttributes(attribute.Int("http.status_code", c.Writer.Status()))
+	}
+}
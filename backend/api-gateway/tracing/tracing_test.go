@@ -0,0 +1,47 @@
+package tracing
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestMiddleware_ContinuesClientTrace(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	prevTP, prevProp := otel.GetTracerProvider(), otel.GetTextMapPropagator()
+	tp := sdktrace.NewTracerProvider()
+	otel.SetTracerProvider(tp)
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	t.Cleanup(func() {
+		otel.SetTracerProvider(prevTP)
+		otel.SetTextMapPropagator(prevProp)
+	})
+
+	var sc trace.SpanContext
+	r := gin.New()
+	r.Use(Middleware())
+	r.GET("/orders", func(c *gin.Context) {
+		sc = trace.SpanContextFromContext(c.Request.Context())
+		c.Status(http.StatusOK)
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/orders", nil)
+	req.Header.Set("traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	r.ServeHTTP(httptest.NewRecorder(), req)
+
+	if !sc.IsValid() || sc.IsRemote() {
+		t.Fatalf("expected a local server span, got %+v", sc)
+	}
+	if sc.TraceID().String() != "4bf92f3577b34da6a3ce929d0e0e4736" {
+		t.Fatalf("expected client trace id, got %s", sc.TraceID())
+	}
+	if sc.SpanID().String() == "00f067aa0ba902b7" {
+		t.Fatal("expected a new span id for the gateway span")
+	}
+}
@@ -0,0 +1,41 @@
+package utils
+
+import (
+	"net/url"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Codes for the errors the gateway produces itself when forwarding fails.
+// Error responses from a service, 5xx included, are passed through untouched,
+// so a body carrying one of these codes always comes from the gateway.
+const (
+	ErrCodeUpstreamUnreachable = "upstream_unreachable" // connection refused, reset, DNS failure
+	ErrCodeUpstreamTimeout     = "upstream_timeout"     // no response within the forward timeout
+	ErrCodeInvalidRequestBody  = "invalid_request_body" // the client's body could not be read
+	ErrCodeGatewayError        = "gateway_error"        // the gateway could not build the request
+)
+
+// ForwardError is the body written when the gateway cannot get a response
+// from a service. Target names the service the request was meant for.
+type ForwardError struct {
+	Error  string `json:"error"`
+	Code   string `json:"code"`
+	Target string `json:"target"`
+}
+
+func writeForwardError(c *gin.Context, status int, code, msg, target string) {
+	c.JSON(status, ForwardError{Error: msg, Code: code, Target: target})
+}
+
+// forwardTarget names the service in error bodies: the configured service
+// name, or the upstream's host when there is none.
+func forwardTarget(opts ForwardOptions, upstream string) string {
+	if opts.Service != "" {
+		return opts.Service
+	}
+	if u, err := url.Parse(upstream); err == nil && u.Host != "" {
+		return u.Host
+	}
+	return upstream
+}
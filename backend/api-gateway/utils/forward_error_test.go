@@ -0,0 +1,94 @@
+package utils
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func decodeForwardError(t *testing.T, w *httptest.ResponseRecorder) ForwardError {
+	t.Helper()
+	var body ForwardError
+	if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+		t.Fatalf("decode body %q: %v", w.Body.String(), err)
+	}
+	return body
+}
+
+func TestForwardRequest_UnreachableUpstreamBody(t *testing.T) {
+	r := newForwardRouter(ForwardOptions{TargetBase: deadUpstream(t), Service: "product-service"})
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/products", nil))
+
+	if w.Code != http.StatusBadGateway {
+		t.Fatalf("expected 502, got %d", w.Code)
+	}
+	got := decodeForwardError(t, w)
+	want := ForwardError{Error: "service unreachable", Code: ErrCodeUpstreamUnreachable, Target: "product-service"}
+	if got != want {
+		t.Fatalf("expected %+v, got %+v", want, got)
+	}
+}
+
+func TestForwardRequest_TimeoutBody(t *testing.T) {
+	release := make(chan struct{})
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+	}))
+	defer upstream.Close()
+	defer close(release)
+
+	pool := NewUpstreamPool([]string{upstream.URL}, 3, time.Minute)
+	r := newForwardRouter(ForwardOptions{Upstreams: pool, BasePath: "/products", Service: "product-service", Timeout: 50 * time.Millisecond})
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/products", nil))
+
+	if w.Code != http.StatusGatewayTimeout {
+		t.Fatalf("expected 504, got %d", w.Code)
+	}
+	got := decodeForwardError(t, w)
+	want := ForwardError{Error: "upstream timed out", Code: ErrCodeUpstreamTimeout, Target: "product-service"}
+	if got != want {
+		t.Fatalf("expected %+v, got %+v", want, got)
+	}
+}
+
+func TestForwardRequest_TargetFallsBackToHost(t *testing.T) {
+	dead := deadUpstream(t)
+	r := newForwardRouter(ForwardOptions{TargetBase: dead})
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/products", nil))
+
+	if got := decodeForwardError(t, w).Target; got != dead[len("http://"):] {
+		t.Fatalf("expected target to be the upstream host, got %q", got)
+	}
+}
+
+func TestForwardRequest_Upstream5xxPassedThrough(t *testing.T) {
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(`{"error":"database down"}`))
+	}))
+	defer upstream.Close()
+
+	r := newForwardRouter(ForwardOptions{TargetBase: upstream.URL, Service: "product-service"})
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/products", nil))
+
+	if w.Code != http.StatusInternalServerError {
+		t.Fatalf("expected the upstream 500, got %d", w.Code)
+	}
+	if got := w.Body.String(); got != `{"error":"database down"}` {
+		t.Fatalf("expected the upstream body unchanged, got %q", got)
+	}
+}
@@ -1,23 +1,54 @@
 package utils
 
 import (
+	"bytes"
+	"context"
+	"errors"
 	"io"
 	"log"
 	"net/http"
 	"strings"
+	"sync/atomic"
 	"time"
 
 	"api-gateway/logger"
 
 	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
 	"go.uber.org/zap"
 )
 
 type ForwardOptions struct {
 	TargetBase  string
 	StripPrefix string
+	// Upstreams, when set, is used instead of TargetBase: each attempt goes to
+	// Candidates()[i] + BasePath, failing over to the next address on
+	// connection errors.
+	Upstreams *UpstreamPool
+	BasePath  string
+	// Service and Metrics tag and receive per-request forward metrics.
+	Service string
+	Metrics MetricsSink
+	// Timeout bounds the wait for upstream response headers, failover
+	// attempts included; once headers arrive the body streams for as long as
+	// the client stays connected. A shorter RequestTimeoutHeader from the
+	// client wins. Defaults to 30s.
+	Timeout time.Duration
 }
 
+const defaultUpstreamTimeout = 30 * time.Second
+
+// RequestTimeoutHeader lets a client ask for a shorter upstream budget than
+// the gateway's own, as a Go duration such as "2s" or "500ms". The budget
+// left when each attempt is sent is passed on to the service in the same
+// header.
+const RequestTimeoutHeader = "X-Request-Timeout"
+
+// maxReplayableBody caps how much of a request body is buffered so it can be
+// replayed against an alternate upstream. Larger bodies get a single attempt.
+const maxReplayableBody = 1 << 20
+
 func ForwardRequest(c *gin.Context, opts ForwardOptions) {
 	// Get the path - handle case where there's no wildcard parameter
 	targetPath := ""
@@ -29,53 +60,122 @@ func ForwardRequest(c *gin.Context, opts ForwardOptions) {
 		targetPath = strings.TrimPrefix(targetPath, opts.StripPrefix)
 	}
 
-	targetURL := opts.TargetBase + targetPath
-	if c.Request.URL.RawQuery != "" {
-		targetURL += "?" + c.Request.URL.RawQuery
+	pool := opts.Upstreams
+	if pool != nil && pool.Len() == 0 {
+		pool = nil
 	}
+	upstreams := []string{opts.TargetBase}
+	if pool != nil {
+		upstreams = pool.Candidates()
+	}
+	target := forwardTarget(opts, upstreams[0])
 
-	logger.Log.Info("🔁 Forwarding request",
-		zap.String("method", c.Request.Method),
-		zap.String("url", targetURL),
-		zap.String("path", targetPath),
-	)
-
-	req, err := http.NewRequest(c.Request.Method, targetURL, c.Request.Body)
-	if err != nil {
-		logger.Log.Error("❌ Failed to create forward request", zap.Error(err))
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to create request"})
-		return
+	// Buffer small bodies so they can be resent on failover
+	var body []byte
+	replayable := len(upstreams) > 1 && c.Request.ContentLength >= 0 && c.Request.ContentLength <= maxReplayableBody
+	if replayable && c.Request.Body != nil {
+		b, err := io.ReadAll(c.Request.Body)
+		if err != nil {
+			logger.Log.Error("❌ Failed to read request body", zap.Error(err))
+			writeForwardError(c, http.StatusBadRequest, ErrCodeInvalidRequestBody, "failed to read request body", target)
+			return
+		}
+		body = b
+	}
+	if !replayable {
+		upstreams = upstreams[:1]
 	}
 
-	// Copy original headers
-	for k, v := range c.Request.Header {
-		req.Header[k] = v
+	// Derive from the inbound context so a client disconnect also cancels
+	// the upstream call. The timer only covers getting response headers and
+	// is stopped before the body is copied, so a long download is not cut
+	// off after the status has been sent.
+	timeout := upstreamTimeout(c.Request, opts.Timeout)
+	deadline := time.Now().Add(timeout)
+	ctx, cancel := context.WithCancel(c.Request.Context())
+	defer cancel()
+	var timedOut atomic.Bool
+	timer := time.AfterFunc(timeout, func() {
+		timedOut.Store(true)
+		cancel()
+	})
+	defer timer.Stop()
+
+	client := &http.Client{}
+
+	start := time.Now()
+	metric := ForwardMetric{
+		Service:      opts.Service,
+		Method:       c.Request.Method,
+		RequestBytes: c.Request.ContentLength,
+	}
+	if replayable {
+		metric.RequestBytes = int64(len(body))
 	}
 
-	// Inject user claims headers for downstream services
-	if userID, exists := c.Get("user_id"); exists {
-		if uid, ok := userID.(string); ok {
-			req.Header.Set("X-User-ID", uid)
+	var resp *http.Response
+	for i, upstream := range upstreams {
+		targetURL := upstream + targetPath
+		if pool != nil {
+			targetURL = upstream + opts.BasePath + targetPath
 		}
-	}
-	if email, exists := c.Get("email"); exists {
-		if e, ok := email.(string); ok {
-			req.Header.Set("X-User-Email", e)
+		if c.Request.URL.RawQuery != "" {
+			targetURL += "?" + c.Request.URL.RawQuery
 		}
-	}
-	if role, exists := c.Get("role"); exists {
-		if r, ok := role.(string); ok {
-			req.Header.Set("X-User-Role", r)
+
+		logger.Log.Info("🔁 Forwarding request",
+			zap.String("method", c.Request.Method),
+			zap.String("url", targetURL),
+			zap.String("path", targetPath),
+			zap.Int("attempt", i+1),
+		)
+
+		var reqBody io.Reader = c.Request.Body
+		if replayable {
+			reqBody = bytes.NewReader(body)
 		}
-	}
+		req, err := http.NewRequestWithContext(ctx, c.Request.Method, targetURL, reqBody)
+		if err != nil {
+			logger.Log.Error("❌ Failed to create forward request", zap.Error(err))
+			writeForwardError(c, http.StatusInternalServerError, ErrCodeGatewayError, "failed to create request", target)
+			return
+		}
+		copyRequestHeaders(c, req)
+		req.Header.Set(RequestTimeoutHeader, time.Until(deadline).Round(time.Millisecond).String())
 
-	client := &http.Client{
-		Timeout: 30 * time.Second,
+		resp, err = client.Do(req)
+		if err == nil {
+			if pool != nil {
+				pool.MarkSuccess(upstream)
+			}
+			break
+		}
+		logger.Log.Error("❌ Failed to forward request", zap.String("url", targetURL), zap.Error(err))
+		if pool != nil {
+			pool.MarkFailure(upstream)
+		}
+		if ctx.Err() != nil {
+			// Deadline spent or client gone: no budget left for another upstream
+			break
+		}
 	}
-	resp, err := client.Do(req)
-	if err != nil {
-		logger.Log.Error("❌ Failed to forward request", zap.Error(err))
-		c.JSON(http.StatusBadGateway, gin.H{"error": "service unreachable"})
+	if resp != nil && !timer.Stop() {
+		// The timer fired just as headers arrived and has already cancelled
+		// the body
+		resp.Body.Close()
+		resp = nil
+	}
+	if resp == nil {
+		status := http.StatusBadGateway
+		if timedOut.Load() || errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			status = http.StatusGatewayTimeout
+			writeForwardError(c, status, ErrCodeUpstreamTimeout, "upstream timed out", target)
+		} else {
+			writeForwardError(c, status, ErrCodeUpstreamUnreachable, "service unreachable", target)
+		}
+		metric.Status = status
+		metric.Latency = time.Since(start)
+		recordForwardMetric(opts.Metrics, metric)
 		return
 	}
 	defer resp.Body.Close()
@@ -89,6 +189,12 @@ func ForwardRequest(c *gin.Context, opts ForwardOptions) {
 			continue
 		}
 
+		// The gateway's RequestID middleware already set this; services
+		// echo the same value
+		if lowerKey == "x-request-id" {
+			continue
+		}
+
 		// Skip hop-by-hop headers (these are not meant to be forwarded)
 		if lowerKey == "connection" || lowerKey == "keep-alive" ||
 			lowerKey == "proxy-authenticate" || lowerKey == "proxy-authorization" ||
@@ -109,8 +215,104 @@ func ForwardRequest(c *gin.Context, opts ForwardOptions) {
 	// Set status AFTER all headers are set
 	c.Status(resp.StatusCode)
 
-	// Copy response body
-	if _, err := io.Copy(c.Writer, resp.Body); err != nil {
+	// Stream the body through rather than buffering it
+	n, err := copyResponseBody(c.Writer, resp)
+	if err != nil {
 		logger.Log.Error("❌ Failed to copy response body", zap.Error(err))
 	}
+
+	metric.Status = resp.StatusCode
+	metric.ResponseBytes = n
+	metric.Latency = time.Since(start)
+	recordForwardMetric(opts.Metrics, metric)
+}
+
+// upstreamTimeout returns the budget for reaching an upstream: the configured
+// timeout, shortened by a valid RequestTimeoutHeader or by a deadline already
+// on the request context. A client can only shorten the budget, never extend
+// it.
+func upstreamTimeout(r *http.Request, configured time.Duration) time.Duration {
+	timeout := configured
+	if timeout <= 0 {
+		timeout = defaultUpstreamTimeout
+	}
+	if v := r.Header.Get(RequestTimeoutHeader); v != "" {
+		if d, err := time.ParseDuration(v); err == nil && d > 0 && d < timeout {
+			timeout = d
+		}
+	}
+	if dl, ok := r.Context().Deadline(); ok {
+		if d := time.Until(dl); d < timeout {
+			timeout = d
+		}
+	}
+	return timeout
+}
+
+// internalHeaders carry identity that services trust without checking. They
+// are only ever set by the gateway from a verified token, never taken from
+// the client.
+var internalHeaders = map[string]bool{
+	"X-User-Id":    true,
+	"X-User-Email": true,
+	"X-User-Role":  true,
+	"X-Role":       true,
+}
+
+// copyRequestHeaders copies the inbound headers onto req, minus any
+// client-supplied internal headers, and injects the authenticated user's
+// claims and the gateway's trace context for downstream services.
+func copyRequestHeaders(c *gin.Context, req *http.Request) {
+	for k, v := range c.Request.Header {
+		if internalHeaders[http.CanonicalHeaderKey(k)] {
+			continue
+		}
+		req.Header[k] = v
+	}
+	otel.GetTextMapPropagator().Inject(req.Context(), propagation.HeaderCarrier(req.Header))
+
+	if userID, exists := c.Get("user_id"); exists {
+		if uid, ok := userID.(string); ok {
+			req.Header.Set("X-User-ID", uid)
+		}
+	}
+	if email, exists := c.Get("email"); exists {
+		if e, ok := email.(string); ok {
+			req.Header.Set("X-User-Email", e)
+		}
+	}
+	if role, exists := c.Get("role"); exists {
+		if r, ok := role.(string); ok {
+			req.Header.Set("X-User-Role", r)
+		}
+	}
+}
+
+// copyResponseBody streams resp.Body to w. Responses of unknown length
+// (chunked, server-sent events) are flushed after every read so the client
+// sees data as soon as the upstream produces it.
+func copyResponseBody(w gin.ResponseWriter, resp *http.Response) (int64, error) {
+	if resp.ContentLength != -1 {
+		return io.Copy(w, resp.Body)
+	}
+
+	buf := make([]byte, 32*1024)
+	var written int64
+	for {
+		nr, rerr := resp.Body.Read(buf)
+		if nr > 0 {
+			nw, werr := w.Write(buf[:nr])
+			written += int64(nw)
+			if werr != nil {
+				return written, werr
+			}
+			w.Flush()
+		}
+		if rerr == io.EOF {
+			return written, nil
+		}
+		if rerr != nil {
+			return written, rerr
+		}
+	}
 }
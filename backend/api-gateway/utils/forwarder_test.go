@@ -0,0 +1,444 @@
+package utils
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"api-gateway/logger"
+
+	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+func init() {
+	logger.InitLogger()
+	gin.SetMode(gin.TestMode)
+}
+
+// deadUpstream returns the address of a server that has already been shut
+// down, so connections to it are refused.
+func deadUpstream(t *testing.T) string {
+	t.Helper()
+	srv := httptest.NewServer(http.NotFoundHandler())
+	addr := srv.URL
+	srv.Close()
+	return addr
+}
+
+func newForwardRouter(opts ForwardOptions) *gin.Engine {
+	r := gin.New()
+	handler := func(c *gin.Context) { ForwardRequest(c, opts) }
+	r.Any("/products", handler)
+	r.Any("/products/*any", handler)
+	return r
+}
+
+func TestForwardRequest_FailsOverToHealthyUpstream(t *testing.T) {
+	var gotPath, gotBody string
+	healthy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.Path
+		b, _ := io.ReadAll(r.Body)
+		gotBody = string(b)
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte("ok"))
+	}))
+	defer healthy.Close()
+
+	dead := deadUpstream(t)
+	pool := NewUpstreamPool([]string{dead, healthy.URL}, 3, time.Minute)
+	r := newForwardRouter(ForwardOptions{Upstreams: pool, BasePath: "/products"})
+
+	req := httptest.NewRequest(http.MethodPost, "/products/abc", strings.NewReader(`{"name":"x"}`))
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	if w.Code != http.StatusCreated {
+		t.Fatalf("expected 201 via healthy upstream, got %d", w.Code)
+	}
+	if gotPath != "/products/abc" {
+		t.Fatalf("expected upstream path /products/abc, got %q", gotPath)
+	}
+	if gotBody != `{"name":"x"}` {
+		t.Fatalf("expected body to be replayed, got %q", gotBody)
+	}
+}
+
+func TestForwardRequest_AllUpstreamsDown(t *testing.T) {
+	pool := NewUpstreamPool([]string{deadUpstream(t), deadUpstream(t)}, 3, time.Minute)
+	r := newForwardRouter(ForwardOptions{Upstreams: pool, BasePath: "/products"})
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/products", nil))
+
+	if w.Code != http.StatusBadGateway {
+		t.Fatalf("expected 502, got %d", w.Code)
+	}
+}
+
+func TestUpstreamPool_EjectsAndRecovers(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	pool := NewUpstreamPool([]string{"http://a", "http://b"}, 2, 30*time.Second)
+	pool.now = func() time.Time { return now }
+
+	pool.MarkFailure("http://a")
+	pool.MarkFailure("http://a")
+
+	// a is ejected: b is always tried first, a only as a last resort
+	for i := 0; i < 4; i++ {
+		got := pool.Candidates()
+		if len(got) != 2 || got[0] != "http://b" || got[1] != "http://a" {
+			t.Fatalf("expected [b a] while a is ejected, got %v", got)
+		}
+	}
+
+	now = now.Add(31 * time.Second)
+	seenFirst := map[string]bool{}
+	for i := 0; i < 2; i++ {
+		seenFirst[pool.Candidates()[0]] = true
+	}
+	if !seenFirst["http://a"] || !seenFirst["http://b"] {
+		t.Fatalf("expected a to rejoin rotation after cooldown, got %v", seenFirst)
+	}
+}
+
+func TestUpstreamPool_SuccessResetsFailures(t *testing.T) {
+	pool := NewUpstreamPool([]string{"http://a", "http://b"}, 2, time.Minute)
+
+	pool.MarkFailure("http://a")
+	pool.MarkSuccess("http://a")
+	pool.MarkFailure("http://a")
+
+	pool.mu.Lock()
+	ejected := !pool.targets[0].ejectedUntil.IsZero()
+	pool.mu.Unlock()
+	if ejected {
+		t.Fatalf("expected a not to be ejected after success reset the failure count")
+	}
+}
+
+func TestForwardRequest_StreamsChunkedResponse(t *testing.T) {
+	release := make(chan struct{})
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("first-chunk"))
+		w.(http.Flusher).Flush()
+		<-release
+		_, _ = w.Write([]byte("second-chunk"))
+	}))
+	defer upstream.Close()
+	defer func() {
+		select {
+		case <-release:
+		default:
+			close(release)
+		}
+	}()
+
+	pool := NewUpstreamPool([]string{upstream.URL}, 3, time.Minute)
+	gateway := httptest.NewServer(newForwardRouter(ForwardOptions{Upstreams: pool, BasePath: "/products"}))
+	defer gateway.Close()
+
+	resp, err := http.Get(gateway.URL + "/products/export")
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	defer resp.Body.Close()
+
+	// The first chunk must arrive while the upstream is still blocked; a
+	// buffering gateway would hold it until the upstream finished.
+	first := make([]byte, len("first-chunk"))
+	done := make(chan error, 1)
+	go func() {
+		_, err := io.ReadFull(resp.Body, first)
+		done <- err
+	}()
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatalf("failed to read first chunk: %v", err)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatalf("first chunk not received before upstream completed; response is being buffered")
+	}
+	if string(first) != "first-chunk" {
+		t.Fatalf("unexpected first chunk %q", first)
+	}
+
+	close(release)
+	rest, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("failed to read rest of body: %v", err)
+	}
+	if string(rest) != "second-chunk" {
+		t.Fatalf("unexpected remainder %q", rest)
+	}
+}
+
+func TestForwardRequest_LargeBodyIntact(t *testing.T) {
+	payload := bytes.Repeat([]byte("0123456789abcdef"), 512*1024) // 8MB
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/octet-stream")
+		w.Header().Set("X-Export-Id", "42")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write(payload)
+	}))
+	defer upstream.Close()
+
+	pool := NewUpstreamPool([]string{upstream.URL}, 3, time.Minute)
+	gateway := httptest.NewServer(newForwardRouter(ForwardOptions{Upstreams: pool, BasePath: "/products"}))
+	defer gateway.Close()
+
+	resp, err := http.Get(gateway.URL + "/products/export")
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK || resp.Header.Get("X-Export-Id") != "42" {
+		t.Fatalf("status/headers not preserved: %d %v", resp.StatusCode, resp.Header)
+	}
+	got, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("failed to read body: %v", err)
+	}
+	if !bytes.Equal(got, payload) {
+		t.Fatalf("body corrupted: got %d bytes, want %d", len(got), len(payload))
+	}
+}
+
+func TestForwardRequest_SlowUpstreamReturns504(t *testing.T) {
+	release := make(chan struct{})
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+	}))
+	defer upstream.Close()
+	defer close(release)
+
+	pool := NewUpstreamPool([]string{upstream.URL}, 3, time.Minute)
+	r := newForwardRouter(ForwardOptions{Upstreams: pool, BasePath: "/products", Timeout: 50 * time.Millisecond})
+
+	start := time.Now()
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/products", nil))
+
+	if w.Code != http.StatusGatewayTimeout {
+		t.Fatalf("expected 504, got %d", w.Code)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Fatalf("expected timeout to cut the request short, took %v", elapsed)
+	}
+}
+
+func TestForwardRequest_InheritsIncomingDeadline(t *testing.T) {
+	release := make(chan struct{})
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+	}))
+	defer upstream.Close()
+	defer close(release)
+
+	pool := NewUpstreamPool([]string{upstream.URL}, 3, time.Minute)
+	r := newForwardRouter(ForwardOptions{Upstreams: pool, BasePath: "/products", Timeout: time.Minute})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	req := httptest.NewRequest(http.MethodGet, "/products", nil).WithContext(ctx)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	if w.Code != http.StatusGatewayTimeout {
+		t.Fatalf("expected incoming deadline to yield 504, got %d", w.Code)
+	}
+}
+
+func TestForwardRequest_ClientTimeoutHeaderShortensBudget(t *testing.T) {
+	release := make(chan struct{})
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+	}))
+	defer upstream.Close()
+	defer close(release)
+
+	pool := NewUpstreamPool([]string{upstream.URL}, 3, time.Minute)
+	r := newForwardRouter(ForwardOptions{Upstreams: pool, BasePath: "/products", Timeout: time.Minute})
+
+	start := time.Now()
+	req := httptest.NewRequest(http.MethodGet, "/products", nil)
+	req.Header.Set(RequestTimeoutHeader, "50ms")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	if w.Code != http.StatusGatewayTimeout {
+		t.Fatalf("expected client timeout to yield 504, got %d", w.Code)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Fatalf("expected client timeout to cut the request short, took %v", elapsed)
+	}
+}
+
+func TestForwardRequest_PropagatesRemainingTimeout(t *testing.T) {
+	var got string
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r.Header.Get(RequestTimeoutHeader)
+	}))
+	defer upstream.Close()
+
+	r := newForwardRouter(ForwardOptions{TargetBase: upstream.URL, Timeout: 10 * time.Second})
+	req := httptest.NewRequest(http.MethodGet, "/products", nil)
+	// Longer than the gateway allows, so it must not be passed on as is
+	req.Header.Set(RequestTimeoutHeader, "1h")
+	r.ServeHTTP(httptest.NewRecorder(), req)
+
+	d, err := time.ParseDuration(got)
+	if err != nil || d <= 0 || d > 10*time.Second {
+		t.Fatalf("expected remaining budget of at most 10s upstream, got %q", got)
+	}
+}
+
+func TestForwardRequest_TimeoutDoesNotCutOffBody(t *testing.T) {
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("first-chunk"))
+		w.(http.Flusher).Flush()
+		// Keep streaming well past the header timeout
+		time.Sleep(200 * time.Millisecond)
+		_, _ = w.Write([]byte("second-chunk"))
+	}))
+	defer upstream.Close()
+
+	gateway := httptest.NewServer(newForwardRouter(ForwardOptions{TargetBase: upstream.URL, Timeout: 50 * time.Millisecond}))
+	defer gateway.Close()
+
+	resp, err := http.Get(gateway.URL + "/products/export")
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("failed to read body: %v", err)
+	}
+	if resp.StatusCode != http.StatusOK || string(body) != "first-chunksecond-chunk" {
+		t.Fatalf("expected the full body after the header timeout, got %d %q", resp.StatusCode, body)
+	}
+}
+
+func TestForwardRequest_PropagatesTraceContext(t *testing.T) {
+	prev := otel.GetTextMapPropagator()
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	t.Cleanup(func() { otel.SetTextMapPropagator(prev) })
+	tp := sdktrace.NewTracerProvider()
+
+	var gotTraceparent string
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotTraceparent = r.Header.Get("traceparent")
+	}))
+	defer upstream.Close()
+
+	r := gin.New()
+	var traceID string
+	r.GET("/products", func(c *gin.Context) {
+		ctx, span := tp.Tracer("test").Start(c.Request.Context(), "GET /products")
+		defer span.End()
+		traceID = span.SpanContext().TraceID().String()
+		c.Request = c.Request.WithContext(ctx)
+		ForwardRequest(c, ForwardOptions{TargetBase: upstream.URL + "/products"})
+	})
+
+	r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/products", nil))
+
+	if !strings.Contains(gotTraceparent, traceID) {
+		t.Fatalf("expected upstream traceparent with trace %s, got %q", traceID, gotTraceparent)
+	}
+}
+
+func TestForwardRequest_ReplacesSpoofedIdentityHeaders(t *testing.T) {
+	var got http.Header
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r.Header.Clone()
+	}))
+	defer upstream.Close()
+
+	r := gin.New()
+	r.GET("/products", func(c *gin.Context) {
+		// What JWTMiddleware sets for a verified token
+		c.Set("user_id", "real-user")
+		c.Set("role", "user")
+		ForwardRequest(c, ForwardOptions{TargetBase: upstream.URL})
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/products", nil)
+	req.Header.Set("X-User-ID", "someone-else")
+	req.Header.Set("X-User-Role", "admin")
+	req.Header.Set("X-Role", "admin")
+	req.Header.Set("X-Request-ID", "req-1")
+	r.ServeHTTP(httptest.NewRecorder(), req)
+
+	if got.Get("X-User-ID") != "real-user" || len(got.Values("X-User-ID")) != 1 {
+		t.Fatalf("expected X-User-ID from the token, got %v", got.Values("X-User-ID"))
+	}
+	if got.Get("X-User-Role") != "user" || got.Get("X-Role") != "" {
+		t.Fatalf("expected spoofed roles to be dropped, got X-User-Role=%q X-Role=%q", got.Get("X-User-Role"), got.Get("X-Role"))
+	}
+	if got.Get("X-Request-ID") != "req-1" {
+		t.Fatalf("expected ordinary headers to pass through, got %q", got.Get("X-Request-ID"))
+	}
+}
+
+func TestForwardRequest_StripsIdentityHeadersOnPublicRoutes(t *testing.T) {
+	var got http.Header
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r.Header.Clone()
+	}))
+	defer upstream.Close()
+
+	r := newForwardRouter(ForwardOptions{TargetBase: upstream.URL})
+	req := httptest.NewRequest(http.MethodGet, "/products", nil)
+	req.Header.Set("X-User-ID", "someone-else")
+	req.Header.Set("X-User-Email", "victim@example.com")
+	r.ServeHTTP(httptest.NewRecorder(), req)
+
+	if got.Get("X-User-ID") != "" || got.Get("X-User-Email") != "" {
+		t.Fatalf("unauthenticated request must not carry identity headers, got %v", got)
+	}
+}
+
+func TestForwardRequest_RequestIDEchoedOnce(t *testing.T) {
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Services echo the ID they were given
+		w.Header().Set("X-Request-ID", r.Header.Get("X-Request-ID"))
+	}))
+	defer upstream.Close()
+
+	r := gin.New()
+	r.GET("/products", func(c *gin.Context) {
+		// What the RequestID middleware does before forwarding
+		c.Header("X-Request-ID", "req-1")
+		ForwardRequest(c, ForwardOptions{TargetBase: upstream.URL})
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/products", nil)
+	req.Header.Set("X-Request-ID", "req-1")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	if got := w.Header().Values("X-Request-ID"); len(got) != 1 || got[0] != "req-1" {
+		t.Fatalf("expected a single X-Request-ID req-1, got %v", got)
+	}
+}
@@ -0,0 +1,93 @@
+package utils
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"sync"
+	"time"
+
+	"api-gateway/logger"
+
+	"go.uber.org/zap"
+)
+
+// ForwardMetric describes one proxied request.
+type ForwardMetric struct {
+	Service       string
+	Method        string
+	Status        int
+	Latency       time.Duration
+	RequestBytes  int64
+	ResponseBytes int64
+}
+
+// MetricsSink receives forward metrics. Implementations should be cheap; the
+// forwarder calls Record inline after the response has been written.
+type MetricsSink interface {
+	Record(m ForwardMetric) error
+}
+
+// recordForwardMetric emits m without letting a broken sink affect the request.
+func recordForwardMetric(sink MetricsSink, m ForwardMetric) {
+	if sink == nil {
+		return
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			logger.Log.Warn("Metrics sink panicked", zap.Any("panic", r))
+		}
+	}()
+	if err := sink.Record(m); err != nil {
+		logger.Log.Warn("Failed to record forward metric", zap.String("service", m.Service), zap.Error(err))
+	}
+}
+
+// EMFMetricsSink writes metrics in CloudWatch Embedded Metric Format, one JSON
+// document per line. When the gateway's stdout is shipped to CloudWatch Logs
+// these are extracted into metrics without any API calls from the gateway.
+type EMFMetricsSink struct {
+	mu        sync.Mutex
+	w         io.Writer
+	namespace string
+	now       func() time.Time
+}
+
+func NewEMFMetricsSink(w io.Writer, namespace string) *EMFMetricsSink {
+	return &EMFMetricsSink{w: w, namespace: namespace, now: time.Now}
+}
+
+func (s *EMFMetricsSink) Record(m ForwardMetric) error {
+	doc := map[string]interface{}{
+		"_aws": map[string]interface{}{
+			"Timestamp": s.now().UnixMilli(),
+			"CloudWatchMetrics": []map[string]interface{}{{
+				"Namespace":  s.namespace,
+				"Dimensions": [][]string{{"Service", "Method"}, {"Service", "Method", "Status"}},
+				"Metrics": []map[string]string{
+					{"Name": "Latency", "Unit": "Milliseconds"},
+					{"Name": "RequestBytes", "Unit": "Bytes"},
+					{"Name": "ResponseBytes", "Unit": "Bytes"},
+				},
+			}},
+		},
+		"Service":       m.Service,
+		"Method":        m.Method,
+		"Status":        strconv.Itoa(m.Status),
+		"Latency":       float64(m.Latency.Microseconds()) / 1000,
+		"RequestBytes":  m.RequestBytes,
+		"ResponseBytes": m.ResponseBytes,
+	}
+	b, err := json.Marshal(doc)
+	if err != nil {
+		return fmt.Errorf("marshal metric: %w", err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, err := s.w.Write(append(b, '\n')); err != nil {
+		return fmt.Errorf("write metric: %w", err)
+	}
+	return nil
+}
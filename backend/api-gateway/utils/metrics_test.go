@@ -0,0 +1,120 @@
+package utils
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+type mockMetricsSink struct {
+	metrics []ForwardMetric
+	err     error
+}
+
+func (m *mockMetricsSink) Record(metric ForwardMetric) error {
+	m.metrics = append(m.metrics, metric)
+	return m.err
+}
+
+func TestForwardRequest_RecordsMetrics(t *testing.T) {
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusAccepted)
+		_, _ = w.Write([]byte("0123456789"))
+	}))
+	defer upstream.Close()
+
+	sink := &mockMetricsSink{}
+	pool := NewUpstreamPool([]string{upstream.URL}, 3, time.Minute)
+	r := newForwardRouter(ForwardOptions{Upstreams: pool, BasePath: "/products", Service: "product-service", Metrics: sink})
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodPut, "/products/1", strings.NewReader("abcd")))
+
+	if len(sink.metrics) != 1 {
+		t.Fatalf("expected 1 metric, got %d", len(sink.metrics))
+	}
+	m := sink.metrics[0]
+	if m.Service != "product-service" || m.Method != http.MethodPut {
+		t.Fatalf("unexpected dimensions: service=%q method=%q", m.Service, m.Method)
+	}
+	if m.Status != http.StatusAccepted {
+		t.Fatalf("expected status 202, got %d", m.Status)
+	}
+	if m.RequestBytes != 4 || m.ResponseBytes != 10 {
+		t.Fatalf("unexpected sizes: request=%d response=%d", m.RequestBytes, m.ResponseBytes)
+	}
+	if m.Latency <= 0 {
+		t.Fatalf("expected positive latency, got %v", m.Latency)
+	}
+}
+
+func TestForwardRequest_RecordsMetricsOnUpstreamFailure(t *testing.T) {
+	sink := &mockMetricsSink{}
+	pool := NewUpstreamPool([]string{deadUpstream(t)}, 3, time.Minute)
+	r := newForwardRouter(ForwardOptions{Upstreams: pool, BasePath: "/products", Service: "product-service", Metrics: sink})
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/products", nil))
+
+	if len(sink.metrics) != 1 || sink.metrics[0].Status != http.StatusBadGateway {
+		t.Fatalf("expected a 502 metric, got %+v", sink.metrics)
+	}
+}
+
+func TestForwardRequest_MetricsErrorIsNonFatal(t *testing.T) {
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("ok"))
+	}))
+	defer upstream.Close()
+
+	sink := &mockMetricsSink{err: errors.New("sink unavailable")}
+	pool := NewUpstreamPool([]string{upstream.URL}, 3, time.Minute)
+	r := newForwardRouter(ForwardOptions{Upstreams: pool, BasePath: "/products", Service: "product-service", Metrics: sink})
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/products", nil))
+
+	if w.Code != http.StatusOK || w.Body.String() != "ok" {
+		t.Fatalf("expected response to be unaffected by sink error, got %d %q", w.Code, w.Body.String())
+	}
+}
+
+func TestEMFMetricsSink_WritesDimensions(t *testing.T) {
+	var buf bytes.Buffer
+	sink := NewEMFMetricsSink(&buf, "Test/Gateway")
+
+	err := sink.Record(ForwardMetric{
+		Service:       "cart-service",
+		Method:        http.MethodGet,
+		Status:        200,
+		Latency:       1500 * time.Microsecond,
+		ResponseBytes: 42,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var doc map[string]interface{}
+	if err := json.Unmarshal(buf.Bytes(), &doc); err != nil {
+		t.Fatalf("expected JSON line, got %q: %v", buf.String(), err)
+	}
+	if doc["Service"] != "cart-service" || doc["Method"] != "GET" || doc["Status"] != "200" {
+		t.Fatalf("unexpected dimensions: %v", doc)
+	}
+	if doc["Latency"] != 1.5 || doc["ResponseBytes"] != float64(42) {
+		t.Fatalf("unexpected values: latency=%v bytes=%v", doc["Latency"], doc["ResponseBytes"])
+	}
+	aws, ok := doc["_aws"].(map[string]interface{})
+	if !ok {
+		t.Fatalf("missing _aws metadata: %v", doc)
+	}
+	cw := aws["CloudWatchMetrics"].([]interface{})[0].(map[string]interface{})
+	if cw["Namespace"] != "Test/Gateway" {
+		t.Fatalf("unexpected namespace: %v", cw["Namespace"])
+	}
+}
@@ -0,0 +1,131 @@
+package utils
+
+import (
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	defaultMaxFailures = 3
+	defaultCooldown    = 30 * time.Second
+)
+
+// UpstreamPool is a set of interchangeable addresses for one downstream service
+// with passive health tracking: an address is ejected after MaxFailures
+// consecutive failures and becomes eligible again once Cooldown has passed.
+type UpstreamPool struct {
+	name        string
+	mu          sync.Mutex
+	targets     []*upstreamTarget
+	next        int
+	maxFailures int
+	cooldown    time.Duration
+	now         func() time.Time
+}
+
+type upstreamTarget struct {
+	base         string
+	failures     int
+	ejectedUntil time.Time
+}
+
+func NewUpstreamPool(bases []string, maxFailures int, cooldown time.Duration) *UpstreamPool {
+	if maxFailures <= 0 {
+		maxFailures = defaultMaxFailures
+	}
+	if cooldown <= 0 {
+		cooldown = defaultCooldown
+	}
+	p := &UpstreamPool{maxFailures: maxFailures, cooldown: cooldown, now: time.Now}
+	for _, b := range bases {
+		b = strings.TrimRight(strings.TrimSpace(b), "/")
+		if b != "" {
+			p.targets = append(p.targets, &upstreamTarget{base: b})
+		}
+	}
+	return p
+}
+
+// NewServiceUpstreamPool builds a pool for the named service. Health
+// thresholds come from UPSTREAM_MAX_FAILURES and UPSTREAM_COOLDOWN_SECONDS.
+func NewServiceUpstreamPool(name string, bases []string) *UpstreamPool {
+	maxFailures, _ := strconv.Atoi(os.Getenv("UPSTREAM_MAX_FAILURES"))
+	cooldownSecs, _ := strconv.Atoi(os.Getenv("UPSTREAM_COOLDOWN_SECONDS"))
+	p := NewUpstreamPool(bases, maxFailures, time.Duration(cooldownSecs)*time.Second)
+	p.name = name
+	return p
+}
+
+// Name returns the service name the pool was created for.
+func (p *UpstreamPool) Name() string {
+	return p.name
+}
+
+// Len returns the number of configured addresses.
+func (p *UpstreamPool) Len() int {
+	return len(p.targets)
+}
+
+// Candidates returns addresses in the order they should be tried: healthy
+// ones round-robin first, then ejected ones as a last resort so a fully
+// ejected pool still gets probed rather than failing outright.
+func (p *UpstreamPool) Candidates() []string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	n := len(p.targets)
+	if n == 0 {
+		return nil
+	}
+	now := p.now()
+	start := p.next
+	p.next = (p.next + 1) % n
+
+	healthy := make([]string, 0, n)
+	var ejected []string
+	for i := 0; i < n; i++ {
+		t := p.targets[(start+i)%n]
+		if now.Before(t.ejectedUntil) {
+			ejected = append(ejected, t.base)
+			continue
+		}
+		healthy = append(healthy, t.base)
+	}
+	return append(healthy, ejected...)
+}
+
+// MarkSuccess resets the failure count for base.
+func (p *UpstreamPool) MarkSuccess(base string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if t := p.find(base); t != nil {
+		t.failures = 0
+		t.ejectedUntil = time.Time{}
+	}
+}
+
+// MarkFailure records a failed attempt against base, ejecting it once the
+// consecutive failure threshold is reached.
+func (p *UpstreamPool) MarkFailure(base string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if t := p.find(base); t != nil {
+		t.failures++
+		if t.failures >= p.maxFailures {
+			t.ejectedUntil = p.now().Add(p.cooldown)
+			t.failures = 0
+		}
+	}
+}
+
+func (p *UpstreamPool) find(base string) *upstreamTarget {
+	for _, t := range p.targets {
+		if t.base == base {
+			return t
+		}
+	}
+	return nil
+}
@@ -75,6 +75,7 @@ _aws sqs create-queue --queue-name order-processing-dlq || true
 _aws sqs create-queue --queue-name email-notify-queue || true
 _aws sqs create-queue --queue-name payment-events-queue || true
 _aws sqs create-queue --queue-name payment-request-queue || true
+_aws sqs create-queue --queue-name shipment-events-queue || true
 
 # Subscribe SQS to SNS
 echo "Subscribing queues to SNS"
@@ -107,6 +108,8 @@ done
 _aws dynamodb create-table --table-name Products --attribute-definitions AttributeName=product_id,AttributeType=S --key-schema AttributeName=product_id,KeyType=HASH --billing-mode PAY_PER_REQUEST || true
 _aws dynamodb create-table --table-name Inventory --attribute-definitions AttributeName=product_id,AttributeType=S --key-schema AttributeName=product_id,KeyType=HASH --billing-mode PAY_PER_REQUEST || true
 _aws dynamodb create-table --table-name Categories --attribute-definitions AttributeName=category_id,AttributeType=S --key-schema AttributeName=category_id,KeyType=HASH --billing-mode PAY_PER_REQUEST || true
+_aws dynamodb create-table --table-name Reviews --attribute-definitions AttributeName=product_id,AttributeType=S AttributeName=user_id,AttributeType=S --key-schema AttributeName=product_id,KeyType=HASH AttributeName=user_id,KeyType=RANGE --billing-mode PAY_PER_REQUEST || true
+_aws dynamodb create-table --table-name ProductPriceHistory --attribute-definitions AttributeName=product_id,AttributeType=S AttributeName=change_id,AttributeType=S --key-schema AttributeName=product_id,KeyType=HASH AttributeName=change_id,KeyType=RANGE --billing-mode PAY_PER_REQUEST || true
 # Seed initial categories into DynamoDB
 echo "Seeding initial categories into DynamoDB"
 _aws dynamodb put-item --table-name Categories --item '{"category_id": {"S": "cat-electronics"}, "name": {"S": "Electronics"}}' || true
@@ -10,6 +10,7 @@ import (
 	"auth-service/services"
 
 	"github.com/gin-gonic/gin"
+	"github.com/yashrajoria/common/httputil"
 )
 
 type IAuthService interface {
@@ -35,13 +36,13 @@ func (ctrl *AuthController) Login(c *gin.Context) {
 		Password string `json:"password" binding:"required"`
 	}
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body", "details": err.Error()})
+		httputil.RespondErrorDetails(c, http.StatusBadRequest, "Invalid request body", err.Error())
 		return
 	}
 
 	tokenPair, err := ctrl.service.Login(c.Request.Context(), req.Email, req.Password)
 	if err != nil {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		httputil.RespondError(c, http.StatusUnauthorized, err.Error())
 		return
 	}
 
@@ -71,28 +72,28 @@ func (ctrl *AuthController) Register(c *gin.Context) {
 		Role     string `json:"role" binding:"required"`
 	}
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body", "details": err.Error()})
+		httputil.RespondErrorDetails(c, http.StatusBadRequest, "Invalid request body", err.Error())
 		return
 	}
 
 	// Validate password strength before proceeding
 	pwValidator := services.NewPasswordValidator()
 	if err := pwValidator.ValidatePassword(req.Password); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		httputil.RespondError(c, http.StatusBadRequest, err.Error())
 		return
 	}
 
 	err := ctrl.service.Register(c.Request.Context(), req.Name, req.Email, req.Password, req.Role)
 	if err != nil {
 		if strings.Contains(err.Error(), "already exists") {
-			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+			httputil.RespondError(c, http.StatusConflict, err.Error())
 			return
 		}
 		if strings.Contains(err.Error(), "failed to send verification email") {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "Account created, but failed to send verification email. Please try verifying later."})
+			httputil.RespondError(c, http.StatusInternalServerError, "Account created, but failed to send verification email. Please try verifying later.")
 			return
 		}
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Could not create account at this time."})
+		httputil.RespondError(c, http.StatusInternalServerError, "Could not create account at this time.")
 		return
 	}
 
@@ -105,21 +106,21 @@ func (ctrl *AuthController) VerifyEmail(c *gin.Context) {
 		Code  string `json:"code" binding:"required"`
 	}
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+		httputil.RespondError(c, http.StatusBadRequest, "Invalid request body")
 		return
 	}
 
 	err := ctrl.service.VerifyEmail(c.Request.Context(), req.Email, req.Code)
 	if err != nil {
 		if strings.Contains(err.Error(), "not found") {
-			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			httputil.RespondError(c, http.StatusNotFound, err.Error())
 			return
 		}
 		if strings.Contains(err.Error(), "invalid") {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+			httputil.RespondError(c, http.StatusUnauthorized, err.Error())
 			return
 		}
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to verify email"})
+		httputil.RespondError(c, http.StatusInternalServerError, "Failed to verify email")
 		return
 	}
 
@@ -147,7 +148,7 @@ func (ctrl *AuthController) Logout(c *gin.Context) {
 func (ctrl *AuthController) Refresh(c *gin.Context) {
 	refreshToken, err := c.Cookie("refresh_token")
 	if err != nil {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "Refresh token not found"})
+		httputil.RespondError(c, http.StatusUnauthorized, "Refresh token not found")
 		return
 	}
 
@@ -187,7 +188,7 @@ func (ctrl *AuthController) GetAuthStatus(c *gin.Context) {
 	role := c.GetHeader("X-User-Role")
 
 	if userID == "" {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "Not authenticated"})
+		httputil.RespondError(c, http.StatusUnauthorized, "Not authenticated")
 		return
 	}
 
@@ -207,25 +208,25 @@ func (ctrl *AuthController) ResendVerificationEmail(c *gin.Context) {
 		Email string `json:"email" binding:"required,email"`
 	}
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body", "details": err.Error()})
+		httputil.RespondErrorDetails(c, http.StatusBadRequest, "Invalid request body", err.Error())
 		return
 	}
 
 	err := ctrl.service.ResendVerificationEmail(c.Request.Context(), req.Email)
 	if err != nil {
 		if strings.Contains(err.Error(), "not found") {
-			c.JSON(http.StatusNotFound, gin.H{"error": "User not found"})
+			httputil.RespondError(c, http.StatusNotFound, "User not found")
 			return
 		}
 		if strings.Contains(err.Error(), "already verified") {
-			c.JSON(http.StatusBadRequest, gin.H{"error": "Email already verified"})
+			httputil.RespondError(c, http.StatusBadRequest, "Email already verified")
 			return
 		}
 		if strings.Contains(err.Error(), "failed to send") {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to send verification email. Please try again later."})
+			httputil.RespondError(c, http.StatusInternalServerError, "Failed to send verification email. Please try again later.")
 			return
 		}
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Could not resend verification email"})
+		httputil.RespondError(c, http.StatusInternalServerError, "Could not resend verification email")
 		return
 	}
 
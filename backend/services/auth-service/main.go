@@ -16,6 +16,7 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/joho/godotenv"
+	commonmw "github.com/yashrajoria/common/middleware"
 	"go.uber.org/zap"
 )
 
@@ -58,7 +59,8 @@ func main() {
 	// --- 3. HTTP Server & Middleware ---
 
 	r := gin.New()
-	r.Use(gin.Recovery()) // Panic protection
+	r.Use(commonmw.RequestID())
+	r.Use(commonmw.Recovery(logger, "auth-service", os.Stdout)) // Panic protection
 
 	// Add request timeout middleware
 	r.Use(func(c *gin.Context) {
@@ -18,6 +18,8 @@ type User struct {
 	Role             string    `gorm:"type:varchar(50);default:'user'"`
 	CreatedAt        time.Time `gorm:"autoCreateTime"`
 	UpdatedAt        time.Time `gorm:"autoUpdateTime"`
+	// DeletedAt is set by user-service when the account is deactivated
+	DeletedAt gorm.DeletedAt `gorm:"index"`
 }
 
 // RefreshToken model stores issued refresh tokens for rotation and revocation
@@ -16,9 +16,11 @@ func NewUserRepository(db *gorm.DB) *UserRepository {
 	return &UserRepository{db: db}
 }
 
+// FindByEmail includes deactivated accounts so callers can tell them apart
+// from unknown emails.
 func (r *UserRepository) FindByEmail(ctx context.Context, email string) (*models.User, error) {
 	var user models.User
-	err := r.db.WithContext(ctx).Where("email = ?", email).First(&user).Error
+	err := r.db.WithContext(ctx).Unscoped().Where("email = ?", email).First(&user).Error
 	return &user, err
 }
 
@@ -33,7 +35,7 @@ func (r *UserRepository) Update(ctx context.Context, user *models.User) error {
 func (r *UserRepository) FindByID(ctx context.Context, id uuid.UUID) (*models.User, error) {
 	var user models.User
 	// GORM can work directly with the uuid.UUID type.
-	err := r.db.WithContext(ctx).Where("id = ?", id).First(&user).Error
+	err := r.db.WithContext(ctx).Unscoped().Where("id = ?", id).First(&user).Error
 	return &user, err
 }
 
@@ -62,6 +62,10 @@ func (s *AuthService) Login(ctx context.Context, email, password string) (*Token
 		return nil, fmt.Errorf("invalid email or password")
 	}
 
+	if user.DeletedAt.Valid {
+		return nil, fmt.Errorf("account deactivated")
+	}
+
 	tokenPair, refreshTokenID, err := s.tokenService.GenerateTokenPair(user.ID.String(), user.Email, user.Role)
 	if err != nil {
 		return nil, err
@@ -168,18 +172,12 @@ func (s *AuthService) RefreshTokens(ctx context.Context, refreshToken string) (*
 		return nil, fmt.Errorf("invalid token: user ID (sub) is not a valid UUID")
 	}
 
-	_, err = s.userRepo.FindByID(ctx, userID)
+	user, err := s.userRepo.FindByID(ctx, userID)
 	if err != nil {
 		return nil, fmt.Errorf("user not found")
 	}
-
-	email, ok := claims["email"].(string)
-	if !ok {
-		return nil, fmt.Errorf("invalid token: email claim is missing or not a string")
-	}
-	role, ok := claims["role"].(string)
-	if !ok {
-		return nil, fmt.Errorf("invalid token: role claim is missing or not a string")
+	if user.DeletedAt.Valid {
+		return nil, fmt.Errorf("account deactivated")
 	}
 
 	// verify the refresh token against DB (jti)
@@ -201,8 +199,9 @@ func (s *AuthService) RefreshTokens(ctx context.Context, refreshToken string) (*
 		return nil, fmt.Errorf("failed to revoke old refresh token: %w", err)
 	}
 
-	// generate new pair and persist new refresh token
-	tokenPair, newTokenID, err := s.tokenService.GenerateTokenPair(userIDStr, email, role)
+	// generate new pair from the stored user, not the old claims, so a role
+	// change takes effect on the next refresh; persist the new refresh token
+	tokenPair, newTokenID, err := s.tokenService.GenerateTokenPair(userIDStr, user.Email, user.Role)
 	if err != nil {
 		return nil, err
 	}
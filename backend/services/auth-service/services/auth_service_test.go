@@ -3,6 +3,7 @@ package services
 import (
 	"context"
 	"testing"
+	"time"
 
 	"auth-service/models"
 
@@ -165,4 +166,51 @@ func TestLogin(t *testing.T) {
 		assert.Equal(t, "email not verified", err.Error())
 		mockRepo.AssertExpectations(t)
 	})
+
+	t.Run("Account Deactivated", func(t *testing.T) {
+		// Arrange
+		deactivatedUser := *testUser
+		deactivatedUser.DeletedAt = gorm.DeletedAt{Time: time.Now(), Valid: true}
+		mockRepo.On("FindByEmail", ctx, deactivatedUser.Email).Return(&deactivatedUser, nil).Once()
+
+		// Act
+		_, err := authService.Login(ctx, deactivatedUser.Email, password)
+
+		// Assert
+		assert.Error(t, err)
+		assert.Equal(t, "account deactivated", err.Error())
+		mockRepo.AssertExpectations(t)
+	})
+}
+
+func TestRefreshTokens_UsesStoredRole(t *testing.T) {
+	mockRepo := new(MockUserRepository)
+	mockTokenService := new(MockTokenService)
+	authService := NewAuthService(mockRepo, mockTokenService, nil)
+	ctx := context.Background()
+
+	// The refresh token was issued while the user was an admin; they have
+	// since been demoted.
+	demotedUser := &models.User{ID: uuid.New(), Email: "test@example.com", Role: "user", EmailVerified: true}
+	claims := jwt.MapClaims{
+		"sub":   demotedUser.ID.String(),
+		"email": demotedUser.Email,
+		"role":  "admin",
+		"jti":   "rt-old",
+	}
+	stored := &models.RefreshToken{TokenID: "rt-old", UserID: demotedUser.ID, ExpiresAt: time.Now().Add(time.Hour)}
+
+	mockTokenService.On("ValidateToken", "refresh-token", "refresh").Return(claims, nil).Once()
+	mockRepo.On("GetRefreshTokenByTokenID", ctx, "rt-old").Return(stored, nil)
+	mockRepo.On("FindByID", ctx, demotedUser.ID).Return(demotedUser, nil).Once()
+	mockRepo.On("RevokeRefreshTokenByTokenID", ctx, "rt-old").Return(nil).Once()
+	mockTokenService.On("GenerateTokenPair", demotedUser.ID.String(), demotedUser.Email, "user").Return(&TokenPair{"access", "refresh"}, "rt-new", nil).Once()
+	mockRepo.On("CreateRefreshToken", ctx, mock.Anything).Return(nil).Once()
+
+	tokenPair, err := authService.RefreshTokens(ctx, "refresh-token")
+
+	assert.NoError(t, err)
+	assert.NotNil(t, tokenPair)
+	mockRepo.AssertExpectations(t)
+	mockTokenService.AssertExpectations(t)
 }
@@ -6,8 +6,10 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"mime"
 	"net/http"
 	"net/url"
+	"strings"
 	"time"
 )
 
@@ -43,6 +45,47 @@ func (g *GatewayClient) Do(ctx context.Context, method, path string, query url.V
 	return g.client.Do(req)
 }
 
+// DoStream is like Do but forwards body as-is with the given length, so
+// large or binary payloads are streamed instead of buffered. A negative
+// contentLength sends the body chunked.
+func (g *GatewayClient) DoStream(ctx context.Context, method, path string, query url.Values, headers http.Header, body io.Reader, contentLength int64) (*http.Response, error) {
+	u := g.baseURL + path
+	if len(query) > 0 {
+		u += "?" + query.Encode()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, u, body)
+	if err != nil {
+		return nil, err
+	}
+	req.ContentLength = contentLength
+	if contentLength == 0 {
+		req.Body = http.NoBody
+	}
+
+	for k, v := range headers {
+		for _, vv := range v {
+			req.Header.Add(k, vv)
+		}
+	}
+
+	return g.client.Do(req)
+}
+
+// IsJSONBody reports whether r carries a JSON (or empty/untyped) body that is
+// safe to buffer. Multipart and other binary uploads should be streamed.
+func IsJSONBody(r *http.Request) bool {
+	ct := r.Header.Get("Content-Type")
+	if ct == "" {
+		return true
+	}
+	mediaType, _, err := mime.ParseMediaType(ct)
+	if err != nil {
+		return false
+	}
+	return mediaType == "application/json" || strings.HasSuffix(mediaType, "+json")
+}
+
 func ReadJSONBody(r *http.Request) ([]byte, error) {
 	if r.Body == nil {
 		return nil, nil
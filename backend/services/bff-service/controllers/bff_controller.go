@@ -8,6 +8,7 @@ import (
 	"bff-service/clients"
 
 	"github.com/gin-gonic/gin"
+	"github.com/yashrajoria/common/httputil"
 )
 
 type BFFController struct {
@@ -69,9 +70,8 @@ func (b *BFFController) Home(c *gin.Context) {
 	categories := <-categoriesCh
 
 	if products.err != nil || categories.err != nil {
-		c.JSON(http.StatusBadGateway, gin.H{
-			"error":     "failed to load home data",
-			"products":  errorString(products.err),
+		httputil.RespondErrorDetails(c, http.StatusBadGateway, "failed to load home data", gin.H{
+			"products":   errorString(products.err),
 			"categories": errorString(categories.err),
 		})
 		return
@@ -128,8 +128,7 @@ func (b *BFFController) Profile(c *gin.Context) {
 	orders := <-ordersCh
 
 	if profile.err != nil || orders.err != nil {
-		c.JSON(http.StatusBadGateway, gin.H{
-			"error":   "failed to load profile data",
+		httputil.RespondErrorDetails(c, http.StatusBadGateway, "failed to load profile data", gin.H{
 			"profile": errorString(profile.err),
 			"orders":  errorString(orders.err),
 		})
@@ -145,20 +144,27 @@ func (b *BFFController) Profile(c *gin.Context) {
 
 func (b *BFFController) Proxy(method, path string) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		bodyBytes, err := clients.ReadJSONBody(c.Request)
-		if err != nil {
-			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body"})
-			return
+		var resp *http.Response
+		var err error
+		if clients.IsJSONBody(c.Request) {
+			bodyBytes, readErr := clients.ReadJSONBody(c.Request)
+			if readErr != nil {
+				httputil.RespondError(c, http.StatusBadRequest, "invalid request body")
+				return
+			}
+			resp, err = b.gateway.Do(c.Request.Context(), method, path, c.Request.URL.Query(), c.Request.Header, clients.BodyFromBytes(bodyBytes))
+		} else {
+			// Multipart/binary uploads pass through untouched so boundaries and
+			// Content-Length survive and large files aren't held in memory.
+			resp, err = b.gateway.DoStream(c.Request.Context(), method, path, c.Request.URL.Query(), c.Request.Header, c.Request.Body, c.Request.ContentLength)
 		}
-
-		resp, err := b.gateway.Do(c.Request.Context(), method, path, c.Request.URL.Query(), c.Request.Header, clients.BodyFromBytes(bodyBytes))
 		if err != nil {
-			c.JSON(http.StatusBadGateway, gin.H{"error": "upstream request failed"})
+			httputil.RespondError(c, http.StatusBadGateway, "upstream request failed")
 			return
 		}
 
 		if err := clients.CopyResponse(c.Writer, resp); err != nil {
-			c.JSON(http.StatusBadGateway, gin.H{"error": "failed to read upstream response"})
+			httputil.RespondError(c, http.StatusBadGateway, "failed to read upstream response")
 			return
 		}
 	}
@@ -170,12 +176,12 @@ func (b *BFFController) OrderByID(c *gin.Context) {
 
 	resp, err := b.gateway.Do(c.Request.Context(), http.MethodGet, path, c.Request.URL.Query(), c.Request.Header, nil)
 	if err != nil {
-		c.JSON(http.StatusBadGateway, gin.H{"error": "upstream request failed"})
+		httputil.RespondError(c, http.StatusBadGateway, "upstream request failed")
 		return
 	}
 
 	if err := clients.CopyResponse(c.Writer, resp); err != nil {
-		c.JSON(http.StatusBadGateway, gin.H{"error": "failed to read upstream response"})
+		httputil.RespondError(c, http.StatusBadGateway, "failed to read upstream response")
 		return
 	}
 }
@@ -186,12 +192,12 @@ func (b *BFFController) ProductByID(c *gin.Context) {
 
 	resp, err := b.gateway.Do(c.Request.Context(), http.MethodGet, path, c.Request.URL.Query(), c.Request.Header, nil)
 	if err != nil {
-		c.JSON(http.StatusBadGateway, gin.H{"error": "upstream request failed"})
+		httputil.RespondError(c, http.StatusBadGateway, "upstream request failed")
 		return
 	}
 
 	if err := clients.CopyResponse(c.Writer, resp); err != nil {
-		c.JSON(http.StatusBadGateway, gin.H{"error": "failed to read upstream response"})
+		httputil.RespondError(c, http.StatusBadGateway, "failed to read upstream response")
 		return
 	}
 }
@@ -202,12 +208,12 @@ func (b *BFFController) CartRemoveItem(c *gin.Context) {
 
 	resp, err := b.gateway.Do(c.Request.Context(), http.MethodDelete, path, c.Request.URL.Query(), c.Request.Header, nil)
 	if err != nil {
-		c.JSON(http.StatusBadGateway, gin.H{"error": "upstream request failed"})
+		httputil.RespondError(c, http.StatusBadGateway, "upstream request failed")
 		return
 	}
 
 	if err := clients.CopyResponse(c.Writer, resp); err != nil {
-		c.JSON(http.StatusBadGateway, gin.H{"error": "failed to read upstream response"})
+		httputil.RespondError(c, http.StatusBadGateway, "failed to read upstream response")
 		return
 	}
 }
@@ -218,12 +224,12 @@ func (b *BFFController) PaymentStatusByOrderID(c *gin.Context) {
 
 	resp, err := b.gateway.Do(c.Request.Context(), http.MethodGet, path, c.Request.URL.Query(), c.Request.Header, nil)
 	if err != nil {
-		c.JSON(http.StatusBadGateway, gin.H{"error": "upstream request failed"})
+		httputil.RespondError(c, http.StatusBadGateway, "upstream request failed")
 		return
 	}
 
 	if err := clients.CopyResponse(c.Writer, resp); err != nil {
-		c.JSON(http.StatusBadGateway, gin.H{"error": "failed to read upstream response"})
+		httputil.RespondError(c, http.StatusBadGateway, "failed to read upstream response")
 		return
 	}
 }
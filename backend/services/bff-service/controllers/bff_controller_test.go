@@ -0,0 +1,94 @@
+package controllers
+
+import (
+	"bytes"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"bff-service/clients"
+
+	"github.com/gin-gonic/gin"
+)
+
+type capturedRequest struct {
+	contentType   string
+	contentLength int64
+	body          []byte
+}
+
+func newCapturingGateway(t *testing.T) (*httptest.Server, *capturedRequest) {
+	t.Helper()
+	captured := &capturedRequest{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		captured.contentType = r.Header.Get("Content-Type")
+		captured.contentLength = r.ContentLength
+		captured.body, _ = io.ReadAll(r.Body)
+		w.WriteHeader(http.StatusCreated)
+	}))
+	return srv, captured
+}
+
+func newProxyRouter(gatewayURL, path string) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	ctrl := NewBFFController(clients.NewGatewayClient(gatewayURL, 5*time.Second))
+	r := gin.New()
+	r.POST(path, ctrl.Proxy(http.MethodPost, path))
+	return r
+}
+
+func TestProxy_MultipartPassesThroughIntact(t *testing.T) {
+	gateway, captured := newCapturingGateway(t)
+	defer gateway.Close()
+
+	var buf bytes.Buffer
+	mw := multipart.NewWriter(&buf)
+	_ = mw.WriteField("name", "Sneaker")
+	fw, _ := mw.CreateFormFile("images", "shoe.png")
+	image := append([]byte{0x89, 'P', 'N', 'G', 0x00, 0xff}, bytes.Repeat([]byte{0xab}, 4096)...)
+	_, _ = fw.Write(image)
+	_ = mw.Close()
+	sent := buf.Bytes()
+
+	req := httptest.NewRequest(http.MethodPost, "/products", bytes.NewReader(sent))
+	req.Header.Set("Content-Type", mw.FormDataContentType())
+	w := httptest.NewRecorder()
+	newProxyRouter(gateway.URL, "/products").ServeHTTP(w, req)
+
+	if w.Code != http.StatusCreated {
+		t.Fatalf("expected 201, got %d", w.Code)
+	}
+	if captured.contentType != mw.FormDataContentType() {
+		t.Fatalf("boundary not preserved: got %q", captured.contentType)
+	}
+	if captured.contentLength != int64(len(sent)) {
+		t.Fatalf("expected content-length %d, got %d", len(sent), captured.contentLength)
+	}
+	if !bytes.Equal(captured.body, sent) {
+		t.Fatalf("multipart body modified in transit")
+	}
+}
+
+func TestProxy_JSONBodyUnaffected(t *testing.T) {
+	gateway, captured := newCapturingGateway(t)
+	defer gateway.Close()
+
+	payload := []byte(`{"email":"a@b.com","password":"secret"}`)
+	req := httptest.NewRequest(http.MethodPost, "/auth/login", bytes.NewReader(payload))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	newProxyRouter(gateway.URL, "/auth/login").ServeHTTP(w, req)
+
+	if w.Code != http.StatusCreated {
+		t.Fatalf("expected 201, got %d", w.Code)
+	}
+	if captured.contentType != "application/json" {
+		t.Fatalf("unexpected content-type %q", captured.contentType)
+	}
+	if !bytes.Equal(captured.body, payload) {
+		t.Fatalf("expected JSON body %q, got %q", payload, captured.body)
+	}
+}
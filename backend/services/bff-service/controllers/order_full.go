@@ -0,0 +1,130 @@
+package controllers
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"bff-service/clients"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yashrajoria/common/httputil"
+)
+
+// OrderFull returns the order, its payment status and its shipment state in
+// one response so the post-checkout page can poll a single endpoint. The
+// order is required; payment is best effort and listed under "unavailable"
+// when it cannot be loaded. Shipment progress is tracked by order-service on
+// the order status, so it is derived from the order rather than fetched.
+func (b *BFFController) OrderFull(c *gin.Context) {
+	ctx := c.Request.Context()
+	orderID := c.Param("id")
+
+	type result struct {
+		data map[string]interface{}
+		resp *http.Response
+		err  error
+	}
+
+	orderCh := make(chan result, 1)
+	paymentCh := make(chan result, 1)
+
+	go func() {
+		resp, err := b.gateway.Do(ctx, http.MethodGet, "/orders/"+orderID, nil, c.Request.Header, nil)
+		if err != nil {
+			orderCh <- result{err: err}
+			return
+		}
+		if resp.StatusCode != http.StatusOK {
+			// Handed back as-is so 401/404 reach the client unchanged
+			orderCh <- result{resp: resp}
+			return
+		}
+		var data map[string]interface{}
+		err = clients.DecodeJSON(resp, &data)
+		orderCh <- result{data: data, err: err}
+	}()
+
+	go func() {
+		resp, err := b.gateway.Do(ctx, http.MethodGet, "/payment/status/by-order/"+orderID, nil, c.Request.Header, nil)
+		if err != nil {
+			paymentCh <- result{err: err}
+			return
+		}
+		var data map[string]interface{}
+		err = clients.DecodeJSON(resp, &data)
+		paymentCh <- result{data: data, err: err}
+	}()
+
+	order := <-orderCh
+	payment := <-paymentCh
+
+	if order.resp != nil {
+		if err := clients.CopyResponse(c.Writer, order.resp); err != nil {
+			httputil.RespondError(c, http.StatusBadGateway, "failed to read upstream response")
+		}
+		return
+	}
+	if order.err != nil {
+		httputil.RespondErrorDetails(c, http.StatusBadGateway, "failed to load order", gin.H{"order": errorString(order.err)})
+		return
+	}
+
+	orderStatus := stringField(order.data, "Status", "status")
+	paymentStatus := ""
+	unavailable := []string{}
+	if payment.err != nil {
+		unavailable = append(unavailable, "payment")
+	} else {
+		paymentStatus = stringField(payment.data, "status")
+	}
+
+	var shipment gin.H
+	if orderStatus == "shipped" || orderStatus == "delivered" {
+		shipment = gin.H{"status": orderStatus}
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"order":          order.data,
+		"payment":        payment.data,
+		"shipment":       shipment,
+		"overall_status": deriveOverallStatus(orderStatus, paymentStatus),
+		"unavailable":    unavailable,
+		"timestamp":      time.Now().UTC(),
+	})
+}
+
+// deriveOverallStatus folds order and payment state into the single status
+// the frontend shows. Fulfilment beats payment, and the order's own status is
+// used when the payment status is unknown.
+func deriveOverallStatus(orderStatus, paymentStatus string) string {
+	orderStatus = strings.ToLower(orderStatus)
+	paymentStatus = strings.ToLower(paymentStatus)
+
+	switch orderStatus {
+	case "delivered", "shipped", "paid", "payment_failed":
+		return orderStatus
+	}
+	switch paymentStatus {
+	case "succeeded", "paid":
+		return "paid"
+	case "failed":
+		return "payment_failed"
+	}
+	if orderStatus == "" {
+		return "pending_payment"
+	}
+	return orderStatus
+}
+
+// stringField returns the first of keys present in data as a string.
+// order-service encodes field names as-is (e.g. "Status"), so callers pass
+// both spellings.
+func stringField(data map[string]interface{}, keys ...string) string {
+	for _, k := range keys {
+		if s, ok := data[k].(string); ok {
+			return s
+		}
+	}
+	return ""
+}
@@ -0,0 +1,97 @@
+package controllers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"bff-service/clients"
+
+	"github.com/gin-gonic/gin"
+)
+
+// newOrderGateway fakes the gateway's order and payment endpoints. With
+// paymentUp false the payment endpoint fails.
+func newOrderGateway(t *testing.T, orderStatus string, paymentUp bool) *httptest.Server {
+	t.Helper()
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/orders/o-1":
+			w.Write([]byte(`{"ID":"o-1","Status":"` + orderStatus + `","Amount":2500}`))
+		case "/payment/status/by-order/o-1":
+			if !paymentUp {
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+			w.Write([]byte(`{"order_id":"o-1","status":"succeeded"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+}
+
+func getOrderFull(t *testing.T, gatewayURL, id string) (int, map[string]interface{}) {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+	ctrl := NewBFFController(clients.NewGatewayClient(gatewayURL, 5*time.Second))
+	r := gin.New()
+	r.GET("/order/:id/full", ctrl.OrderFull)
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/order/"+id+"/full", nil))
+	var body map[string]interface{}
+	json.Unmarshal(w.Body.Bytes(), &body)
+	return w.Code, body
+}
+
+func TestOrderFull_PaymentOnly(t *testing.T) {
+	gateway := newOrderGateway(t, "paid", true)
+	defer gateway.Close()
+
+	code, body := getOrderFull(t, gateway.URL, "o-1")
+	if code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", code)
+	}
+	if body["overall_status"] != "paid" || body["shipment"] != nil {
+		t.Fatalf("expected paid with no shipment, got %v", body)
+	}
+	if payment, _ := body["payment"].(map[string]interface{}); payment["status"] != "succeeded" {
+		t.Fatalf("expected payment status in response, got %v", body["payment"])
+	}
+}
+
+func TestOrderFull_WithShipment(t *testing.T) {
+	gateway := newOrderGateway(t, "shipped", true)
+	defer gateway.Close()
+
+	_, body := getOrderFull(t, gateway.URL, "o-1")
+	shipment, _ := body["shipment"].(map[string]interface{})
+	if body["overall_status"] != "shipped" || shipment["status"] != "shipped" {
+		t.Fatalf("expected shipped order with shipment, got %v", body)
+	}
+}
+
+func TestOrderFull_PaymentUnavailable(t *testing.T) {
+	gateway := newOrderGateway(t, "pending_payment", false)
+	defer gateway.Close()
+
+	code, body := getOrderFull(t, gateway.URL, "o-1")
+	if code != http.StatusOK {
+		t.Fatalf("payment outage should not fail the view, got %d", code)
+	}
+	unavailable, _ := body["unavailable"].([]interface{})
+	if len(unavailable) != 1 || unavailable[0] != "payment" || body["overall_status"] != "pending_payment" {
+		t.Fatalf("expected payment listed as unavailable, got %v", body)
+	}
+}
+
+func TestOrderFull_UnknownOrderPassesThrough404(t *testing.T) {
+	gateway := newOrderGateway(t, "paid", true)
+	defer gateway.Close()
+
+	if code, _ := getOrderFull(t, gateway.URL, "missing"); code != http.StatusNotFound {
+		t.Fatalf("expected 404, got %d", code)
+	}
+}
@@ -14,10 +14,19 @@ import (
 	"bff-service/routes"
 
 	"github.com/gin-gonic/gin"
+	commonmw "github.com/yashrajoria/common/middleware"
+	"go.uber.org/zap"
 )
 
 func main() {
+	logger, _ := zap.NewProduction()
+	defer logger.Sync()
+
 	cfg := config.Load()
+	corsConfig, err := commonmw.LoadCORSConfig()
+	if err != nil {
+		log.Fatalf("[BFF] invalid CORS configuration: %v", err)
+	}
 
 	timeout, err := time.ParseDuration(cfg.RequestTimeout)
 	if err != nil {
@@ -28,7 +37,9 @@ func main() {
 	controller := controllers.NewBFFController(gateway)
 
 	r := gin.New()
-	r.Use(gin.Recovery())
+	r.Use(commonmw.RequestID())
+	r.Use(commonmw.Recovery(logger, "bff-service", os.Stdout))
+	r.Use(commonmw.CORS(corsConfig))
 
 	r.GET("/docs", func(c *gin.Context) {
 		c.Header("Content-Type", "text/html; charset=utf-8")
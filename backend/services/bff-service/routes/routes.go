@@ -46,6 +46,8 @@ func RegisterRoutes(r *gin.Engine, ctrl *controllers.BFFController) {
 		// Orders page
 		protected.GET("/orders", ctrl.Proxy("GET", "/orders"))
 		protected.GET("/orders/:id", ctrl.OrderByID)
+		// Order + payment + shipment in one call, for post-checkout polling
+		protected.GET("/order/:id/full", ctrl.OrderFull)
 
 		// Profile settings
 		protected.GET("/profile", ctrl.Profile)
@@ -0,0 +1,58 @@
+package clients
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+// ErrProductNotFound is returned when product-service has no such product.
+var ErrProductNotFound = errors.New("product not found")
+
+type ProductClient struct {
+	baseURL string
+	client  *http.Client
+}
+
+func NewProductClient(baseURL string) *ProductClient {
+	return &ProductClient{
+		baseURL: baseURL,
+		client:  &http.Client{Timeout: 5 * time.Second},
+	}
+}
+
+// GetProduct returns the public product JSON from product-service as is.
+func (p *ProductClient) GetProduct(ctx context.Context, productID string) (json.RawMessage, error) {
+	url := fmt.Sprintf("%s/products/%s", p.baseURL, productID)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, ErrProductNotFound
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("product service returned %d", resp.StatusCode)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if !json.Valid(body) {
+		return nil, fmt.Errorf("product service returned invalid JSON")
+	}
+	return body, nil
+}
@@ -1,25 +1,33 @@
 package config
 
 import (
+	"log"
 	"os"
+	"strconv"
 	"time"
 )
 
 type Config struct {
-	Port             string
-	RedisURL         string
-	CartTTL          time.Duration
-	CheckoutQueueURL string // SQS queue URL for checkout events
-	OrderSNSTopicARN string // SNS topic ARN for order events
+	Port              string
+	RedisURL          string
+	CartTTL           time.Duration
+	CheckoutQueueURL  string // SQS queue URL for checkout events
+	OrderSNSTopicARN  string // SNS topic ARN for order events
+	ProductServiceURL string // base URL used to enrich wishlist entries
+	MaxCartItems      int    // distinct products allowed in one cart
+	MaxItemQuantity   int    // quantity allowed for a single product
 }
 
 func Load() Config {
 	return Config{
-		Port:             getEnv("PORT", "8086"),
-		RedisURL:         getEnv("REDIS_URL", "redis://redis:6379"),
-		CartTTL:          time.Hour * 24 * 7, // default 7 days
-		CheckoutQueueURL: os.Getenv("CHECKOUT_QUEUE_URL"),
-		OrderSNSTopicARN: getEnv("ORDER_SNS_TOPIC_ARN", "arn:aws:sns:eu-west-2:000000000000:order-events"),
+		Port:              getEnv("PORT", "8086"),
+		RedisURL:          getEnv("REDIS_URL", "redis://redis:6379"),
+		CartTTL:           time.Hour * 24 * 7, // default 7 days
+		CheckoutQueueURL:  os.Getenv("CHECKOUT_QUEUE_URL"),
+		OrderSNSTopicARN:  getEnv("ORDER_SNS_TOPIC_ARN", "arn:aws:sns:eu-west-2:000000000000:order-events"),
+		ProductServiceURL: getEnv("PRODUCT_SERVICE_URL", "http://product-service:8082"),
+		MaxCartItems:      getEnvInt("CART_MAX_ITEMS", 100),
+		MaxItemQuantity:   getEnvInt("CART_MAX_ITEM_QUANTITY", 99),
 	}
 }
 
@@ -29,3 +37,16 @@ func getEnv(key, defaultVal string) string {
 	}
 	return defaultVal
 }
+
+// getEnvInt reads a positive integer, exiting if key is set to anything else.
+func getEnvInt(key string, defaultVal int) int {
+	raw := os.Getenv(key)
+	if raw == "" {
+		return defaultVal
+	}
+	v, err := strconv.Atoi(raw)
+	if err != nil || v < 1 {
+		log.Fatalf("%s must be a positive integer, got %q", key, raw)
+	}
+	return v
+}
@@ -3,27 +3,35 @@ package controllers
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"log"
 	"net/http"
 	"os"
 	"time"
 
 	"cart-service/config"
-	"cart-service/database"
 	"cart-service/models"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 	aws_pkg "github.com/yashrajoria/E-Commerce-backend/backend/pkg/aws"
+	"github.com/yashrajoria/common/httputil"
 )
 
+// CartStore persists carts. It is implemented by database.CartRepository.
+type CartStore interface {
+	GetCart(ctx context.Context, userID string) (*models.Cart, error)
+	SaveCart(ctx context.Context, cart *models.Cart) error
+	DeleteCart(ctx context.Context, userID string) error
+}
+
 type CartController struct {
-	Repo      *database.CartRepository
+	Repo      CartStore
 	SNSClient *aws_pkg.SNSClient
 	Config    config.Config
 }
 
-func NewCartController(repo *database.CartRepository, snsClient *aws_pkg.SNSClient, cfg config.Config) *CartController {
+func NewCartController(repo CartStore, snsClient *aws_pkg.SNSClient, cfg config.Config) *CartController {
 	return &CartController{
 		Repo:      repo,
 		SNSClient: snsClient,
@@ -35,7 +43,7 @@ func NewCartController(repo *database.CartRepository, snsClient *aws_pkg.SNSClie
 func (cc *CartController) GetCart(c *gin.Context) {
 	userID := c.GetHeader("X-User-ID")
 	if userID == "" {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "user not authorized"})
+		httputil.RespondError(c, http.StatusUnauthorized, "user not authorized")
 		return
 	}
 
@@ -44,7 +52,7 @@ func (cc *CartController) GetCart(c *gin.Context) {
 	cart, err := cc.Repo.GetCart(ctx, userID)
 	if err != nil {
 		log.Printf("{GET CART FAILED} for user %s: %v", userID, err)
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to get cart"})
+		httputil.RespondError(c, http.StatusInternalServerError, "failed to get cart")
 		return
 	}
 
@@ -69,13 +77,13 @@ type AddItemsRequest struct {
 func (cc *CartController) AddItems(c *gin.Context) {
 	userID := c.GetHeader("X-User-ID")
 	if userID == "" {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "user not authorized"})
+		httputil.RespondError(c, http.StatusUnauthorized, "user not authorized")
 		return
 	}
 
 	var req AddItemsRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request", "details": err.Error()})
+		httputil.RespondErrorDetails(c, http.StatusBadRequest, "invalid request", err.Error())
 		return
 	}
 
@@ -83,7 +91,7 @@ func (cc *CartController) AddItems(c *gin.Context) {
 
 	cart, err := cc.Repo.GetCart(ctx, userID)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to get cart"})
+		httputil.RespondError(c, http.StatusInternalServerError, "failed to get cart")
 		return
 	}
 
@@ -95,37 +103,62 @@ func (cc *CartController) AddItems(c *gin.Context) {
 	}
 
 	// Update cart items: increment quantities if product exists, else add new
+	distinct := len(cart.Items)
 	for _, newItem := range req.Items {
 		found := false
 		for i, existing := range cart.Items {
 			if existing.ProductID == newItem.ProductID {
 				cart.Items[i].Quantity += newItem.Quantity
+				if err := cc.checkQuantity(cart.Items[i]); err != nil {
+					httputil.RespondError(c, http.StatusBadRequest, err.Error())
+					return
+				}
 				found = true
 				break
 			}
 		}
 		if !found {
-			cart.Items = append(cart.Items, models.CartItem{
+			item := models.CartItem{
 				ProductID: newItem.ProductID,
 				Quantity:  newItem.Quantity,
-			})
+			}
+			if err := cc.checkQuantity(item); err != nil {
+				httputil.RespondError(c, http.StatusBadRequest, err.Error())
+				return
+			}
+			cart.Items = append(cart.Items, item)
 		}
 	}
 
+	// Only adding new products is refused, so a cart left over the limit by a
+	// config change can still have its existing items updated.
+	if len(cart.Items) > distinct && len(cart.Items) > cc.Config.MaxCartItems {
+		httputil.RespondError(c, http.StatusBadRequest, fmt.Sprintf("cart cannot hold more than %d distinct items", cc.Config.MaxCartItems))
+		return
+	}
+
 	if err := cc.Repo.SaveCart(ctx, cart); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to save cart"})
+		httputil.RespondError(c, http.StatusInternalServerError, "failed to save cart")
 		return
 	}
 
 	c.JSON(http.StatusOK, cart)
 }
 
+// checkQuantity rejects an item whose quantity is over the configured maximum.
+func (cc *CartController) checkQuantity(item models.CartItem) error {
+	if item.Quantity > cc.Config.MaxItemQuantity {
+		return fmt.Errorf("quantity for product %s cannot exceed %d", item.ProductID, cc.Config.MaxItemQuantity)
+	}
+	return nil
+}
+
 // RemoveItem removes a specific item from the cart
 func (cc *CartController) RemoveItem(c *gin.Context) {
 	userID := c.GetHeader("X-User-ID")
 	productID := c.Param("product_id")
 	if userID == "" {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "user not authorized"})
+		httputil.RespondError(c, http.StatusUnauthorized, "user not authorized")
 		return
 	}
 
@@ -135,7 +168,7 @@ func (cc *CartController) RemoveItem(c *gin.Context) {
 	if cart == nil {
 		log.Printf("⚠️ [RemoveItem] Cart not found for userID=%s", userID)
 
-		c.JSON(http.StatusNotFound, gin.H{"error": "cart not found"})
+		httputil.RespondError(c, http.StatusNotFound, "cart not found")
 		return
 	}
 
@@ -150,18 +183,19 @@ func (cc *CartController) RemoveItem(c *gin.Context) {
 	if err := cc.Repo.SaveCart(ctx, cart); err != nil {
 		log.Printf("❌ [RemoveItem] Failed to update cart for userID=%s: %v", userID, err)
 
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to update cart"})
+		httputil.RespondError(c, http.StatusInternalServerError, "failed to update cart")
 		return
 	}
 
 	c.JSON(http.StatusOK, cart)
 }
 
-// ClearCart removes all items from the cart
+// ClearCart removes all items from the cart in a single delete. Clearing a
+// cart that is already empty succeeds.
 func (cc *CartController) ClearCart(c *gin.Context) {
 	userID := c.GetHeader("X-User-ID")
 	if userID == "" {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "user not authorized"})
+		httputil.RespondError(c, http.StatusUnauthorized, "user not authorized")
 		return
 	}
 
@@ -171,24 +205,25 @@ func (cc *CartController) ClearCart(c *gin.Context) {
 	if err != nil {
 		log.Printf("❌ [ClearCart] Failed to clear cart for userID=%s: %v", userID, err)
 
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to clear cart"})
+		httputil.RespondError(c, http.StatusInternalServerError, "failed to clear cart")
 		return
 	}
 
 	c.JSON(http.StatusOK, gin.H{"message": "cart cleared"})
 }
 
-// Checkout publishes the cart to SNS and clears it
+// Checkout publishes the cart to SNS. The cart is left intact; order-service
+// empties it once the order is paid.
 func (cc *CartController) Checkout(c *gin.Context) {
 	userID := c.GetHeader("X-User-ID")
 	if userID == "" {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "user not authorized"})
+		httputil.RespondError(c, http.StatusUnauthorized, "user not authorized")
 		return
 	}
 
 	if userID == "" {
 		log.Println("❌ [Checkout] Unauthorized: missing or empty user ID header")
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		httputil.RespondError(c, http.StatusUnauthorized, "unauthorized")
 		return
 	}
 	ctx := context.Background()
@@ -197,7 +232,7 @@ func (cc *CartController) Checkout(c *gin.Context) {
 	if err != nil || cart == nil {
 		log.Printf("❌ [Checkout] Cart not found or error for userID=%s: %v", userID, err)
 
-		c.JSON(http.StatusNotFound, gin.H{"error": "cart not found"})
+		httputil.RespondError(c, http.StatusNotFound, "cart not found")
 		return
 	}
 	orderID := uuid.New().String()
@@ -222,12 +257,12 @@ func (cc *CartController) Checkout(c *gin.Context) {
 	if err := cc.SNSClient.Publish(ctx, topicArn, eventBytes); err != nil {
 		log.Printf("❌ [Checkout] Failed to send SNS event for userID=%s topic=%s: %v", userID, topicArn, err)
 
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to publish checkout event"})
+		httputil.RespondError(c, http.StatusInternalServerError, "failed to publish checkout event")
 		return
 	}
 
-	// Clear cart after sending
-	// _ = cc.Repo.DeleteCart(ctx, userID)
+	// The cart is kept until payment succeeds; order-service clears it with
+	// DELETE /cart once the order is paid.
 
 	c.JSON(http.StatusOK, gin.H{"order_id": orderID, "status": "PENDING"})
 }
@@ -0,0 +1,151 @@
+package controllers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"cart-service/config"
+	"cart-service/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+const otherProductID = "7a9b8c6d-5e4f-4a3b-8c2d-1e0f9a8b7c6d"
+
+// memCarts is an in-memory CartStore.
+type memCarts struct {
+	carts map[string]*models.Cart
+}
+
+func newMemCarts() *memCarts {
+	return &memCarts{carts: make(map[string]*models.Cart)}
+}
+
+func (m *memCarts) GetCart(_ context.Context, userID string) (*models.Cart, error) {
+	cart, ok := m.carts[userID]
+	if !ok {
+		return nil, nil
+	}
+	cp := *cart
+	cp.Items = append([]models.CartItem(nil), cart.Items...)
+	return &cp, nil
+}
+
+func (m *memCarts) SaveCart(_ context.Context, cart *models.Cart) error {
+	m.carts[cart.UserID] = cart
+	return nil
+}
+
+func (m *memCarts) DeleteCart(_ context.Context, userID string) error {
+	delete(m.carts, userID)
+	return nil
+}
+
+func newLimitedCartRouter(store CartStore, maxItems, maxQuantity int) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	cc := NewCartController(store, nil, config.Config{MaxCartItems: maxItems, MaxItemQuantity: maxQuantity})
+	r := gin.New()
+	r.POST("/cart/add", cc.AddItems)
+	return r
+}
+
+func addItems(r *gin.Engine, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, "/cart/add", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-User-ID", testUserID)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w
+}
+
+func TestAddItems_WithinLimits(t *testing.T) {
+	store := newMemCarts()
+	r := newLimitedCartRouter(store, 2, 5)
+
+	w := addItems(r, `{"items":[{"product_id":"`+testProductID+`","quantity":3},{"product_id":"`+otherProductID+`","quantity":5}]}`)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	if got := len(store.carts[testUserID].Items); got != 2 {
+		t.Fatalf("expected 2 items saved, got %d", got)
+	}
+}
+
+func TestAddItems_TooManyItems(t *testing.T) {
+	store := newMemCarts()
+	store.carts[testUserID] = &models.Cart{UserID: testUserID, Items: []models.CartItem{{ProductID: testProductID, Quantity: 1}}}
+	r := newLimitedCartRouter(store, 1, 5)
+
+	w := addItems(r, `{"items":[{"product_id":"`+otherProductID+`","quantity":1}]}`)
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400, got %d: %s", w.Code, w.Body.String())
+	}
+	if got := len(store.carts[testUserID].Items); got != 1 {
+		t.Fatalf("cart should be unchanged, has %d items", got)
+	}
+
+	// Updating a product already in the full cart is still allowed.
+	if w := addItems(r, `{"items":[{"product_id":"`+testProductID+`","quantity":1}]}`); w.Code != http.StatusOK {
+		t.Fatalf("expected 200 updating an existing item, got %d: %s", w.Code, w.Body.String())
+	}
+}
+
+func TestAddItems_QuantityTooHigh(t *testing.T) {
+	store := newMemCarts()
+	r := newLimitedCartRouter(store, 10, 5)
+
+	if w := addItems(r, `{"items":[{"product_id":"`+testProductID+`","quantity":6}]}`); w.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400 for a new item over the limit, got %d: %s", w.Code, w.Body.String())
+	}
+
+	if w := addItems(r, `{"items":[{"product_id":"`+testProductID+`","quantity":4}]}`); w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	// 4 already in the cart + 2 more goes over the limit.
+	if w := addItems(r, `{"items":[{"product_id":"`+testProductID+`","quantity":2}]}`); w.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400 when the total goes over the limit, got %d: %s", w.Code, w.Body.String())
+	}
+	if got := store.carts[testUserID].Items[0].Quantity; got != 4 {
+		t.Fatalf("expected quantity to stay 4, got %d", got)
+	}
+}
+
+func clearCart(t *testing.T, store CartStore) *httptest.ResponseRecorder {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+	cc := NewCartController(store, nil, config.Config{})
+	r := gin.New()
+	r.DELETE("/cart", cc.ClearCart)
+
+	req := httptest.NewRequest(http.MethodDelete, "/cart", nil)
+	req.Header.Set("X-User-ID", testUserID)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w
+}
+
+func TestClearCart_Populated(t *testing.T) {
+	store := newMemCarts()
+	store.carts[testUserID] = &models.Cart{UserID: testUserID, Items: []models.CartItem{
+		{ProductID: testProductID, Quantity: 2},
+		{ProductID: otherProductID, Quantity: 1},
+	}}
+
+	if w := clearCart(t, store); w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	if _, ok := store.carts[testUserID]; ok {
+		t.Fatal("expected the cart to be removed")
+	}
+}
+
+func TestClearCart_AlreadyEmpty(t *testing.T) {
+	store := newMemCarts()
+
+	if w := clearCart(t, store); w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+}
@@ -0,0 +1,38 @@
+package controllers
+
+import (
+	"context"
+	"log"
+	"net/http"
+	"time"
+
+	"cart-service/database"
+
+	"github.com/gin-gonic/gin"
+)
+
+// readyPingTimeout bounds the Redis check so a hung connection fails the
+// probe instead of stalling it.
+const readyPingTimeout = 2 * time.Second
+
+type ReadyController struct {
+	Redis database.Pinger
+}
+
+func NewReadyController(redis database.Pinger) *ReadyController {
+	return &ReadyController{Redis: redis}
+}
+
+// Ready reports whether the service can serve carts: 200 when Redis answers a
+// PING, 503 otherwise.
+func (rc *ReadyController) Ready(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), readyPingTimeout)
+	defer cancel()
+
+	if err := rc.Redis.Ping(ctx).Err(); err != nil {
+		log.Printf("❌ [Ready] Redis ping failed: %v", err)
+		c.JSON(http.StatusServiceUnavailable, gin.H{"status": "UNAVAILABLE", "redis": "unreachable"})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"status": "READY", "redis": "ok"})
+}
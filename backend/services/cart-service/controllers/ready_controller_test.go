@@ -0,0 +1,46 @@
+package controllers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/redis/go-redis/v9"
+)
+
+type stubPinger struct{ err error }
+
+func (s stubPinger) Ping(ctx context.Context) *redis.StatusCmd {
+	if s.err != nil {
+		return redis.NewStatusResult("", s.err)
+	}
+	return redis.NewStatusResult("PONG", nil)
+}
+
+func serveReady(t *testing.T, p stubPinger) *httptest.ResponseRecorder {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.GET("/ready", NewReadyController(p).Ready)
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/ready", nil))
+	return w
+}
+
+func TestReady_RedisReachable(t *testing.T) {
+	w := serveReady(t, stubPinger{})
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+}
+
+func TestReady_RedisDown(t *testing.T) {
+	w := serveReady(t, stubPinger{err: errors.New("connection refused")})
+	if w.Code != http.StatusServiceUnavailable {
+		t.Fatalf("expected 503, got %d: %s", w.Code, w.Body.String())
+	}
+}
@@ -0,0 +1,155 @@
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"sync"
+
+	"cart-service/clients"
+	"cart-service/models"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/yashrajoria/common/httputil"
+)
+
+// WishlistStore persists wishlists. It is implemented by
+// database.WishlistRepository.
+type WishlistStore interface {
+	Add(ctx context.Context, userID, productID string) (bool, error)
+	Remove(ctx context.Context, userID, productID string) error
+	List(ctx context.Context, userID string) ([]models.WishlistItem, error)
+}
+
+// ProductFetcher looks up public product data. It is implemented by
+// clients.ProductClient.
+type ProductFetcher interface {
+	GetProduct(ctx context.Context, productID string) (json.RawMessage, error)
+}
+
+type WishlistController struct {
+	Store    WishlistStore
+	Products ProductFetcher
+}
+
+func NewWishlistController(store WishlistStore, products ProductFetcher) *WishlistController {
+	return &WishlistController{
+		Store:    store,
+		Products: products,
+	}
+}
+
+// GetWishlist returns the user's wishlist, newest first, with each entry's
+// current product data. Entries whose product can no longer be loaded are
+// returned without it.
+func (wc *WishlistController) GetWishlist(c *gin.Context) {
+	userID := c.GetHeader("X-User-ID")
+	if userID == "" {
+		httputil.RespondError(c, http.StatusUnauthorized, "user not authorized")
+		return
+	}
+
+	ctx := c.Request.Context()
+
+	items, err := wc.Store.List(ctx, userID)
+	if err != nil {
+		log.Printf("❌ [GetWishlist] Failed to list wishlist for userID=%s: %v", userID, err)
+		httputil.RespondError(c, http.StatusInternalServerError, "failed to get wishlist")
+		return
+	}
+
+	var wg sync.WaitGroup
+	for i := range items {
+		wg.Add(1)
+		go func(item *models.WishlistItem) {
+			defer wg.Done()
+			product, err := wc.Products.GetProduct(ctx, item.ProductID)
+			if err != nil {
+				if !errors.Is(err, clients.ErrProductNotFound) {
+					log.Printf("⚠️ [GetWishlist] Failed to load product %s: %v", item.ProductID, err)
+				}
+				return
+			}
+			item.Product = product
+		}(&items[i])
+	}
+	wg.Wait()
+
+	c.JSON(http.StatusOK, models.Wishlist{UserID: userID, Items: items})
+}
+
+// AddToWishlist saves a product to the user's wishlist. Adding a product that
+// is already there succeeds without changing it.
+func (wc *WishlistController) AddToWishlist(c *gin.Context) {
+	userID := c.GetHeader("X-User-ID")
+	if userID == "" {
+		httputil.RespondError(c, http.StatusUnauthorized, "user not authorized")
+		return
+	}
+
+	productID, ok := wishlistProductID(c)
+	if !ok {
+		return
+	}
+
+	ctx := c.Request.Context()
+
+	if _, err := wc.Products.GetProduct(ctx, productID); err != nil {
+		if errors.Is(err, clients.ErrProductNotFound) {
+			httputil.RespondError(c, http.StatusNotFound, "product not found")
+			return
+		}
+		log.Printf("❌ [AddToWishlist] Failed to look up product %s: %v", productID, err)
+		httputil.RespondError(c, http.StatusBadGateway, "failed to look up product")
+		return
+	}
+
+	added, err := wc.Store.Add(ctx, userID, productID)
+	if err != nil {
+		log.Printf("❌ [AddToWishlist] Failed to update wishlist for userID=%s: %v", userID, err)
+		httputil.RespondError(c, http.StatusInternalServerError, "failed to update wishlist")
+		return
+	}
+
+	status := http.StatusOK
+	if added {
+		status = http.StatusCreated
+	}
+	c.JSON(status, gin.H{"product_id": productID, "added": added})
+}
+
+// RemoveFromWishlist removes a product from the user's wishlist.
+func (wc *WishlistController) RemoveFromWishlist(c *gin.Context) {
+	userID := c.GetHeader("X-User-ID")
+	if userID == "" {
+		httputil.RespondError(c, http.StatusUnauthorized, "user not authorized")
+		return
+	}
+
+	productID, ok := wishlistProductID(c)
+	if !ok {
+		return
+	}
+
+	if err := wc.Store.Remove(c.Request.Context(), userID, productID); err != nil {
+		log.Printf("❌ [RemoveFromWishlist] Failed to update wishlist for userID=%s: %v", userID, err)
+		httputil.RespondError(c, http.StatusInternalServerError, "failed to update wishlist")
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// wishlistProductID reads the product_id path parameter in canonical form,
+// writing a 400 when it is not a UUID.
+func wishlistProductID(c *gin.Context) (string, bool) {
+	id, err := uuid.Parse(c.Param("product_id"))
+	if err != nil {
+		httputil.RespondError(c, http.StatusBadRequest, "invalid product_id")
+		return "", false
+	}
+	return id.String(), true
+}
@@ -0,0 +1,185 @@
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"cart-service/clients"
+	"cart-service/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	testUserID    = "user-1"
+	testProductID = "3f1c2a4e-8b7d-4c6a-9e2f-1a2b3c4d5e6f"
+)
+
+// memWishlist is an in-memory WishlistStore that keeps insertion order.
+type memWishlist struct {
+	items map[string][]models.WishlistItem
+}
+
+func newMemWishlist() *memWishlist {
+	return &memWishlist{items: make(map[string][]models.WishlistItem)}
+}
+
+func (m *memWishlist) Add(_ context.Context, userID, productID string) (bool, error) {
+	for _, it := range m.items[userID] {
+		if it.ProductID == productID {
+			return false, nil
+		}
+	}
+	m.items[userID] = append([]models.WishlistItem{{ProductID: productID, AddedAt: time.Now()}}, m.items[userID]...)
+	return true, nil
+}
+
+func (m *memWishlist) Remove(_ context.Context, userID, productID string) error {
+	kept := m.items[userID][:0]
+	for _, it := range m.items[userID] {
+		if it.ProductID != productID {
+			kept = append(kept, it)
+		}
+	}
+	m.items[userID] = kept
+	return nil
+}
+
+func (m *memWishlist) List(_ context.Context, userID string) ([]models.WishlistItem, error) {
+	return append([]models.WishlistItem(nil), m.items[userID]...), nil
+}
+
+type fakeProducts map[string]string
+
+func (f fakeProducts) GetProduct(_ context.Context, productID string) (json.RawMessage, error) {
+	p, ok := f[productID]
+	if !ok {
+		return nil, clients.ErrProductNotFound
+	}
+	return json.RawMessage(p), nil
+}
+
+func newWishlistRouter(store WishlistStore, products ProductFetcher) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	wc := NewWishlistController(store, products)
+	r := gin.New()
+	r.GET("/wishlist", wc.GetWishlist)
+	r.POST("/wishlist/:product_id", wc.AddToWishlist)
+	r.DELETE("/wishlist/:product_id", wc.RemoveFromWishlist)
+	return r
+}
+
+func doWishlist(r *gin.Engine, method, path string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, nil)
+	req.Header.Set("X-User-ID", testUserID)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w
+}
+
+func TestAddToWishlist(t *testing.T) {
+	store := newMemWishlist()
+	r := newWishlistRouter(store, fakeProducts{testProductID: `{"name":"Mug"}`})
+
+	w := doWishlist(r, http.MethodPost, "/wishlist/"+testProductID)
+	if w.Code != http.StatusCreated {
+		t.Fatalf("status = %d, want %d: %s", w.Code, http.StatusCreated, w.Body)
+	}
+	if got := len(store.items[testUserID]); got != 1 {
+		t.Fatalf("wishlist has %d items, want 1", got)
+	}
+}
+
+func TestAddToWishlist_DuplicateIsIdempotent(t *testing.T) {
+	store := newMemWishlist()
+	r := newWishlistRouter(store, fakeProducts{testProductID: `{"name":"Mug"}`})
+
+	doWishlist(r, http.MethodPost, "/wishlist/"+testProductID)
+	first := store.items[testUserID][0].AddedAt
+
+	w := doWishlist(r, http.MethodPost, "/wishlist/"+testProductID)
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d: %s", w.Code, http.StatusOK, w.Body)
+	}
+	if got := len(store.items[testUserID]); got != 1 {
+		t.Fatalf("wishlist has %d items after duplicate add, want 1", got)
+	}
+	if !store.items[testUserID][0].AddedAt.Equal(first) {
+		t.Errorf("duplicate add changed added_at")
+	}
+}
+
+func TestAddToWishlist_Rejects(t *testing.T) {
+	r := newWishlistRouter(newMemWishlist(), fakeProducts{})
+
+	if w := doWishlist(r, http.MethodPost, "/wishlist/not-a-uuid"); w.Code != http.StatusBadRequest {
+		t.Errorf("invalid id: status = %d, want %d", w.Code, http.StatusBadRequest)
+	}
+	if w := doWishlist(r, http.MethodPost, "/wishlist/"+testProductID); w.Code != http.StatusNotFound {
+		t.Errorf("unknown product: status = %d, want %d", w.Code, http.StatusNotFound)
+	}
+
+	req := httptest.NewRequest(http.MethodPost, "/wishlist/"+testProductID, nil)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	if w.Code != http.StatusUnauthorized {
+		t.Errorf("no user: status = %d, want %d", w.Code, http.StatusUnauthorized)
+	}
+}
+
+func TestRemoveFromWishlist(t *testing.T) {
+	store := newMemWishlist()
+	r := newWishlistRouter(store, fakeProducts{testProductID: `{"name":"Mug"}`})
+
+	doWishlist(r, http.MethodPost, "/wishlist/"+testProductID)
+	w := doWishlist(r, http.MethodDelete, "/wishlist/"+testProductID)
+	if w.Code != http.StatusNoContent {
+		t.Fatalf("status = %d, want %d: %s", w.Code, http.StatusNoContent, w.Body)
+	}
+	if got := len(store.items[testUserID]); got != 0 {
+		t.Fatalf("wishlist has %d items after remove, want 0", got)
+	}
+
+	// Removing again is not an error.
+	if w := doWishlist(r, http.MethodDelete, "/wishlist/"+testProductID); w.Code != http.StatusNoContent {
+		t.Errorf("second remove: status = %d, want %d", w.Code, http.StatusNoContent)
+	}
+}
+
+func TestGetWishlist_Enriched(t *testing.T) {
+	const goneID = "9a8b7c6d-5e4f-4a3b-8c2d-1e0f9a8b7c6d"
+	store := newMemWishlist()
+	store.Add(context.Background(), testUserID, goneID)
+	store.Add(context.Background(), testUserID, testProductID)
+	r := newWishlistRouter(store, fakeProducts{testProductID: `{"name":"Mug"}`})
+
+	w := doWishlist(r, http.MethodGet, "/wishlist")
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d: %s", w.Code, http.StatusOK, w.Body)
+	}
+
+	var got struct {
+		Items []struct {
+			ProductID string `json:"product_id"`
+			Product   *struct {
+				Name string `json:"name"`
+			} `json:"product"`
+		} `json:"items"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &got); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if len(got.Items) != 2 {
+		t.Fatalf("got %d items, want 2", len(got.Items))
+	}
+	if got.Items[0].ProductID != testProductID || got.Items[0].Product == nil || got.Items[0].Product.Name != "Mug" {
+		t.Errorf("first item = %+v, want enriched %s", got.Items[0], testProductID)
+	}
+	if got.Items[1].ProductID != goneID || got.Items[1].Product != nil {
+		t.Errorf("second item = %+v, want %s without product", got.Items[1], goneID)
+	}
+}
@@ -2,12 +2,27 @@ package database
 
 import (
 	"context"
+	"fmt"
 	"log"
+	"time"
 
 	"github.com/redis/go-redis/v9"
 )
 
-// NewRedisClient initializes and returns a Redis client
+// Pinger is the part of a Redis client used to check connectivity.
+type Pinger interface {
+	Ping(ctx context.Context) *redis.StatusCmd
+}
+
+// NewRedisClient retries the initial PING in case Redis hasn't started yet
+// (e.g. Docker Compose).
+const (
+	redisConnectAttempts = 10
+	redisRetryInterval   = 2 * time.Second
+)
+
+// NewRedisClient initializes a Redis client and waits for it to answer a
+// PING, exiting if Redis is still unreachable after the retries.
 func NewRedisClient(redisURL string) *redis.Client {
 	opts, err := redis.ParseURL(redisURL)
 	if err != nil {
@@ -16,11 +31,32 @@ func NewRedisClient(redisURL string) *redis.Client {
 
 	client := redis.NewClient(opts)
 
-	// Optionally test the connection
-	if err := client.Ping(context.Background()).Err(); err != nil {
+	if err := WaitForRedis(context.Background(), client, redisConnectAttempts, redisRetryInterval); err != nil {
 		log.Fatalf("Failed to connect to Redis: %v", err)
 	}
 
 	log.Println("Connected to Redis")
 	return client
 }
+
+// WaitForRedis pings p up to attempts times, sleeping interval between
+// failures. It returns the last ping error if every attempt fails, or the
+// context error if ctx is done first.
+func WaitForRedis(ctx context.Context, p Pinger, attempts int, interval time.Duration) error {
+	var err error
+	for i := 0; i < attempts; i++ {
+		if err = p.Ping(ctx).Err(); err == nil {
+			return nil
+		}
+		log.Printf("Redis connection failed (%d/%d): %v", i+1, attempts, err)
+		if i == attempts-1 {
+			break
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(interval):
+		}
+	}
+	return fmt.Errorf("redis unreachable after %d attempts: %w", attempts, err)
+}
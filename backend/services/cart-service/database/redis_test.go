@@ -0,0 +1,69 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// stubPinger fails the first failures pings, then answers PONG.
+type stubPinger struct {
+	failures int
+	calls    int
+}
+
+func (s *stubPinger) Ping(ctx context.Context) *redis.StatusCmd {
+	s.calls++
+	if s.calls <= s.failures {
+		return redis.NewStatusResult("", errors.New("connection refused"))
+	}
+	return redis.NewStatusResult("PONG", nil)
+}
+
+func TestWaitForRedis_SucceedsFirstTry(t *testing.T) {
+	p := &stubPinger{}
+	if err := WaitForRedis(context.Background(), p, 3, 0); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if p.calls != 1 {
+		t.Fatalf("expected 1 ping, got %d", p.calls)
+	}
+}
+
+func TestWaitForRedis_RetriesUntilReachable(t *testing.T) {
+	p := &stubPinger{failures: 2}
+	if err := WaitForRedis(context.Background(), p, 3, 0); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if p.calls != 3 {
+		t.Fatalf("expected 3 pings, got %d", p.calls)
+	}
+}
+
+func TestWaitForRedis_GivesUpAfterAttempts(t *testing.T) {
+	p := &stubPinger{failures: 10}
+	err := WaitForRedis(context.Background(), p, 3, 0)
+	if err == nil {
+		t.Fatal("expected an error when Redis never answers")
+	}
+	if p.calls != 3 {
+		t.Fatalf("expected 3 pings, got %d", p.calls)
+	}
+}
+
+func TestWaitForRedis_StopsWhenContextDone(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	p := &stubPinger{failures: 10}
+	err := WaitForRedis(ctx, p, 5, time.Hour)
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context.Canceled, got %v", err)
+	}
+	if p.calls != 1 {
+		t.Fatalf("expected 1 ping before giving up, got %d", p.calls)
+	}
+}
@@ -0,0 +1,62 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"cart-service/models"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// WishlistRepository stores each user's wishlist as a sorted set of product
+// IDs scored by the time they were added, so entries are unique and list
+// newest first.
+type WishlistRepository struct {
+	client *redis.Client
+}
+
+func NewWishlistRepository(client *redis.Client) *WishlistRepository {
+	return &WishlistRepository{client: client}
+}
+
+func (r *WishlistRepository) getKey(userID string) string {
+	return fmt.Sprintf("wishlist:user:%s", userID)
+}
+
+// Add saves productID to the user's wishlist. It reports false if the
+// product was already there, in which case its added time is unchanged.
+func (r *WishlistRepository) Add(ctx context.Context, userID, productID string) (bool, error) {
+	added, err := r.client.ZAddNX(ctx, r.getKey(userID), redis.Z{
+		Score:  float64(time.Now().UnixMilli()),
+		Member: productID,
+	}).Result()
+	if err != nil {
+		return false, err
+	}
+	return added == 1, nil
+}
+
+// Remove deletes productID from the user's wishlist. Removing a product that
+// is not there is not an error.
+func (r *WishlistRepository) Remove(ctx context.Context, userID, productID string) error {
+	return r.client.ZRem(ctx, r.getKey(userID), productID).Err()
+}
+
+// List returns the user's wishlist, most recently added first.
+func (r *WishlistRepository) List(ctx context.Context, userID string) ([]models.WishlistItem, error) {
+	entries, err := r.client.ZRevRangeWithScores(ctx, r.getKey(userID), 0, -1).Result()
+	if err != nil {
+		return nil, err
+	}
+	items := make([]models.WishlistItem, 0, len(entries))
+	for _, e := range entries {
+		productID, _ := e.Member.(string)
+		items = append(items, models.WishlistItem{
+			ProductID: productID,
+			AddedAt:   time.UnixMilli(int64(e.Score)).UTC(),
+		})
+	}
+	return items, nil
+}
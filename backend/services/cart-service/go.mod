@@ -65,6 +65,16 @@ require (
 	github.com/aws/smithy-go v1.24.0 // indirect
 	github.com/cespare/xxhash/v2 v2.3.0 // indirect
 	github.com/dgryski/go-rendezvous v0.0.0-20200823014737-9f7001d12a5f // indirect
+	github.com/gin-contrib/cors v1.7.4 // indirect
+	go.uber.org/multierr v1.10.0 // indirect
+	golang.org/x/time v0.11.0 // indirect
 )
 
 replace github.com/yashrajoria/E-Commerce-backend/backend/pkg/aws => ../../pkg/aws
+
+require (
+	github.com/yashrajoria/common v0.0.0
+	go.uber.org/zap v1.27.0
+)
+
+replace github.com/yashrajoria/common => ../common
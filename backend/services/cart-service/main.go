@@ -10,6 +10,8 @@ import (
 	"time"
 
 	"github.com/gin-gonic/gin"
+	commonmw "github.com/yashrajoria/common/middleware"
+	"go.uber.org/zap"
 
 	"cart-service/config"
 	"cart-service/database"
@@ -19,6 +21,8 @@ import (
 )
 
 func main() {
+	logger, _ := zap.NewProduction()
+	defer logger.Sync()
 
 	// Load environment configuration
 	cfg := config.Load()
@@ -34,7 +38,10 @@ func main() {
 	snsClient := aws_pkg.NewSNSClient(awsCfg)
 
 	// Initialize Gin router
-	router := gin.Default()
+	router := gin.New()
+	router.Use(gin.Logger())
+	router.Use(commonmw.RequestID())
+	router.Use(commonmw.Recovery(logger, "cart-service", os.Stdout))
 
 	// Register routes
 	routes.RegisterCartRoutes(router, redisClient, snsClient, cfg)
@@ -0,0 +1,17 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+)
+
+type WishlistItem struct {
+	ProductID string          `json:"product_id"`
+	AddedAt   time.Time       `json:"added_at"`
+	Product   json.RawMessage `json:"product,omitempty"` // product-service response; absent if the product is gone
+}
+
+type Wishlist struct {
+	UserID string         `json:"user_id"`
+	Items  []WishlistItem `json:"items"`
+}
@@ -1,6 +1,7 @@
 package routes
 
 import (
+	"cart-service/clients"
 	"cart-service/config"
 	"cart-service/controllers"
 	"cart-service/database"
@@ -16,6 +17,9 @@ func RegisterCartRoutes(
 	snsClient *aws_pkg.SNSClient,
 	cfg config.Config,
 ) {
+	readyController := controllers.NewReadyController(redisClient)
+	r.GET("/ready", readyController.Ready)
+
 	repo := database.NewCartRepository(redisClient, cfg.CartTTL)
 	controller := controllers.NewCartController(repo, snsClient, cfg)
 
@@ -26,7 +30,20 @@ func RegisterCartRoutes(
 		api.GET("/", controller.GetCart)
 		api.POST("/add", controller.AddItems)
 		api.DELETE("/remove/:product_id", controller.RemoveItem)
+		api.DELETE("", controller.ClearCart)
 		api.DELETE("/clear", controller.ClearCart)
 		api.POST("/checkout", controller.Checkout)
 	}
+
+	wishlistController := controllers.NewWishlistController(
+		database.NewWishlistRepository(redisClient),
+		clients.NewProductClient(cfg.ProductServiceURL),
+	)
+
+	wishlist := r.Group("/wishlist")
+	{
+		wishlist.GET("", wishlistController.GetWishlist)
+		wishlist.POST("/:product_id", wishlistController.AddToWishlist)
+		wishlist.DELETE("/:product_id", wishlistController.RemoveFromWishlist)
+	}
 }
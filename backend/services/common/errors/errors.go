@@ -43,7 +43,8 @@ func New(code int, message string, err error) *Error {
 	}
 }
 
-// Common error types
+// Common error types. ErrNotFound doubles as the sentinel repositories wrap
+// when a lookup matches no record; check for it with errors.Is.
 var (
 	ErrBadRequest         = New(http.StatusBadRequest, "Bad request", nil)
 	ErrUnauthorized       = New(http.StatusUnauthorized, "Unauthorized", nil)
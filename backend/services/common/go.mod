@@ -3,6 +3,7 @@ module github.com/yashrajoria/common
 go 1.25
 
 require (
+	github.com/gin-contrib/cors v1.7.4
 	github.com/gin-gonic/gin v1.10.1
 	github.com/golang-jwt/jwt/v4 v4.5.1
 	github.com/joho/godotenv v1.5.1
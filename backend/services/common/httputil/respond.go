@@ -0,0 +1,45 @@
+package httputil
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RespondError aborts the request with the error body every service returns:
+// {error, code, request_id}. code is a snake_case form of the status text,
+// e.g. "not_found".
+func RespondError(c *gin.Context, status int, message string) {
+	respond(c, status, "", message, nil)
+}
+
+// RespondErrorCode is RespondError with an explicit machine-readable code,
+// for callers that need to tell errors with the same status apart.
+func RespondErrorCode(c *gin.Context, status int, code, message string) {
+	respond(c, status, code, message, nil)
+}
+
+// RespondErrorDetails is RespondError with a details field, e.g. the
+// validation failures behind a 400.
+func RespondErrorDetails(c *gin.Context, status int, message string, details interface{}) {
+	respond(c, status, "", message, details)
+}
+
+func respond(c *gin.Context, status int, code, message string, details interface{}) {
+	if status < 400 || status > 599 {
+		status = http.StatusInternalServerError
+	}
+	if code == "" {
+		code = strings.ReplaceAll(strings.ToLower(http.StatusText(status)), " ", "_")
+	}
+
+	body := gin.H{"error": message, "code": code}
+	if details != nil {
+		body["details"] = details
+	}
+	if requestID := c.GetHeader("X-Request-ID"); requestID != "" {
+		body["request_id"] = requestID
+	}
+	c.AbortWithStatusJSON(status, body)
+}
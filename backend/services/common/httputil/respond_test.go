@@ -0,0 +1,71 @@
+package httputil
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestRespondError_UniformShape(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name       string
+		respond    func(c *gin.Context)
+		wantStatus int
+		wantCode   string
+	}{
+		{"derived code", func(c *gin.Context) { RespondError(c, 400, "Invalid user ID format") }, 400, "bad_request"},
+		{"explicit code", func(c *gin.Context) { RespondErrorCode(c, 409, "order_paid", "Invalid user ID format") }, 409, "order_paid"},
+		{"invalid status", func(c *gin.Context) { RespondError(c, 0, "Invalid user ID format") }, 500, "internal_server_error"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest(http.MethodGet, "/", nil)
+			c.Request.Header.Set("X-Request-ID", "req-123")
+
+			tt.respond(c)
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("expected status %d, got %d", tt.wantStatus, w.Code)
+			}
+			var body map[string]string
+			if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+				t.Fatalf("invalid error body %q: %v", w.Body.String(), err)
+			}
+			if body["error"] != "Invalid user ID format" || body["code"] != tt.wantCode || body["request_id"] != "req-123" {
+				t.Fatalf("unexpected body %v", body)
+			}
+			if !c.IsAborted() {
+				t.Fatal("expected the request to be aborted")
+			}
+		})
+	}
+}
+
+func TestRespondErrorDetails(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodPost, "/", nil)
+
+	RespondErrorDetails(c, http.StatusBadRequest, "Validation failed", []string{"name is required"})
+
+	var body struct {
+		Error     string   `json:"error"`
+		Code      string   `json:"code"`
+		Details   []string `json:"details"`
+		RequestID *string  `json:"request_id"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+		t.Fatalf("invalid error body %q: %v", w.Body.String(), err)
+	}
+	if body.Error != "Validation failed" || body.Code != "bad_request" || len(body.Details) != 1 || body.RequestID != nil {
+		t.Fatalf("unexpected body %s", w.Body)
+	}
+}
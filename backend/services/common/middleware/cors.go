@@ -0,0 +1,139 @@
+package middleware
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/gin-contrib/cors"
+	"github.com/gin-gonic/gin"
+)
+
+var (
+	defaultCORSOrigins = []string{"http://localhost:3000", "http://localhost:3001"}
+	defaultCORSMethods = []string{"POST", "HEAD", "PATCH", "OPTIONS", "GET", "PUT", "DELETE"}
+	defaultCORSHeaders = []string{"Content-Type", "Content-Length", "Accept-Encoding", "X-CSRF-Token", "Authorization", "Accept", "Origin", "Cache-Control", "X-Requested-With"}
+)
+
+var corsMethods = map[string]bool{
+	http.MethodGet: true, http.MethodHead: true, http.MethodPost: true, http.MethodPut: true,
+	http.MethodPatch: true, http.MethodDelete: true, http.MethodOptions: true,
+}
+
+// CORSConfig is the browser access policy applied to every route.
+type CORSConfig struct {
+	AllowOrigins []string
+	AllowMethods []string
+	AllowHeaders []string
+}
+
+// LoadCORSConfig reads ALLOWED_ORIGINS, ALLOWED_METHODS and ALLOWED_HEADERS as
+// comma-separated lists, falling back to the local development defaults for
+// any that are unset. Credentials are always allowed, and browsers refuse a
+// wildcard origin on credentialed requests, so ALLOWED_ORIGINS="*" is
+// rejected: list the origins instead.
+func LoadCORSConfig() (CORSConfig, error) {
+	var cfg CORSConfig
+	var err error
+
+	origins := strings.TrimSpace(os.Getenv("ALLOWED_ORIGINS"))
+	switch origins {
+	case "*":
+		return CORSConfig{}, fmt.Errorf("ALLOWED_ORIGINS=* cannot be used with credentials, list the allowed origins")
+	case "":
+		cfg.AllowOrigins = defaultCORSOrigins
+	default:
+		if cfg.AllowOrigins, err = ParseOrigins(origins); err != nil {
+			return CORSConfig{}, err
+		}
+	}
+
+	cfg.AllowMethods = defaultCORSMethods
+	if raw := strings.TrimSpace(os.Getenv("ALLOWED_METHODS")); raw != "" {
+		if cfg.AllowMethods, err = ParseMethods(raw); err != nil {
+			return CORSConfig{}, err
+		}
+	}
+
+	cfg.AllowHeaders = defaultCORSHeaders
+	if raw := strings.TrimSpace(os.Getenv("ALLOWED_HEADERS")); raw != "" {
+		if cfg.AllowHeaders, err = ParseHeaders(raw); err != nil {
+			return CORSConfig{}, err
+		}
+	}
+	return cfg, nil
+}
+
+// ParseOrigins parses a comma-separated list of origins. Each entry must be a
+// bare scheme://host[:port] with an http or https scheme.
+func ParseOrigins(raw string) ([]string, error) {
+	var origins []string
+	for _, o := range splitList(raw) {
+		o = strings.TrimRight(o, "/")
+		u, err := url.Parse(o)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" ||
+			u.Path != "" || u.RawQuery != "" || u.Fragment != "" || u.User != nil {
+			return nil, fmt.Errorf("invalid CORS origin %q, expected scheme://host[:port]", o)
+		}
+		origins = append(origins, o)
+	}
+	if len(origins) == 0 {
+		return nil, fmt.Errorf("ALLOWED_ORIGINS has no origins")
+	}
+	return origins, nil
+}
+
+// ParseMethods parses a comma-separated list of HTTP methods.
+func ParseMethods(raw string) ([]string, error) {
+	var methods []string
+	for _, m := range splitList(raw) {
+		m = strings.ToUpper(m)
+		if !corsMethods[m] {
+			return nil, fmt.Errorf("invalid CORS method %q", m)
+		}
+		methods = append(methods, m)
+	}
+	if len(methods) == 0 {
+		return nil, fmt.Errorf("ALLOWED_METHODS has no methods")
+	}
+	return methods, nil
+}
+
+// ParseHeaders parses a comma-separated list of request header names.
+func ParseHeaders(raw string) ([]string, error) {
+	var headers []string
+	for _, h := range splitList(raw) {
+		if strings.ContainsAny(h, " \t:") {
+			return nil, fmt.Errorf("invalid CORS header %q", h)
+		}
+		headers = append(headers, h)
+	}
+	if len(headers) == 0 {
+		return nil, fmt.Errorf("ALLOWED_HEADERS has no headers")
+	}
+	return headers, nil
+}
+
+func splitList(raw string) []string {
+	var out []string
+	for _, s := range strings.Split(raw, ",") {
+		if s = strings.TrimSpace(s); s != "" {
+			out = append(out, s)
+		}
+	}
+	return out
+}
+
+// CORS applies cfg to every request. Requests from an origin that is not
+// allowed are rejected with 403.
+func CORS(cfg CORSConfig) gin.HandlerFunc {
+	return cors.New(cors.Config{
+		AllowOrigins:     cfg.AllowOrigins,
+		AllowMethods:     cfg.AllowMethods,
+		AllowHeaders:     cfg.AllowHeaders,
+		ExposeHeaders:    []string{RequestIDHeader},
+		AllowCredentials: true,
+	})
+}
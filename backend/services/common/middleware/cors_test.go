@@ -0,0 +1,104 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestLoadCORSConfig_FromEnv(t *testing.T) {
+	t.Setenv("ALLOWED_ORIGINS", "https://shop.example.com, https://admin.example.com/")
+	t.Setenv("ALLOWED_METHODS", "get,post")
+	t.Setenv("ALLOWED_HEADERS", "Authorization, Content-Type")
+
+	cfg, err := LoadCORSConfig()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(cfg.AllowOrigins) != 2 || cfg.AllowOrigins[1] != "https://admin.example.com" {
+		t.Fatalf("unexpected origins: %v", cfg.AllowOrigins)
+	}
+	if len(cfg.AllowMethods) != 2 || cfg.AllowMethods[0] != "GET" {
+		t.Fatalf("unexpected methods: %v", cfg.AllowMethods)
+	}
+	if len(cfg.AllowHeaders) != 2 {
+		t.Fatalf("unexpected headers: %v", cfg.AllowHeaders)
+	}
+}
+
+func TestLoadCORSConfig_Defaults(t *testing.T) {
+	t.Setenv("ALLOWED_ORIGINS", "")
+	t.Setenv("ALLOWED_METHODS", "")
+	t.Setenv("ALLOWED_HEADERS", "")
+
+	cfg, err := LoadCORSConfig()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(cfg.AllowOrigins) != len(defaultCORSOrigins) {
+		t.Fatalf("expected default origins, got %+v", cfg)
+	}
+}
+
+func TestLoadCORSConfig_Invalid(t *testing.T) {
+	cases := map[string][2]string{
+		"origin without scheme": {"ALLOWED_ORIGINS", "shop.example.com"},
+		"origin with path":      {"ALLOWED_ORIGINS", "https://shop.example.com/app"},
+		"ftp origin":            {"ALLOWED_ORIGINS", "ftp://shop.example.com"},
+		"wildcard origin":       {"ALLOWED_ORIGINS", "*"},
+		"unknown method":        {"ALLOWED_METHODS", "GET,FETCH"},
+		"header with space":     {"ALLOWED_HEADERS", "X Custom"},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			t.Setenv("ALLOWED_ORIGINS", "")
+			t.Setenv("ALLOWED_METHODS", "")
+			t.Setenv("ALLOWED_HEADERS", "")
+			t.Setenv(tc[0], tc[1])
+			if _, err := LoadCORSConfig(); err == nil {
+				t.Fatalf("expected error for %s=%q", tc[0], tc[1])
+			}
+		})
+	}
+}
+
+func TestCORS_ConfiguredOrigins(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	t.Setenv("ALLOWED_ORIGINS", "https://shop.example.com")
+
+	cfg, err := LoadCORSConfig()
+	if err != nil {
+		t.Fatalf("load config: %v", err)
+	}
+	r := gin.New()
+	r.Use(CORS(cfg))
+	r.GET("/ping", func(c *gin.Context) { c.Status(http.StatusOK) })
+
+	cases := []struct {
+		origin     string
+		wantStatus int
+		wantHeader string
+	}{
+		{origin: "https://shop.example.com", wantStatus: http.StatusOK, wantHeader: "https://shop.example.com"},
+		{origin: "http://localhost:3000", wantStatus: http.StatusForbidden},
+		{origin: "https://evil.example.com", wantStatus: http.StatusForbidden},
+	}
+	for _, tc := range cases {
+		req := httptest.NewRequest(http.MethodGet, "/ping", nil)
+		req.Header.Set("Origin", tc.origin)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		if w.Code != tc.wantStatus {
+			t.Errorf("origin %s: expected %d, got %d", tc.origin, tc.wantStatus, w.Code)
+		}
+		if got := w.Header().Get("Access-Control-Allow-Origin"); got != tc.wantHeader {
+			t.Errorf("origin %s: expected Access-Control-Allow-Origin %q, got %q", tc.origin, tc.wantHeader, got)
+		}
+		if tc.wantStatus == http.StatusOK && w.Header().Get("Access-Control-Allow-Credentials") != "true" {
+			t.Errorf("origin %s: expected credentials to be allowed", tc.origin)
+		}
+	}
+}
@@ -0,0 +1,81 @@
+package middleware
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"runtime/debug"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+const panicMetricNamespace = "ShopSwift/Services"
+
+// Recovery replaces gin.Recovery. A panic is logged with its stack through
+// logger, counted as a Panics metric in CloudWatch Embedded Metric Format on
+// metrics, and answered with a plain 500 carrying the request ID.
+func Recovery(logger *zap.Logger, service string, metrics io.Writer) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		defer func() {
+			rec := recover()
+			if rec == nil {
+				return
+			}
+			// The client went away mid-response; net/http handles this quietly
+			if err, ok := rec.(error); ok && errors.Is(err, http.ErrAbortHandler) {
+				panic(rec)
+			}
+
+			requestID := c.GetHeader("X-Request-ID")
+			logger.Error("Panic recovered",
+				zap.Any("panic", rec),
+				zap.String("request_id", requestID),
+				zap.String("method", c.Request.Method),
+				zap.String("path", c.Request.URL.Path),
+				zap.ByteString("stack", debug.Stack()),
+			)
+			if err := writePanicMetric(metrics, service, c.FullPath()); err != nil {
+				logger.Warn("Failed to record panic metric", zap.Error(err))
+			}
+
+			if c.Writer.Written() {
+				c.Abort()
+				return
+			}
+			body := gin.H{"error": "Internal server error"}
+			if requestID != "" {
+				body["request_id"] = requestID
+			}
+			c.AbortWithStatusJSON(http.StatusInternalServerError, body)
+		}()
+		c.Next()
+	}
+}
+
+func writePanicMetric(w io.Writer, service, route string) error {
+	if w == nil {
+		return nil
+	}
+	doc := map[string]interface{}{
+		"_aws": map[string]interface{}{
+			"Timestamp": time.Now().UnixMilli(),
+			"CloudWatchMetrics": []map[string]interface{}{{
+				"Namespace":  panicMetricNamespace,
+				"Dimensions": [][]string{{"Service"}},
+				"Metrics":    []map[string]string{{"Name": "Panics", "Unit": "Count"}},
+			}},
+		},
+		"Service": service,
+		"Route":   route,
+		"Panics":  1,
+	}
+	line, err := json.Marshal(doc)
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(append(line, '\n'))
+	return err
+}
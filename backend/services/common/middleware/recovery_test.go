@@ -0,0 +1,80 @@
+package middleware
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestRecovery_LogsPanicAndReturns500(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	core, logs := observer.New(zapcore.ErrorLevel)
+	var metrics bytes.Buffer
+
+	r := gin.New()
+	r.Use(Recovery(zap.New(core), "catalog-service", &metrics))
+	r.GET("/boom", func(c *gin.Context) {
+		panic("nil map write")
+	})
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/boom", nil)
+	req.Header.Set("X-Request-ID", "req-123")
+	r.ServeHTTP(w, req)
+
+	if w.Code != http.StatusInternalServerError {
+		t.Fatalf("expected 500, got %d", w.Code)
+	}
+	var body map[string]string
+	if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+		t.Fatalf("invalid JSON body: %v", err)
+	}
+	if body["request_id"] != "req-123" || body["error"] == "" {
+		t.Fatalf("unexpected body %v", body)
+	}
+
+	entries := logs.FilterMessage("Panic recovered").All()
+	if len(entries) != 1 {
+		t.Fatalf("expected one panic log entry, got %d", len(entries))
+	}
+	fields := entries[0].ContextMap()
+	if fields["panic"] != "nil map write" || fields["request_id"] != "req-123" {
+		t.Fatalf("unexpected log fields %v", fields)
+	}
+	if stack, _ := fields["stack"].(string); !bytes.Contains([]byte(stack), []byte("recovery_test.go")) {
+		t.Fatalf("expected stack to include the panicking handler, got %q", stack)
+	}
+
+	var metric map[string]interface{}
+	if err := json.Unmarshal(metrics.Bytes(), &metric); err != nil {
+		t.Fatalf("invalid metric line %q: %v", metrics.String(), err)
+	}
+	if metric["Panics"] != float64(1) || metric["Service"] != "catalog-service" || metric["Route"] != "/boom" {
+		t.Fatalf("unexpected metric %v", metric)
+	}
+}
+
+func TestRecovery_PassesThroughWithoutPanic(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	var metrics bytes.Buffer
+
+	r := gin.New()
+	r.Use(Recovery(zap.NewNop(), "catalog-service", &metrics))
+	r.GET("/ok", func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"ok": true})
+	})
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/ok", nil))
+
+	if w.Code != http.StatusOK || metrics.Len() != 0 {
+		t.Fatalf("expected untouched 200 and no metric, got %d / %q", w.Code, metrics.String())
+	}
+}
@@ -0,0 +1,52 @@
+package middleware
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RequestIDHeader carries a request's correlation ID from the client through
+// the gateway to each service, and back on the response.
+const RequestIDHeader = "X-Request-ID"
+
+// RequestIDKey is the gin context key holding the request ID.
+const RequestIDKey = "request_id"
+
+// RequestID gives every request an ID: the caller's X-Request-ID when it is
+// well formed, otherwise a new random one. The ID is set on the request so
+// handlers, logs and outgoing calls see the same value, stored under
+// RequestIDKey, and echoed in the response header.
+func RequestID() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id := c.GetHeader(RequestIDHeader)
+		if !validRequestID(id) {
+			id = newRequestID()
+			c.Request.Header.Set(RequestIDHeader, id)
+		}
+		c.Set(RequestIDKey, id)
+		c.Header(RequestIDHeader, id)
+		c.Next()
+	}
+}
+
+// validRequestID accepts 1-128 visible ASCII characters, so a client cannot
+// smuggle control characters or oversized values into logs.
+func validRequestID(id string) bool {
+	if id == "" || len(id) > 128 {
+		return false
+	}
+	for i := 0; i < len(id); i++ {
+		if id[i] < '!' || id[i] > '~' {
+			return false
+		}
+	}
+	return true
+}
+
+func newRequestID() string {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
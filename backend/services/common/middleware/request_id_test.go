@@ -0,0 +1,80 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func newRequestIDRouter(seen *[]string) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(RequestID())
+	r.GET("/", func(c *gin.Context) {
+		// Every view of the ID inside the request must agree
+		*seen = append(*seen, c.GetHeader(RequestIDHeader), c.GetString(RequestIDKey))
+		c.Status(http.StatusOK)
+	})
+	return r
+}
+
+func TestRequestID_GeneratesWhenAbsent(t *testing.T) {
+	var seen []string
+	r := newRequestIDRouter(&seen)
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	id := w.Header().Get(RequestIDHeader)
+	if id == "" {
+		t.Fatal("expected a generated X-Request-ID on the response")
+	}
+	for _, s := range seen {
+		if s != id {
+			t.Fatalf("expected request ID %q throughout the request, saw %v", id, seen)
+		}
+	}
+
+	w2 := httptest.NewRecorder()
+	r.ServeHTTP(w2, httptest.NewRequest(http.MethodGet, "/", nil))
+	if w2.Header().Get(RequestIDHeader) == id {
+		t.Fatal("expected a distinct ID per request")
+	}
+}
+
+func TestRequestID_EchoesCallerID(t *testing.T) {
+	var seen []string
+	r := newRequestIDRouter(&seen)
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set(RequestIDHeader, "req-123")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	if got := w.Header().Get(RequestIDHeader); got != "req-123" {
+		t.Fatalf("expected the caller's ID echoed, got %q", got)
+	}
+	if seen[0] != "req-123" || seen[1] != "req-123" {
+		t.Fatalf("expected the caller's ID inside the request, saw %v", seen)
+	}
+}
+
+func TestRequestID_ReplacesMalformedID(t *testing.T) {
+	var seen []string
+	r := newRequestIDRouter(&seen)
+
+	for _, bad := range []string{"has space", strings.Repeat("a", 129)} {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.Header.Set(RequestIDHeader, bad)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		got := w.Header().Get(RequestIDHeader)
+		if got == "" || got == bad {
+			t.Fatalf("expected malformed ID %q to be replaced, got %q", bad, got)
+		}
+	}
+}
@@ -0,0 +1,50 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestRequestTimeout_SlowHandlerGets503(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(RequestTimeout(20 * time.Millisecond))
+	r.GET("/slow", func(c *gin.Context) {
+		select {
+		case <-c.Request.Context().Done():
+			return
+		case <-time.After(time.Second):
+			c.JSON(http.StatusOK, gin.H{"ok": true})
+		}
+	})
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/slow", nil))
+
+	if w.Code != http.StatusServiceUnavailable {
+		t.Fatalf("expected 503, got %d: %s", w.Code, w.Body.String())
+	}
+}
+
+func TestRequestTimeout_FastHandlerUnaffected(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(RequestTimeout(time.Second))
+	r.GET("/fast", func(c *gin.Context) {
+		if _, ok := c.Request.Context().Deadline(); !ok {
+			t.Errorf("expected request context to carry a deadline")
+		}
+		c.JSON(http.StatusOK, gin.H{"ok": true})
+	})
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/fast", nil))
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", w.Code)
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"time"
 
 	aws_pkg "github.com/yashrajoria/E-Commerce-backend/backend/pkg/aws"
 )
@@ -15,6 +16,8 @@ type Config struct {
 	Database    string // MongoDB database name
 	JWTSecret   string // JWT secret for authentication
 	Port        string // Service port (default: 8084)
+	// RequestTimeout bounds each HTTP request (REQUEST_TIMEOUT, default: 30s)
+	RequestTimeout time.Duration
 }
 
 // LoadConfig loads environment variables into Config struct and validates them.
@@ -30,6 +33,15 @@ func LoadConfig() (*Config, error) {
 		cfg.Port = "8084"
 	}
 
+	cfg.RequestTimeout = 30 * time.Second
+	if v := os.Getenv("REQUEST_TIMEOUT"); v != "" {
+		timeout, err := time.ParseDuration(v)
+		if err != nil || timeout <= 0 {
+			return nil, fmt.Errorf("invalid REQUEST_TIMEOUT: %q", v)
+		}
+		cfg.RequestTimeout = timeout
+	}
+
 	if os.Getenv("AWS_USE_SECRETS") == "true" {
 		if awsCfg, err := aws_pkg.LoadAWSConfig(context.Background()); err == nil {
 			sm := aws_pkg.NewSecretsClient(awsCfg)
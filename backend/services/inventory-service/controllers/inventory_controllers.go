@@ -5,6 +5,7 @@ import (
 	"net/http"
 
 	"github.com/gin-gonic/gin"
+	"github.com/yashrajoria/common/httputil"
 	db "github.com/yashrajoria/inventory-service/database"
 	models "github.com/yashrajoria/inventory-service/database"
 	"go.mongodb.org/mongo-driver/bson"
@@ -13,7 +14,7 @@ import (
 
 func GetInventory(c *gin.Context) {
 	if c.Param("productID") == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Missing product ID"})
+		httputil.RespondError(c, http.StatusBadRequest, "Missing product ID")
 		return
 	}
 	productID := c.Param("productID")
@@ -22,14 +23,14 @@ func GetInventory(c *gin.Context) {
 
 	if err != nil {
 		log.Println("Invalid product ID format:", err)
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch inventory for product"})
+		httputil.RespondError(c, http.StatusInternalServerError, "Failed to fetch inventory for product")
 
 		return
 	}
 
 	var inventory []models.Inventory
 
-	err = db.DB.Collection("products").FindOne(c, bson.M{"_id": objectId}).Decode(&inventory)
+	err = db.DB.Collection("products").FindOne(c.Request.Context(), bson.M{"_id": objectId}).Decode(&inventory)
 	if err != nil {
 		log.Println("Error finding product:", err)
 		c.JSON(http.StatusNotFound, gin.H{"message": "Product not found"})
@@ -0,0 +1,94 @@
+package controllers
+
+import (
+	"context"
+	"encoding/csv"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yashrajoria/common/httputil"
+	db "github.com/yashrajoria/inventory-service/database"
+	models "github.com/yashrajoria/inventory-service/database"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// exportBatchSize is both the cursor batch size and how many rows are
+// buffered before the CSV is flushed to the client.
+const exportBatchSize = 500
+
+var inventoryCSVHeader = []string{"product_id", "available", "reserved", "threshold", "updated_at"}
+
+// inventoryCursor is the part of *mongo.Cursor the export needs.
+type inventoryCursor interface {
+	Next(ctx context.Context) bool
+	Decode(val interface{}) error
+	Err() error
+}
+
+// ExportInventory streams every inventory record as CSV. Records are pulled
+// from the cursor in batches so the whole table is never held in memory.
+func ExportInventory(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	opts := options.Find().
+		SetBatchSize(exportBatchSize).
+		SetSort(bson.D{{Key: "product_id", Value: 1}})
+	cur, err := db.DB.Collection("products").Find(ctx, bson.M{}, opts)
+	if err != nil {
+		log.Println("Error scanning inventory for export:", err)
+		httputil.RespondError(c, http.StatusInternalServerError, "Failed to export inventory")
+		return
+	}
+	defer cur.Close(ctx)
+
+	c.Header("Content-Type", "text/csv")
+	c.Header("Content-Disposition", `attachment; filename="inventory.csv"`)
+	c.Status(http.StatusOK)
+
+	// Headers are already sent, so a failure part-way can only be logged
+	if err := writeInventoryCSV(ctx, c.Writer, cur); err != nil {
+		log.Println("Error writing inventory export:", err)
+	}
+}
+
+func writeInventoryCSV(ctx context.Context, w io.Writer, cur inventoryCursor) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(inventoryCSVHeader); err != nil {
+		return err
+	}
+
+	rows := 0
+	for cur.Next(ctx) {
+		var inv models.Inventory
+		if err := cur.Decode(&inv); err != nil {
+			return err
+		}
+		record := []string{
+			inv.ProductID,
+			strconv.Itoa(inv.Quantity),
+			strconv.Itoa(inv.Reserved),
+			strconv.Itoa(inv.Threshold),
+			inv.UpdatedAt.UTC().Format(time.RFC3339),
+		}
+		if err := cw.Write(record); err != nil {
+			return err
+		}
+		rows++
+		if rows%exportBatchSize == 0 {
+			cw.Flush()
+			if err := cw.Error(); err != nil {
+				return err
+			}
+		}
+	}
+	cw.Flush()
+	if err := cw.Error(); err != nil {
+		return err
+	}
+	return cur.Err()
+}
@@ -0,0 +1,101 @@
+package controllers
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	models "github.com/yashrajoria/inventory-service/database"
+)
+
+// fakeCursor serves records in fixed-size batches like a mongo cursor.
+type fakeCursor struct {
+	pages   [][]models.Inventory
+	page    int
+	idx     int
+	current models.Inventory
+	err     error
+}
+
+func (f *fakeCursor) Next(ctx context.Context) bool {
+	for f.page < len(f.pages) {
+		if f.idx < len(f.pages[f.page]) {
+			f.current = f.pages[f.page][f.idx]
+			f.idx++
+			return true
+		}
+		f.page++
+		f.idx = 0
+	}
+	return false
+}
+
+func (f *fakeCursor) Decode(val interface{}) error {
+	*val.(*models.Inventory) = f.current
+	return nil
+}
+
+func (f *fakeCursor) Err() error { return f.err }
+
+func pagedInventory(total, pageSize int, updated time.Time) [][]models.Inventory {
+	var pages [][]models.Inventory
+	for start := 0; start < total; start += pageSize {
+		var page []models.Inventory
+		for i := start; i < total && i < start+pageSize; i++ {
+			page = append(page, models.Inventory{
+				ProductID: fmt.Sprintf("prod-%04d", i),
+				Quantity:  i,
+				Reserved:  i % 3,
+				Threshold: 5,
+				UpdatedAt: updated,
+			})
+		}
+		pages = append(pages, page)
+	}
+	return pages
+}
+
+func TestWriteInventoryCSV_MultiplePages(t *testing.T) {
+	updated := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	total := exportBatchSize*2 + 7
+	cur := &fakeCursor{pages: pagedInventory(total, exportBatchSize, updated)}
+
+	var buf bytes.Buffer
+	if err := writeInventoryCSV(context.Background(), &buf, cur); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	records, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatalf("output is not valid CSV: %v", err)
+	}
+	if len(records) != total+1 {
+		t.Fatalf("expected header + %d rows, got %d records", total, len(records))
+	}
+	if got := fmt.Sprint(records[0]); got != fmt.Sprint(inventoryCSVHeader) {
+		t.Fatalf("unexpected header %v", records[0])
+	}
+
+	// First row of the last page
+	row := records[exportBatchSize*2+1]
+	want := []string{fmt.Sprintf("prod-%04d", exportBatchSize*2), fmt.Sprint(exportBatchSize * 2), fmt.Sprint(exportBatchSize * 2 % 3), "5", "2024-03-01T12:00:00Z"}
+	if fmt.Sprint(row) != fmt.Sprint(want) {
+		t.Fatalf("expected row %v, got %v", want, row)
+	}
+}
+
+func TestWriteInventoryCSV_CursorError(t *testing.T) {
+	cur := &fakeCursor{
+		pages: pagedInventory(3, 2, time.Now()),
+		err:   errors.New("cursor killed"),
+	}
+
+	var buf bytes.Buffer
+	if err := writeInventoryCSV(context.Background(), &buf, cur); err == nil {
+		t.Fatal("expected cursor error to be returned")
+	}
+}
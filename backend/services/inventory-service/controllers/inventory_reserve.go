@@ -0,0 +1,148 @@
+package controllers
+
+import (
+	"context"
+	"errors"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yashrajoria/common/httputil"
+	db "github.com/yashrajoria/inventory-service/database"
+	models "github.com/yashrajoria/inventory-service/database"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// reservationStore holds, commits and releases stock for order lines. Every
+// operation is idempotent per order line so callers can retry.
+type reservationStore interface {
+	// Reserve holds the line's quantity and reports false when there is not
+	// enough unreserved stock.
+	Reserve(ctx context.Context, r models.InventoryReservation) (bool, error)
+	// Commit turns the line's hold into a sale, taking it out of stock.
+	Commit(ctx context.Context, r models.InventoryRelease) error
+	// Release drops the line's hold, making the stock available again.
+	Release(ctx context.Context, r models.InventoryRelease) error
+}
+
+// reservations is the store used by the handlers; tests replace it.
+var reservations reservationStore = mongoReservations{}
+
+// ReserveInventory holds stock for one order line. The reservation only
+// succeeds if quantity minus what is already reserved covers the request;
+// otherwise it answers 409 and nothing is held. Reserving the same order line
+// twice is a no-op, so the order consumer can safely retry.
+func ReserveInventory(c *gin.Context) {
+	var req models.InventoryReservation
+	if err := c.ShouldBindJSON(&req); err != nil || req.OrderID == "" || req.ProductID == "" || req.Line < 0 || req.Quantity <= 0 || req.Stock < 0 {
+		httputil.RespondError(c, http.StatusBadRequest, "order_id, product_id, line and a positive quantity are required")
+		return
+	}
+
+	reserved, err := reservations.Reserve(c.Request.Context(), req)
+	if err != nil {
+		log.Println("Error reserving inventory:", err)
+		httputil.RespondError(c, http.StatusInternalServerError, "Failed to reserve inventory")
+		return
+	}
+	if !reserved {
+		httputil.RespondErrorCode(c, http.StatusConflict, "insufficient_stock", "Insufficient stock")
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"reserved": true})
+}
+
+// CommitInventory takes a paid order line's held stock out of the available
+// quantity. A line with no outstanding hold is left alone.
+func CommitInventory(c *gin.Context) {
+	settleInventory(c, reservations.Commit, "commit")
+}
+
+// ReleaseInventory returns a failed or canceled order line's held stock. A
+// line with no outstanding hold is left alone.
+func ReleaseInventory(c *gin.Context) {
+	settleInventory(c, reservations.Release, "release")
+}
+
+func settleInventory(c *gin.Context, settle func(context.Context, models.InventoryRelease) error, action string) {
+	var req models.InventoryRelease
+	if err := c.ShouldBindJSON(&req); err != nil || req.OrderID == "" || req.ProductID == "" || req.Line < 0 || req.Quantity <= 0 {
+		httputil.RespondError(c, http.StatusBadRequest, "order_id, product_id, line and a positive quantity are required")
+		return
+	}
+	if err := settle(c.Request.Context(), req); err != nil {
+		log.Printf("Error trying to %s inventory: %v", action, err)
+		httputil.RespondError(c, http.StatusInternalServerError, "Failed to "+action+" inventory")
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"status": "ok"})
+}
+
+// mongoReservations keeps holds on the inventory record itself: Reserved is
+// the total held and Reservations the quantity per order line.
+type mongoReservations struct{}
+
+func (mongoReservations) Reserve(ctx context.Context, r models.InventoryReservation) (bool, error) {
+	products := db.DB.Collection("products")
+	field := "reservations." + models.ReservationKey(r.OrderID, r.Line)
+	now := time.Now()
+
+	// Products are created in product-service, so the first reservation for
+	// one seeds its record from the catalogue stock
+	_, err := products.UpdateOne(ctx,
+		bson.M{"product_id": r.ProductID},
+		bson.M{"$setOnInsert": bson.M{"quantity": r.Stock, "reserved": 0, "threshold": 0, "updated_at": now}},
+		options.Update().SetUpsert(true))
+	if err != nil && !mongo.IsDuplicateKeyError(err) {
+		return false, err
+	}
+
+	filter := bson.M{
+		"product_id": r.ProductID,
+		field:        bson.M{"$exists": false},
+		"$expr": bson.M{"$gte": bson.A{
+			bson.M{"$subtract": bson.A{"$quantity", bson.M{"$ifNull": bson.A{"$reserved", 0}}}},
+			r.Quantity,
+		}},
+	}
+	update := bson.M{
+		"$inc": bson.M{"reserved": r.Quantity},
+		"$set": bson.M{field: r.Quantity, "updated_at": now},
+	}
+	res, err := products.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return false, err
+	}
+	if res.ModifiedCount == 1 {
+		return true, nil
+	}
+
+	// Nothing changed: either this line already holds the stock or there is
+	// not enough of it left
+	err = products.FindOne(ctx, bson.M{"product_id": r.ProductID, field: bson.M{"$exists": true}}).Err()
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+func (mongoReservations) Commit(ctx context.Context, r models.InventoryRelease) error {
+	return settleHold(ctx, r, bson.M{"quantity": -r.Quantity, "reserved": -r.Quantity})
+}
+
+func (mongoReservations) Release(ctx context.Context, r models.InventoryRelease) error {
+	return settleHold(ctx, r, bson.M{"reserved": -r.Quantity})
+}
+
+// settleHold applies inc and removes the line's hold in one update. Matching
+// on the held quantity makes a repeated call a no-op.
+func settleHold(ctx context.Context, r models.InventoryRelease, inc bson.M) error {
+	field := "reservations." + models.ReservationKey(r.OrderID, r.Line)
+	_, err := db.DB.Collection("products").UpdateOne(ctx,
+		bson.M{"product_id": r.ProductID, field: r.Quantity},
+		bson.M{"$inc": inc, "$unset": bson.M{field: ""}, "$set": bson.M{"updated_at": time.Now()}})
+	return err
+}
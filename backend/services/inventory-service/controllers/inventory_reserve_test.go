@@ -0,0 +1,183 @@
+package controllers
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	models "github.com/yashrajoria/inventory-service/database"
+)
+
+// fakeReservations keeps holds per order line in memory, like the Mongo store.
+type fakeReservations struct {
+	available map[string]int
+	holds     map[string]int
+	err       error
+}
+
+func newFakeReservations(available map[string]int) *fakeReservations {
+	return &fakeReservations{available: available, holds: map[string]int{}}
+}
+
+func (f *fakeReservations) Reserve(ctx context.Context, r models.InventoryReservation) (bool, error) {
+	if f.err != nil {
+		return false, f.err
+	}
+	key := models.ReservationKey(r.OrderID, r.Line)
+	if _, ok := f.holds[key]; ok {
+		return true, nil
+	}
+	if f.available[r.ProductID] < r.Quantity {
+		return false, nil
+	}
+	f.available[r.ProductID] -= r.Quantity
+	f.holds[key] = r.Quantity
+	return true, nil
+}
+
+func (f *fakeReservations) Commit(ctx context.Context, r models.InventoryRelease) error {
+	if f.err != nil {
+		return f.err
+	}
+	delete(f.holds, models.ReservationKey(r.OrderID, r.Line))
+	return nil
+}
+
+func (f *fakeReservations) Release(ctx context.Context, r models.InventoryRelease) error {
+	if f.err != nil {
+		return f.err
+	}
+	key := models.ReservationKey(r.OrderID, r.Line)
+	if qty, ok := f.holds[key]; ok {
+		f.available[r.ProductID] += qty
+		delete(f.holds, key)
+	}
+	return nil
+}
+
+func useReservations(t *testing.T, store reservationStore) {
+	t.Helper()
+	prev := reservations
+	reservations = store
+	t.Cleanup(func() { reservations = prev })
+}
+
+func postJSON(handler gin.HandlerFunc, body string) *httptest.ResponseRecorder {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.POST("/", handler)
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/", bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	r.ServeHTTP(w, req)
+	return w
+}
+
+func TestReserveInventory(t *testing.T) {
+	store := newFakeReservations(map[string]int{"p1": 3})
+	useReservations(t, store)
+
+	w := postJSON(ReserveInventory, `{"order_id":"o1","line":0,"product_id":"p1","quantity":2,"stock":3}`)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body)
+	}
+	// A retry of the same line does not hold the stock twice
+	if w := postJSON(ReserveInventory, `{"order_id":"o1","line":0,"product_id":"p1","quantity":2,"stock":3}`); w.Code != http.StatusOK {
+		t.Fatalf("expected retry to succeed, got %d", w.Code)
+	}
+	if store.available["p1"] != 1 {
+		t.Fatalf("expected 1 unit left, got %d", store.available["p1"])
+	}
+}
+
+func TestReserveInventory_SameProductOnTwoLines(t *testing.T) {
+	store := newFakeReservations(map[string]int{"p1": 3})
+	useReservations(t, store)
+
+	postJSON(ReserveInventory, `{"order_id":"o1","line":0,"product_id":"p1","quantity":2}`)
+	w := postJSON(ReserveInventory, `{"order_id":"o1","line":1,"product_id":"p1","quantity":2}`)
+	if w.Code != http.StatusConflict {
+		t.Fatalf("expected second line to need its own stock, got %d", w.Code)
+	}
+}
+
+func TestReserveInventory_InsufficientStock(t *testing.T) {
+	useReservations(t, newFakeReservations(map[string]int{"p1": 1}))
+
+	w := postJSON(ReserveInventory, `{"order_id":"o1","line":0,"product_id":"p1","quantity":2}`)
+	if w.Code != http.StatusConflict {
+		t.Fatalf("expected 409, got %d", w.Code)
+	}
+}
+
+func TestReserveInventory_InvalidRequest(t *testing.T) {
+	useReservations(t, newFakeReservations(nil))
+
+	for _, body := range []string{
+		`{"product_id":"p1","quantity":1}`,
+		`{"order_id":"o1","quantity":1}`,
+		`{"order_id":"o1","product_id":"p1","quantity":0}`,
+		`{"order_id":"o1","line":-1,"product_id":"p1","quantity":1}`,
+		`not json`,
+	} {
+		if w := postJSON(ReserveInventory, body); w.Code != http.StatusBadRequest {
+			t.Fatalf("%s: expected 400, got %d", body, w.Code)
+		}
+	}
+}
+
+func TestReserveInventory_StoreError(t *testing.T) {
+	store := newFakeReservations(nil)
+	store.err = errors.New("mongo unavailable")
+	useReservations(t, store)
+
+	w := postJSON(ReserveInventory, `{"order_id":"o1","line":0,"product_id":"p1","quantity":1}`)
+	if w.Code != http.StatusInternalServerError {
+		t.Fatalf("expected 500, got %d", w.Code)
+	}
+}
+
+func TestReleaseInventory_ReturnsStock(t *testing.T) {
+	store := newFakeReservations(map[string]int{"p1": 2})
+	useReservations(t, store)
+
+	postJSON(ReserveInventory, `{"order_id":"o1","line":0,"product_id":"p1","quantity":2}`)
+	for i := 0; i < 2; i++ {
+		if w := postJSON(ReleaseInventory, `{"order_id":"o1","line":0,"product_id":"p1","quantity":2}`); w.Code != http.StatusOK {
+			t.Fatalf("expected 200, got %d", w.Code)
+		}
+	}
+	if store.available["p1"] != 2 || len(store.holds) != 0 {
+		t.Fatalf("expected stock back and no holds, got available=%d holds=%v", store.available["p1"], store.holds)
+	}
+}
+
+func TestCommitInventory_DropsHold(t *testing.T) {
+	store := newFakeReservations(map[string]int{"p1": 2})
+	useReservations(t, store)
+
+	postJSON(ReserveInventory, `{"order_id":"o1","line":0,"product_id":"p1","quantity":2}`)
+	if w := postJSON(CommitInventory, `{"order_id":"o1","line":0,"product_id":"p1","quantity":2}`); w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", w.Code)
+	}
+	if store.available["p1"] != 0 || len(store.holds) != 0 {
+		t.Fatalf("expected stock sold and no holds, got available=%d holds=%v", store.available["p1"], store.holds)
+	}
+}
+
+func TestSettleInventory_InvalidRequestAndError(t *testing.T) {
+	store := newFakeReservations(nil)
+	useReservations(t, store)
+
+	if w := postJSON(CommitInventory, `{"order_id":"o1","product_id":"p1"}`); w.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400, got %d", w.Code)
+	}
+	store.err = errors.New("mongo unavailable")
+	if w := postJSON(ReleaseInventory, `{"order_id":"o1","line":0,"product_id":"p1","quantity":1}`); w.Code != http.StatusInternalServerError {
+		t.Fatalf("expected 500, got %d", w.Code)
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/joho/godotenv"
+	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
 )
@@ -70,6 +71,20 @@ func Connect() error {
 	return nil
 }
 
+// EnsureIndexes creates the indexes the service relies on. product_id is
+// unique so seeding a product on its first reservation cannot create a
+// second record for it.
+func EnsureIndexes(ctx context.Context) error {
+	_, err := DB.Collection("products").Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "product_id", Value: 1}},
+		Options: options.Index().SetUnique(true),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create product_id index: %w", err)
+	}
+	return nil
+}
+
 // Close disconnects from MongoDB
 func Close() error {
 	// Disconnect from MongoDB
@@ -1,6 +1,7 @@
 package database
 
 import (
+	"fmt"
 	"time"
 
 	"go.mongodb.org/mongo-driver/bson/primitive"
@@ -13,7 +14,11 @@ type Inventory struct {
 	Quantity  int                `bson:"quantity" json:"quantity"`     // Available stock
 	Reserved  int                `bson:"reserved" json:"reserved"`     // Reserved stock (for pending orders)
 	Threshold int                `bson:"threshold" json:"threshold"`   // Minimum stock threshold for alerts
-	UpdatedAt time.Time          `bson:"updated_at" json:"updated_at"` // Last update timestamp
+	// Reservations holds the quantity of each outstanding order line hold,
+	// keyed by ReservationKey. An entry is removed once the hold is committed
+	// or released, so only in-flight orders are listed.
+	Reservations map[string]int `bson:"reservations,omitempty" json:"-"`
+	UpdatedAt    time.Time      `bson:"updated_at" json:"updated_at"` // Last update timestamp
 }
 
 // InventoryUpdate is used for updating inventory quantity
@@ -21,15 +26,24 @@ type InventoryUpdate struct {
 	Quantity int `json:"quantity"` // New stock quantity
 }
 
-// InventoryReservation is used when reserving stock for an order
+// InventoryReservation is used when reserving stock for an order line
 type InventoryReservation struct {
 	OrderID   string `json:"order_id"`   // Order reference
+	Line      int    `json:"line"`       // Position of the line within the order
 	ProductID string `json:"product_id"` // Product reference
 	Quantity  int    `json:"quantity"`   // Quantity to reserve
+	Stock     int    `json:"stock"`      // Catalogue stock, seeds a product not tracked yet
 }
 
-// InventoryRelease is used for releasing reserved stock
+// InventoryRelease is used for committing or releasing an order line's hold
 type InventoryRelease struct {
 	OrderID   string `json:"order_id"`   // Order reference
+	Line      int    `json:"line"`       // Position of the line within the order
 	ProductID string `json:"product_id"` // Product reference
+	Quantity  int    `json:"quantity"`   // Quantity held by the line
+}
+
+// ReservationKey identifies one order line's hold in Inventory.Reservations.
+func ReservationKey(orderID string, line int) string {
+	return fmt.Sprintf("%s:%d", orderID, line)
 }
@@ -1,9 +1,12 @@
 package main
 
 import (
+	"context"
 	"log"
+	"os"
 
 	"github.com/gin-gonic/gin"
+	commonmw "github.com/yashrajoria/common/middleware"
 	"github.com/yashrajoria/inventory-service/controllers"
 	db "github.com/yashrajoria/inventory-service/database"
 	"go.uber.org/zap"
@@ -22,14 +25,27 @@ func main() {
 	err = db.Connect()
 	if err != nil {
 		log.Println("Error connecting to database", zap.Error(err))
+	} else if err := db.EnsureIndexes(context.Background()); err != nil {
+		log.Println("Error creating indexes", zap.Error(err))
 	}
 
-	r := gin.Default()
+	logger, _ := zap.NewProduction()
+	defer logger.Sync()
+
+	r := gin.New()
+	r.Use(gin.Logger())
+	r.Use(commonmw.RequestID())
+	r.Use(commonmw.Recovery(logger, "inventory-service", os.Stdout))
+	r.Use(commonmw.RequestTimeout(cfg.RequestTimeout))
 
 	// Apply request logging
 	//	r.Use(logger.RequestLogger())
 
+	r.GET("/inventory/export", controllers.ExportInventory)
 	r.GET("/inventory/:productId", controllers.GetInventory)
+	r.POST("/inventory/reserve", controllers.ReserveInventory)
+	r.POST("/inventory/commit", controllers.CommitInventory)
+	r.POST("/inventory/release", controllers.ReleaseInventory)
 	// r.POST("/inventory", controllers.AddInventory)
 	// r.PUT("/inventory/:productId", controllers.UpdateInventory)
 
@@ -5,6 +5,8 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"strconv"
+	"strings"
 
 	aws_pkg "github.com/yashrajoria/E-Commerce-backend/backend/pkg/aws"
 )
@@ -19,30 +21,52 @@ type Config struct {
 	PostgresSSLMode   string
 	PostgresTimeZone  string
 	ProductServiceURL string
+	CartServiceURL    string // cart-service, cleared once an order is paid
+	// inventory-service, which holds stock for each order item at checkout
+	InventoryServiceURL string
 	// SQS/SNS config (replaces Kafka)
 	CheckoutQueueURL       string
 	PaymentEventsQueueURL  string
 	PaymentRequestQueueURL string
+	ShipmentEventsQueueURL string
 	OrderSNSTopicARN       string
 	PaymentSNSTopicARN     string
+	// Topic for customer notifications such as order_confirmed
+	NotificationSNSTopicARN string
+	// Pricing
+	DefaultCurrency string  // ISO 4217 code, lower case (default: usd)
+	TaxRate         float64 // flat tax rate as a fraction, e.g. 0.18 (default: 0)
 }
 
 func LoadConfig() (*Config, error) {
 	cfg := &Config{
-		Port:                   getEnv("PORT", "8083"),
-		PostgresUser:           os.Getenv("POSTGRES_USER"),
-		PostgresPassword:       os.Getenv("POSTGRES_PASSWORD"),
-		PostgresDB:             os.Getenv("POSTGRES_DB"),
-		PostgresHost:           os.Getenv("POSTGRES_HOST"),
-		PostgresPort:           getEnv("POSTGRES_PORT", "5432"),
-		PostgresSSLMode:        getEnv("POSTGRES_SSLMODE", "disable"),
-		PostgresTimeZone:       getEnv("POSTGRES_TIMEZONE", "Asia/Kolkata"),
-		ProductServiceURL:      getEnv("PRODUCT_SERVICE_URL", "http://product-service:8082"),
-		CheckoutQueueURL:       os.Getenv("CHECKOUT_QUEUE_URL"),
-		PaymentEventsQueueURL:  os.Getenv("PAYMENT_EVENTS_QUEUE_URL"),
-		PaymentRequestQueueURL: os.Getenv("PAYMENT_REQUEST_QUEUE_URL"),
-		OrderSNSTopicARN:       os.Getenv("ORDER_SNS_TOPIC_ARN"),
-		PaymentSNSTopicARN:     os.Getenv("PAYMENT_SNS_TOPIC_ARN"),
+		Port:                    getEnv("PORT", "8083"),
+		PostgresUser:            os.Getenv("POSTGRES_USER"),
+		PostgresPassword:        os.Getenv("POSTGRES_PASSWORD"),
+		PostgresDB:              os.Getenv("POSTGRES_DB"),
+		PostgresHost:            os.Getenv("POSTGRES_HOST"),
+		PostgresPort:            getEnv("POSTGRES_PORT", "5432"),
+		PostgresSSLMode:         getEnv("POSTGRES_SSLMODE", "disable"),
+		PostgresTimeZone:        getEnv("POSTGRES_TIMEZONE", "Asia/Kolkata"),
+		ProductServiceURL:       getEnv("PRODUCT_SERVICE_URL", "http://product-service:8082"),
+		CartServiceURL:          getEnv("CART_SERVICE_URL", "http://cart-service:8086"),
+		InventoryServiceURL:     getEnv("INVENTORY_SERVICE_URL", "http://inventory-service:8084"),
+		CheckoutQueueURL:        os.Getenv("CHECKOUT_QUEUE_URL"),
+		PaymentEventsQueueURL:   os.Getenv("PAYMENT_EVENTS_QUEUE_URL"),
+		PaymentRequestQueueURL:  os.Getenv("PAYMENT_REQUEST_QUEUE_URL"),
+		ShipmentEventsQueueURL:  os.Getenv("SHIPMENT_EVENTS_QUEUE_URL"),
+		OrderSNSTopicARN:        os.Getenv("ORDER_SNS_TOPIC_ARN"),
+		PaymentSNSTopicARN:      os.Getenv("PAYMENT_SNS_TOPIC_ARN"),
+		NotificationSNSTopicARN: os.Getenv("NOTIFICATION_SNS_TOPIC_ARN"),
+		DefaultCurrency:         strings.ToLower(getEnv("DEFAULT_CURRENCY", "usd")),
+	}
+
+	if v := os.Getenv("TAX_RATE"); v != "" {
+		rate, err := strconv.ParseFloat(v, 64)
+		if err != nil || rate < 0 || rate >= 1 {
+			return nil, fmt.Errorf("TAX_RATE must be a fraction between 0 and 1, got %q", v)
+		}
+		cfg.TaxRate = rate
 	}
 
 	if os.Getenv("AWS_USE_SECRETS") == "true" {
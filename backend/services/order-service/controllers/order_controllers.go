@@ -10,6 +10,7 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
+	"github.com/yashrajoria/common/httputil"
 )
 
 type OrderController struct {
@@ -26,18 +27,18 @@ func NewOrderController(orderService *services.OrderService) *OrderController {
 func (oc *OrderController) CreateOrder(ctx *gin.Context) {
 	userID, err := middleware.GetUserID(ctx)
 	if err != nil {
-		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		httputil.RespondError(ctx, http.StatusUnauthorized, "Unauthorized")
 		return
 	}
 
 	var req services.CreateOrderRequest
 	if err := ctx.ShouldBindJSON(&req); err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request", "details": err.Error()})
+		httputil.RespondErrorDetails(ctx, http.StatusBadRequest, "Invalid request", err.Error())
 		return
 	}
 
-	if err := oc.orderService.CreateOrder(ctx.Request.Context(), userID, &req); err != nil {
-		ctx.JSON(err.StatusCode, gin.H{"error": err.Message})
+	if err := oc.orderService.CreateOrder(ctx.Request.Context(), userID, middleware.GetUserEmail(ctx), &req); err != nil {
+		httputil.RespondErrorCode(ctx, err.StatusCode, err.Code, err.Message)
 		return
 	}
 
@@ -48,12 +49,12 @@ func (oc *OrderController) CreateOrder(ctx *gin.Context) {
 func (oc *OrderController) GetOrders(ctx *gin.Context) {
 	userID, err := middleware.GetUserID(ctx)
 	if err != nil {
-		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		httputil.RespondError(ctx, http.StatusUnauthorized, "Unauthorized")
 		return
 	}
 	if userID == "" {
 		log.Println("User Id is missing")
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "User ID is required"})
+		httputil.RespondError(ctx, http.StatusBadRequest, "User ID is required")
 		return
 	}
 
@@ -62,8 +63,8 @@ func (oc *OrderController) GetOrders(ctx *gin.Context) {
 	result, serviceErr := oc.orderService.GetUserOrders(ctx.Request.Context(), userID, page, limit)
 
 	if serviceErr != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch orders"})
 		fmt.Printf("Error: %v\n", serviceErr)
+		httputil.RespondErrorCode(ctx, serviceErr.StatusCode, serviceErr.Code, serviceErr.Message)
 		return
 	}
 
@@ -79,29 +80,28 @@ func (oc *OrderController) GetOrders(ctx *gin.Context) {
 func (oc *OrderController) GetAllOrders(ctx *gin.Context) {
 	userID, err := middleware.GetUserID(ctx)
 	if err != nil {
-		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		httputil.RespondError(ctx, http.StatusUnauthorized, "Unauthorized")
 		return
 	}
 
 	role, exists := ctx.Get("role")
 	if !exists {
-		ctx.JSON(http.StatusForbidden, gin.H{"error": "Access denied"})
+		httputil.RespondError(ctx, http.StatusForbidden, "Access denied")
 		return
 	}
 
 	roleStr, ok := role.(string)
 	if !ok || roleStr != "admin" {
-		ctx.JSON(http.StatusForbidden, gin.H{"error": "Admin access required"})
+		httputil.RespondError(ctx, http.StatusForbidden, "Admin access required")
 		return
 	}
 
 	page, limit := parsePaginationParams(ctx)
 
-	result, err := oc.orderService.GetAllOrders(ctx.Request.Context(), userID, page, limit)
-	if err != nil {
-		// ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch orders"})
-		fmt.Printf("Error: %v\n", err)
-
+	result, serviceErr := oc.orderService.GetAllOrders(ctx.Request.Context(), userID, page, limit)
+	if serviceErr != nil {
+		fmt.Printf("Error: %v\n", serviceErr)
+		httputil.RespondErrorCode(ctx, serviceErr.StatusCode, serviceErr.Code, serviceErr.Message)
 		return
 	}
 
@@ -112,20 +112,20 @@ func (oc *OrderController) GetAllOrders(ctx *gin.Context) {
 func (oc *OrderController) GetOrderByID(ctx *gin.Context) {
 	userID, err := middleware.GetUserID(ctx)
 	if err != nil {
-		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		httputil.RespondError(ctx, http.StatusUnauthorized, "Unauthorized")
 		return
 	}
 
 	orderID := ctx.Param("id")
 	orderUUID, err := uuid.Parse(orderID)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid order ID format"})
+		httputil.RespondError(ctx, http.StatusBadRequest, "Invalid order ID format")
 		return
 	}
 
 	order, serviceErr := oc.orderService.GetOrderByID(ctx.Request.Context(), userID, orderUUID)
 	if serviceErr != nil {
-		ctx.JSON(serviceErr.StatusCode, gin.H{"error": serviceErr.Message})
+		httputil.RespondErrorCode(ctx, serviceErr.StatusCode, serviceErr.Code, serviceErr.Message)
 		return
 	}
 
@@ -0,0 +1,58 @@
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"order-service/models"
+	repositories "order-service/repository"
+	"order-service/services"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	apperrors "github.com/yashrajoria/common/errors"
+)
+
+// missingOrderRepo finds nothing.
+type missingOrderRepo struct {
+	repositories.OrderRepository
+}
+
+func (missingOrderRepo) FindByIDAndUserID(ctx context.Context, orderID, userID uuid.UUID) (*models.Order, error) {
+	return nil, apperrors.ErrNotFound
+}
+
+func decodeErrorBody(t *testing.T, w *httptest.ResponseRecorder) map[string]string {
+	t.Helper()
+	var body map[string]string
+	if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+		t.Fatalf("invalid error body %q: %v", w.Body.String(), err)
+	}
+	return body
+}
+
+func TestGetOrderByID_NotFoundUsesUniformShape(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	oc := NewOrderController(services.NewOrderServiceSQS(missingOrderRepo{}, nil, ""))
+	r := gin.New()
+	r.GET("/orders/:id", func(c *gin.Context) {
+		c.Set("userID", uuid.New().String())
+		oc.GetOrderByID(c)
+	})
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/orders/"+uuid.New().String(), nil))
+
+	if w.Code != http.StatusNotFound {
+		t.Fatalf("expected 404, got %d", w.Code)
+	}
+	body := decodeErrorBody(t, w)
+	if body["error"] != "Order not found" || body["code"] != "not_found" {
+		t.Fatalf("unexpected body %v", body)
+	}
+	if _, ok := body["request_id"]; ok {
+		t.Fatalf("expected request_id omitted without X-Request-ID, got %v", body)
+	}
+}
@@ -16,9 +16,11 @@ import (
 	repositories "order-service/repository"
 	"order-service/routes"
 	"order-service/services"
+	"order-service/tracing"
 
 	"github.com/gin-gonic/gin"
 	aws_pkg "github.com/yashrajoria/E-Commerce-backend/backend/pkg/aws"
+	commonmw "github.com/yashrajoria/common/middleware"
 	"go.uber.org/zap"
 )
 
@@ -34,10 +36,15 @@ func main() {
 		logger.Fatal("Config load failed", zap.Error(err))
 	}
 
+	shutdownTracing, err := tracing.Init(context.Background(), "order-service")
+	if err != nil {
+		logger.Fatal("Tracing init failed", zap.Error(err))
+	}
+
 	if err := database.Connect(); err != nil {
 		logger.Fatal("DB connection failed", zap.Error(err))
 	}
-	if err := database.DB.AutoMigrate(&models.Order{}, &models.OrderItem{}); err != nil {
+	if err := database.DB.AutoMigrate(&models.Order{}, &models.OrderItem{}, &models.OrderStatusHistory{}); err != nil {
 		logger.Fatal("Migration failed", zap.Error(err))
 	}
 
@@ -52,7 +59,9 @@ func main() {
 
 	// --- HTTP router ---
 	r := gin.New()
-	r.Use(gin.Recovery())
+	r.Use(commonmw.RequestID())
+	r.Use(commonmw.Recovery(logger, "order-service", os.Stdout))
+	r.Use(tracing.Middleware())
 	r.Use(middleware.ConfigMiddleware(cfg.ProductServiceURL))
 
 	// Add request timeout middleware
@@ -109,12 +118,25 @@ func main() {
 		}
 	}
 
+	shipmentEventsQueueURL := cfg.ShipmentEventsQueueURL
+	if shipmentEventsQueueURL == "" {
+		if url, err := aws_pkg.GetQueueURL(context.Background(), awsCfg, "shipment-events-queue"); err == nil {
+			shipmentEventsQueueURL = url
+		} else {
+			logger.Warn("Could not get shipment events queue URL", zap.Error(err))
+		}
+	}
+
 	// Start SQS consumers
+	inventory := services.NewInventoryClient(cfg.InventoryServiceURL)
 	if checkoutQueueURL != "" && paymentRequestQueueURL != "" {
 		checkoutConsumer := services.NewSQSCheckoutConsumer(
 			aws_pkg.NewSQSConsumer(awsCfg, checkoutQueueURL),
 			aws_pkg.NewSQSConsumer(awsCfg, paymentRequestQueueURL), // For sending payment requests
 			database.DB,
+			inventory,
+			services.FlatRateTax{Rate: cfg.TaxRate},
+			cfg.DefaultCurrency,
 		)
 		go checkoutConsumer.Start(shutdownCtx)
 		logger.Info("Started SQS checkout consumer", zap.String("queue", checkoutQueueURL))
@@ -126,6 +148,10 @@ func main() {
 		paymentConsumer := services.NewSQSPaymentConsumer(
 			aws_pkg.NewSQSConsumer(awsCfg, paymentEventsQueueURL),
 			database.DB,
+			snsClient,
+			cfg.NotificationSNSTopicARN,
+			services.NewCartClient(cfg.CartServiceURL),
+			inventory,
 		)
 		go paymentConsumer.Start(shutdownCtx)
 		logger.Info("Started SQS payment events consumer", zap.String("queue", paymentEventsQueueURL))
@@ -133,6 +159,17 @@ func main() {
 		logger.Warn("Payment events consumer not started - missing queue URL")
 	}
 
+	if shipmentEventsQueueURL != "" {
+		shipmentConsumer := services.NewSQSShipmentConsumer(
+			aws_pkg.NewSQSConsumer(awsCfg, shipmentEventsQueueURL),
+			services.NewGormOrderStatusStore(database.DB),
+		)
+		go shipmentConsumer.Start(shutdownCtx)
+		logger.Info("Started SQS shipment events consumer", zap.String("queue", shipmentEventsQueueURL))
+	} else {
+		logger.Warn("Shipment events consumer not started - missing queue URL")
+	}
+
 	// --- HTTP server ---
 	go func() {
 		logger.Info("Order Service started", zap.String("port", cfg.Port))
@@ -157,6 +194,9 @@ func main() {
 	if err := srv.Shutdown(httpShutdownCtx); err != nil {
 		logger.Error("Server shutdown error", zap.Error(err))
 	}
+	if err := shutdownTracing(httpShutdownCtx); err != nil {
+		logger.Warn("Failed to flush traces", zap.Error(err))
+	}
 
 	// Close database connection
 	sqlDB, _ := database.DB.DB()
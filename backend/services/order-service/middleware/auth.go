@@ -39,6 +39,11 @@ func GetUserID(c *gin.Context) (string, error) {
 	return "", errors.New("user ID not found in context")
 }
 
+// GetUserEmail returns the caller's email as forwarded by the gateway, or ""
+func GetUserEmail(c *gin.Context) string {
+	return c.GetString("email")
+}
+
 // Injects any config value into Gin context; here for product service URL
 func ConfigMiddleware(productServiceURL string) gin.HandlerFunc {
 	return func(c *gin.Context) {
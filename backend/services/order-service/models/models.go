@@ -11,7 +11,7 @@ type Order struct {
 	ID          uuid.UUID `gorm:"type:uuid;default:gen_random_uuid();primaryKey"`
 	OrderNumber string    `gorm:"uniqueIndex;not null"`
 	UserID      uuid.UUID `gorm:"type:uuid;not null;index"`
-	Amount      int       `gorm:"not null"`
+	Amount      int       `gorm:"not null"` // amount charged; equals Total
 	Status      string    `gorm:"type:varchar(20);not null;default:'pending_payment'"`
 	CanceledAt  *time.Time
 	CompletedAt *time.Time
@@ -19,6 +19,19 @@ type Order struct {
 	UpdatedAt   time.Time      `gorm:"autoUpdateTime"`
 	DeletedAt   gorm.DeletedAt `gorm:"index"`
 	OrderItems  []OrderItem    `gorm:"foreignKey:OrderID;constraint:OnDelete:CASCADE"`
+	// CustomerEmail is the buyer's address at checkout, used for receipts
+	CustomerEmail string `gorm:"type:varchar(255)"`
+	// ConfirmationSentAt is set once the order_confirmed event is published
+	ConfirmationSentAt *time.Time
+	// CartClearedAt is set once the buyer's cart has been emptied after payment
+	CartClearedAt *time.Time
+	// Subtotal is the sum of the items; Total is Subtotal plus Tax
+	Subtotal int    `gorm:"not null;default:0"`
+	Tax      int    `gorm:"not null;default:0"`
+	Total    int    `gorm:"not null;default:0"`
+	Currency string `gorm:"type:varchar(3);not null;default:'usd'"`
+	// Metadata carries customer notes such as a gift message
+	Metadata map[string]string `gorm:"type:jsonb;serializer:json"`
 }
 
 type OrderItem struct {
@@ -27,4 +40,25 @@ type OrderItem struct {
 	ProductID uuid.UUID `gorm:"type:uuid;not null"`
 	Quantity  int       `gorm:"not null"`
 	Price     int       `gorm:"not null"`
+	// FulfillmentStatus records whether inventory reserved the item at checkout
+	FulfillmentStatus string `gorm:"type:varchar(20);not null;default:'reserved'"`
+	// Line is the item's position in the checkout; with OrderID it names the
+	// item's stock hold in inventory
+	Line int `gorm:"not null;default:0"`
+}
+
+// Item fulfillment statuses set by the checkout consumer.
+const (
+	FulfillmentReserved    = "reserved"
+	FulfillmentBackordered = "backordered"
+)
+
+// OrderStatusHistory is the status timeline of an order. Reference identifies
+// the event that caused the change so redelivered events can be ignored.
+type OrderStatusHistory struct {
+	ID        uuid.UUID `gorm:"type:uuid;default:gen_random_uuid();primaryKey"`
+	OrderID   uuid.UUID `gorm:"type:uuid;not null;index"`
+	Status    string    `gorm:"type:varchar(20);not null"`
+	Reference string    `gorm:"uniqueIndex;not null"`
+	CreatedAt time.Time `gorm:"autoCreateTime"`
 }
@@ -4,11 +4,15 @@ import "time"
 
 // From cart-service → order-service
 type CheckoutEvent struct {
-	Event     string         `json:"event"`   // expected: "checkout.requested"
-	UserID    string         `json:"user_id"` // must be UUID string
-	Items     []CheckoutItem `json:"items"`
-	Timestamp time.Time      `json:"timestamp"`
-	OrderID   string         `json:"order_id"`
+	Event     string            `json:"event"`   // expected: "checkout.requested"
+	UserID    string            `json:"user_id"` // must be UUID string
+	UserEmail string            `json:"user_email,omitempty"`
+	Items     []CheckoutItem    `json:"items"`
+	Metadata  map[string]string `json:"metadata,omitempty"`
+	Timestamp time.Time         `json:"timestamp"`
+	OrderID   string            `json:"order_id"`
+	// TraceContext carries W3C trace headers (traceparent, tracestate)
+	TraceContext map[string]string `json:"trace_context,omitempty"`
 }
 
 type CheckoutItem struct {
@@ -18,9 +22,12 @@ type CheckoutItem struct {
 
 // order-service → payment-service
 type PaymentRequest struct {
-	OrderID string `json:"order_id"`
-	UserID  string `json:"user_id"`
-	Amount  int    `json:"amount"` // minor units
+	OrderID  string `json:"order_id"`
+	UserID   string `json:"user_id"`
+	Amount   int    `json:"amount"` // minor units
+	Currency string `json:"currency,omitempty"`
+
+	TraceContext map[string]string `json:"trace_context,omitempty"`
 }
 
 // payment-service → order-service
@@ -33,3 +40,31 @@ type PaymentEvent struct {
 	Currency  string    `json:"currency,omitempty"`
 	Timestamp time.Time `json:"timestamp,omitempty"`
 }
+
+// shipping → order-service
+type ShipmentEvent struct {
+	Type           string    `json:"type"` // "shipment_created" | "shipment_updated"
+	ShipmentID     string    `json:"shipment_id"`
+	OrderID        string    `json:"order_id"`
+	Status         string    `json:"status"` // shipment status, e.g. "created", "in_transit", "delivered"
+	TrackingNumber string    `json:"tracking_number,omitempty"`
+	Timestamp      time.Time `json:"timestamp,omitempty"`
+}
+
+// order-service → notifications, once an order is paid
+type OrderConfirmedEvent struct {
+	Type        string               `json:"type"` // "order_confirmed"
+	OrderID     string               `json:"order_id"`
+	OrderNumber string               `json:"order_number"`
+	UserID      string               `json:"user_id"`
+	Email       string               `json:"email"`
+	Amount      int                  `json:"amount"` // minor units
+	Items       []OrderConfirmedItem `json:"items"`
+	ConfirmedAt time.Time            `json:"confirmed_at"`
+}
+
+type OrderConfirmedItem struct {
+	ProductID string `json:"product_id"`
+	Quantity  int    `json:"quantity"`
+	Price     int    `json:"price"` // minor units, per item
+}
@@ -2,9 +2,12 @@ package repositories
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"order-service/models"
 
 	"github.com/google/uuid"
+	apperrors "github.com/yashrajoria/common/errors"
 	"gorm.io/gorm"
 )
 
@@ -85,6 +88,9 @@ func (r *GormOrderRepository) FindByIDAndUserID(ctx context.Context, order_id, u
 		Preload("OrderItems").
 		Where("id = ? AND user_id = ?", order_id, userID).
 		First(&order).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("order %s: %w", order_id, apperrors.ErrNotFound)
+		}
 		return nil, err
 	}
 
@@ -0,0 +1,55 @@
+package repositories
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+// dryRunDB builds SQL without a database and records each query it would run.
+func dryRunDB(t *testing.T) (*gorm.DB, *[]string) {
+	t.Helper()
+	db, err := gorm.Open(postgres.New(postgres.Config{DSN: "host=localhost dbname=orders"}), &gorm.Config{DryRun: true, DisableAutomaticPing: true})
+	if err != nil {
+		t.Fatalf("open dry-run db: %v", err)
+	}
+	var queries []string
+	db.Callback().Query().After("gorm:query").Register("test:record", func(tx *gorm.DB) {
+		queries = append(queries, tx.Statement.SQL.String())
+		tx.Statement.SQL.Reset()
+		tx.Statement.Vars = nil
+	})
+	return db, &queries
+}
+
+// Deactivating an account only sets deleted_at on the user-service record.
+// The order queries must not join or filter on users, so admins keep seeing
+// a deactivated user's orders, both in the full listing and per user.
+func TestOrderQueries_DoNotDependOnUserAccounts(t *testing.T) {
+	db, queries := dryRunDB(t)
+	repo := NewGormOrderRepository(db)
+	deactivatedUser := uuid.New()
+
+	if _, _, err := repo.FindAll(context.Background(), 1, 10); err != nil {
+		t.Fatalf("FindAll: %v", err)
+	}
+	if _, _, err := repo.FindByUserID(context.Background(), deactivatedUser, 1, 10); err != nil {
+		t.Fatalf("FindByUserID: %v", err)
+	}
+
+	if len(*queries) != 4 {
+		t.Fatalf("expected a count and a select per listing, got %q", *queries)
+	}
+	for _, q := range *queries {
+		if !strings.Contains(q, `FROM "orders"`) || strings.Contains(q, "users") {
+			t.Fatalf("expected a query on orders alone, got %q", q)
+		}
+		if strings.Count(q, "deleted_at") != 1 || !strings.Contains(q, `"orders"."deleted_at" IS NULL`) {
+			t.Fatalf("expected only the order's own soft delete to be checked, got %q", q)
+		}
+	}
+}
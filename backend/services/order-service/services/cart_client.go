@@ -0,0 +1,47 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// CartClearer empties a user's cart.
+type CartClearer interface {
+	ClearCart(ctx context.Context, userID string) error
+}
+
+// CartClient calls cart-service.
+type CartClient struct {
+	baseURL string
+	client  *http.Client
+}
+
+func NewCartClient(baseURL string) *CartClient {
+	return &CartClient{
+		baseURL: strings.TrimRight(baseURL, "/"),
+		client:  &http.Client{Timeout: 5 * time.Second},
+	}
+}
+
+// ClearCart removes every item from the user's cart.
+func (c *CartClient) ClearCart(ctx context.Context, userID string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, c.baseURL+"/cart", nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("X-User-ID", userID)
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("cart service returned %d", resp.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,35 @@
+package services
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestCartClient_ClearCart(t *testing.T) {
+	var method, path, userID string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		method, path, userID = r.Method, r.URL.Path, r.Header.Get("X-User-ID")
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	if err := NewCartClient(srv.URL+"/").ClearCart(context.Background(), "user-1"); err != nil {
+		t.Fatalf("ClearCart: %v", err)
+	}
+	if method != http.MethodDelete || path != "/cart" || userID != "user-1" {
+		t.Fatalf("unexpected request %s %s for user %q", method, path, userID)
+	}
+}
+
+func TestCartClient_ClearCartUpstreamError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer srv.Close()
+
+	if err := NewCartClient(srv.URL).ClearCart(context.Background(), "user-1"); err == nil {
+		t.Fatal("expected an error for a 500 from cart-service")
+	}
+}
@@ -0,0 +1,105 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// ReservationLine identifies one order line's hold on stock. Line is the
+// item's position in the checkout, so two lines for the same product hold
+// stock separately.
+type ReservationLine struct {
+	OrderID   string `json:"order_id"`
+	Line      int    `json:"line"`
+	ProductID string `json:"product_id"`
+	Quantity  int    `json:"quantity"`
+}
+
+// InventoryReserver holds stock for order lines until the order is paid or
+// fails. Every call is idempotent per order line.
+type InventoryReserver interface {
+	// Reserve holds stock for line. It reports false when there is not
+	// enough stock, and an error when the outcome is unknown. stock is the
+	// catalogue's current quantity, used by inventory the first time it sees
+	// the product.
+	Reserve(ctx context.Context, line ReservationLine, stock int) (bool, error)
+	// Commit takes a paid line's held stock out of inventory.
+	Commit(ctx context.Context, line ReservationLine) error
+	// Release returns an unpaid line's held stock.
+	Release(ctx context.Context, line ReservationLine) error
+}
+
+// InventoryClient calls inventory-service.
+type InventoryClient struct {
+	baseURL string
+	client  *http.Client
+}
+
+func NewInventoryClient(baseURL string) *InventoryClient {
+	return &InventoryClient{
+		baseURL: strings.TrimRight(baseURL, "/"),
+		client:  &http.Client{Timeout: 5 * time.Second},
+	}
+}
+
+// Reserve asks inventory-service to hold line.Quantity units of line.ProductID.
+func (c *InventoryClient) Reserve(ctx context.Context, line ReservationLine, stock int) (bool, error) {
+	status, err := c.post(ctx, "/inventory/reserve", struct {
+		ReservationLine
+		Stock int `json:"stock"`
+	}{line, stock})
+	if err != nil {
+		return false, err
+	}
+	switch status {
+	case http.StatusOK:
+		return true, nil
+	case http.StatusConflict:
+		return false, nil
+	default:
+		return false, fmt.Errorf("inventory service returned %d", status)
+	}
+}
+
+func (c *InventoryClient) Commit(ctx context.Context, line ReservationLine) error {
+	return c.settle(ctx, "/inventory/commit", line)
+}
+
+func (c *InventoryClient) Release(ctx context.Context, line ReservationLine) error {
+	return c.settle(ctx, "/inventory/release", line)
+}
+
+func (c *InventoryClient) settle(ctx context.Context, path string, line ReservationLine) error {
+	status, err := c.post(ctx, path, line)
+	if err != nil {
+		return err
+	}
+	if status != http.StatusOK {
+		return fmt.Errorf("inventory service returned %d", status)
+	}
+	return nil
+}
+
+func (c *InventoryClient) post(ctx context.Context, path string, payload interface{}) (int, error) {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return 0, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.baseURL+path, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	return resp.StatusCode, nil
+}
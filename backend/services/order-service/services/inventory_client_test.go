@@ -0,0 +1,74 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestInventoryClient_Reserve(t *testing.T) {
+	var got map[string]interface{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/inventory/reserve" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		json.NewDecoder(r.Body).Decode(&got)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	line := ReservationLine{OrderID: "order-1", Line: 1, ProductID: "product-1", Quantity: 2}
+	ok, err := NewInventoryClient(srv.URL+"/").Reserve(context.Background(), line, 7)
+	if err != nil || !ok {
+		t.Fatalf("expected reservation, got ok=%v err=%v", ok, err)
+	}
+	if got["order_id"] != "order-1" || got["line"] != float64(1) || got["product_id"] != "product-1" ||
+		got["quantity"] != float64(2) || got["stock"] != float64(7) {
+		t.Fatalf("unexpected body %v", got)
+	}
+}
+
+func TestInventoryClient_ReserveOutcomes(t *testing.T) {
+	for _, tc := range []struct {
+		status  int
+		want    bool
+		wantErr bool
+	}{
+		{http.StatusConflict, false, false},
+		{http.StatusInternalServerError, false, true},
+	} {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(tc.status)
+		}))
+		ok, err := NewInventoryClient(srv.URL).Reserve(context.Background(), ReservationLine{OrderID: "order-1", ProductID: "product-1", Quantity: 1}, 1)
+		srv.Close()
+		if ok != tc.want || (err != nil) != tc.wantErr {
+			t.Fatalf("status %d: got ok=%v err=%v", tc.status, ok, err)
+		}
+	}
+}
+
+func TestInventoryClient_CommitAndRelease(t *testing.T) {
+	var paths []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.Path)
+		if r.URL.Path == "/inventory/release" {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	defer srv.Close()
+
+	client := NewInventoryClient(srv.URL)
+	line := ReservationLine{OrderID: "order-1", ProductID: "product-1", Quantity: 1}
+	if err := client.Commit(context.Background(), line); err != nil {
+		t.Fatalf("commit: %v", err)
+	}
+	if err := client.Release(context.Background(), line); err == nil {
+		t.Fatal("expected release to fail on 503")
+	}
+	if len(paths) != 2 || paths[0] != "/inventory/commit" || paths[1] != "/inventory/release" {
+		t.Fatalf("unexpected requests %v", paths)
+	}
+}
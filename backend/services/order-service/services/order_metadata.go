@@ -0,0 +1,59 @@
+package services
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// Limits on order metadata (gift messages, delivery notes and the like).
+const (
+	maxMetadataKeys     = 10
+	maxMetadataKeyLen   = 40
+	maxMetadataValueLen = 500
+)
+
+// SanitizeMetadata trims keys and values, strips control characters (other
+// than newlines in values) and drops empty entries. It returns an error if the
+// result exceeds the size limits. An empty result is returned as nil.
+func SanitizeMetadata(in map[string]string) (map[string]string, error) {
+	if len(in) == 0 {
+		return nil, nil
+	}
+
+	out := make(map[string]string, len(in))
+	for k, v := range in {
+		key := strings.TrimSpace(stripControl(k, false))
+		value := strings.TrimSpace(stripControl(v, true))
+		if key == "" || value == "" {
+			continue
+		}
+		if len([]rune(key)) > maxMetadataKeyLen {
+			return nil, fmt.Errorf("metadata key %q is longer than %d characters", key, maxMetadataKeyLen)
+		}
+		if len([]rune(value)) > maxMetadataValueLen {
+			return nil, fmt.Errorf("metadata value for %q is longer than %d characters", key, maxMetadataValueLen)
+		}
+		out[key] = value
+	}
+
+	if len(out) > maxMetadataKeys {
+		return nil, fmt.Errorf("metadata has more than %d entries", maxMetadataKeys)
+	}
+	if len(out) == 0 {
+		return nil, nil
+	}
+	return out, nil
+}
+
+func stripControl(s string, keepNewlines bool) string {
+	return strings.Map(func(r rune) rune {
+		if keepNewlines && r == '\n' {
+			return r
+		}
+		if unicode.IsControl(r) {
+			return -1
+		}
+		return r
+	}, s)
+}
@@ -0,0 +1,33 @@
+package services
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestSanitizeMetadata_TrimsAndDropsEmpty(t *testing.T) {
+	got, err := SanitizeMetadata(map[string]string{
+		" gift_message ": "Line one\nLine two\x00",
+		"internal_note":  "   ",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 1 || got["gift_message"] != "Line one\nLine two" {
+		t.Fatalf("unexpected metadata %q", got)
+	}
+}
+
+func TestSanitizeMetadata_Limits(t *testing.T) {
+	tooMany := map[string]string{}
+	for i := 0; i <= maxMetadataKeys; i++ {
+		tooMany[fmt.Sprintf("k%d", i)] = "v"
+	}
+	longKey := map[string]string{fmt.Sprintf("%041d", 0): "v"}
+
+	for name, in := range map[string]map[string]string{"too many keys": tooMany, "long key": longKey} {
+		if _, err := SanitizeMetadata(in); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
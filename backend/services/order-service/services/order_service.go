@@ -3,15 +3,19 @@ package services
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"log"
 	"order-service/models"
 	repositories "order-service/repository"
+	"order-service/tracing"
 
 	aws_pkg "github.com/yashrajoria/E-Commerce-backend/backend/pkg/aws"
 
 	"time"
 
 	"github.com/google/uuid"
+	apperrors "github.com/yashrajoria/common/errors"
+	"go.opentelemetry.io/otel/trace"
 )
 
 type CreateOrderRequest struct {
@@ -19,6 +23,8 @@ type CreateOrderRequest struct {
 		ProductID uuid.UUID `json:"product_id" binding:"required"`
 		Quantity  int       `json:"quantity" binding:"required,min=1"`
 	} `json:"items" binding:"required,dive"`
+	// Metadata holds free-form notes such as a gift message; see SanitizeMetadata
+	Metadata map[string]string `json:"metadata"`
 }
 
 type OrderResponse struct {
@@ -37,6 +43,8 @@ type MetaData struct {
 type ServiceError struct {
 	StatusCode int
 	Message    string
+	// Code is an optional machine-readable error code; see httputil.RespondErrorCode.
+	Code string
 }
 
 func (e *ServiceError) Error() string {
@@ -58,8 +66,9 @@ func NewOrderServiceSQS(orderRepo repositories.OrderRepository, snsClient aws_pk
 	}
 }
 
-// CreateOrder processes order creation via SNS
-func (s *OrderService) CreateOrder(ctx context.Context, userID string, req *CreateOrderRequest) *ServiceError {
+// CreateOrder processes order creation via SNS. email is the caller's address
+// as forwarded by the gateway and is kept on the order for receipts.
+func (s *OrderService) CreateOrder(ctx context.Context, userID, email string, req *CreateOrderRequest) *ServiceError {
 	if len(req.Items) == 0 {
 		return &ServiceError{
 			StatusCode: 400,
@@ -67,6 +76,14 @@ func (s *OrderService) CreateOrder(ctx context.Context, userID string, req *Crea
 		}
 	}
 
+	metadata, err := SanitizeMetadata(req.Metadata)
+	if err != nil {
+		return &ServiceError{
+			StatusCode: 400,
+			Message:    err.Error(),
+		}
+	}
+
 	// Build event items
 	eventItems := make([]models.CheckoutItem, 0, len(req.Items))
 	for _, item := range req.Items {
@@ -76,12 +93,18 @@ func (s *OrderService) CreateOrder(ctx context.Context, userID string, req *Crea
 		})
 	}
 
+	ctx, span := tracing.Tracer().Start(ctx, "checkout.publish", trace.WithSpanKind(trace.SpanKindProducer))
+	defer span.End()
+
 	// Create checkout event
 	checkoutEvent := models.CheckoutEvent{
-		UserID:    userID,
-		OrderID:   uuid.New().String(),
-		Items:     eventItems,
-		Timestamp: time.Now(),
+		UserID:       userID,
+		UserEmail:    email,
+		Metadata:     metadata,
+		OrderID:      uuid.New().String(),
+		Items:        eventItems,
+		Timestamp:    time.Now(),
+		TraceContext: tracing.Inject(ctx),
 	}
 
 	eventBytes, err := json.Marshal(checkoutEvent)
@@ -93,19 +116,25 @@ func (s *OrderService) CreateOrder(ctx context.Context, userID string, req *Crea
 		}
 	}
 
-	// Publish to SNS (which fans out to SQS queues)
-	if s.snsClient != nil && s.snsTopicArn != "" {
-		if err := s.snsClient.Publish(ctx, s.snsTopicArn, eventBytes); err != nil {
-			log.Printf("[OrderService] SNS publish failed: %v", err)
-			return &ServiceError{
-				StatusCode: 500,
-				Message:    "Failed to publish checkout event",
-			}
+	// Without a publisher the checkout would never happen, so don't accept it
+	if s.snsClient == nil || s.snsTopicArn == "" {
+		log.Printf("[OrderService] SNS client not configured, rejecting order for user: %s", userID)
+		return &ServiceError{
+			StatusCode: 503,
+			Message:    "Order processing is unavailable",
+		}
+	}
+
+	// Publish to SNS (which fans out to SQS queues). Publish returns only once
+	// SNS has accepted the message, so a nil error means the event is durable.
+	if err := s.snsClient.Publish(ctx, s.snsTopicArn, eventBytes); err != nil {
+		log.Printf("[OrderService] SNS publish failed: %v", err)
+		return &ServiceError{
+			StatusCode: 500,
+			Message:    "Failed to publish checkout event",
 		}
-		log.Printf("[OrderService] SNS published to %s", s.snsTopicArn)
-	} else {
-		log.Printf("[OrderService] Warning: SNS client not configured, order event not published")
 	}
+	log.Printf("[OrderService] SNS published to %s", s.snsTopicArn)
 
 	log.Printf("[OrderService] Order creation initiated for user: %s", userID)
 	return nil
@@ -179,7 +208,7 @@ func (s *OrderService) GetOrderByID(ctx context.Context, userID string, order_id
 
 	order, err := s.orderRepo.FindByIDAndUserID(ctx, order_id, userUUID)
 	if err != nil {
-		if err.Error() == "record not found" {
+		if errors.Is(err, apperrors.ErrNotFound) {
 			return nil, &ServiceError{
 				StatusCode: 404,
 				Message:    "Order not found",
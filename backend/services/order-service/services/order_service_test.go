@@ -3,19 +3,32 @@ package services
 import (
 	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
+	"order-service/models"
+	repositories "order-service/repository"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/google/uuid"
+	apperrors "github.com/yashrajoria/common/errors"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
 )
 
 // mockSNS implements aws.SNSPublisher (avoids importing aws pkg in test)
 type mockSNS struct {
 	publishedArn string
 	publishedMsg []byte
+	err          error
 }
 
 func (m *mockSNS) Publish(ctx context.Context, topicArn string, message []byte) error {
+	if m.err != nil {
+		return m.err
+	}
 	m.publishedArn = topicArn
 	m.publishedMsg = append([]byte(nil), message...)
 	return nil
@@ -41,7 +54,7 @@ func TestCreateOrder_PublishesToSNS(t *testing.T) {
 	}{ProductID: pid, Quantity: 2})
 
 	// Act
-	err := svc.CreateOrder(context.Background(), "1", req)
+	err := svc.CreateOrder(context.Background(), "1", "buyer@example.com", req)
 	if err != nil {
 		t.Fatalf("CreateOrder returned error: %v", err)
 	}
@@ -66,3 +79,122 @@ func TestCreateOrder_PublishesToSNS(t *testing.T) {
 	// small timing sanity
 	time.Sleep(10 * time.Millisecond)
 }
+
+func singleItemOrder() *CreateOrderRequest {
+	req := &CreateOrderRequest{}
+	req.Items = append(req.Items, struct {
+		ProductID uuid.UUID `json:"product_id" binding:"required"`
+		Quantity  int       `json:"quantity" binding:"required,min=1"`
+	}{ProductID: uuid.New(), Quantity: 1})
+	return req
+}
+
+func TestCreateOrder_PublishFailureReturns500(t *testing.T) {
+	sns := &mockSNS{err: errors.New("sns: throttled")}
+	svc := NewOrderServiceSQS(nil, sns, "arn:aws:sns:eu-west-2:000000000000:order-events")
+
+	serr := svc.CreateOrder(context.Background(), "1", "buyer@example.com", singleItemOrder())
+	if serr == nil || serr.StatusCode != 500 {
+		t.Fatalf("expected 500 when SNS rejects the publish, got %+v", serr)
+	}
+}
+
+func TestCreateOrder_UnconfiguredPublisherReturns503(t *testing.T) {
+	svc := NewOrderServiceSQS(nil, nil, "")
+
+	serr := svc.CreateOrder(context.Background(), "1", "buyer@example.com", singleItemOrder())
+	if serr == nil || serr.StatusCode != 503 {
+		t.Fatalf("expected 503 without a publisher, got %+v", serr)
+	}
+}
+
+func TestCreateOrder_EmbedsTraceContextInEvent(t *testing.T) {
+	prevTP, prevProp := otel.GetTracerProvider(), otel.GetTextMapPropagator()
+	tp := sdktrace.NewTracerProvider()
+	otel.SetTracerProvider(tp)
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	t.Cleanup(func() {
+		tp.Shutdown(context.Background())
+		otel.SetTracerProvider(prevTP)
+		otel.SetTextMapPropagator(prevProp)
+	})
+
+	sns := &mockSNS{}
+	svc := NewOrderServiceSQS(nil, sns, "arn:aws:sns:eu-west-2:000000000000:order-events")
+
+	ctx, span := tp.Tracer("test").Start(context.Background(), "POST /orders")
+	serr := svc.CreateOrder(ctx, "1", "buyer@example.com", singleItemOrder())
+	span.End()
+	if serr != nil {
+		t.Fatalf("CreateOrder returned error: %v", serr)
+	}
+
+	var evt models.CheckoutEvent
+	if err := json.Unmarshal(sns.publishedMsg, &evt); err != nil {
+		t.Fatalf("invalid event: %v", err)
+	}
+	traceparent := evt.TraceContext["traceparent"]
+	if traceparent == "" {
+		t.Fatalf("expected traceparent in event, got %v", evt.TraceContext)
+	}
+	if want := span.SpanContext().TraceID().String(); traceparent[3:35] != want {
+		t.Fatalf("expected trace id %s in %s", want, traceparent)
+	}
+}
+
+// notFoundOrderRepo answers every lookup with a wrapped ErrNotFound.
+type notFoundOrderRepo struct {
+	repositories.OrderRepository
+}
+
+func (notFoundOrderRepo) FindByIDAndUserID(ctx context.Context, orderID, userID uuid.UUID) (*models.Order, error) {
+	return nil, fmt.Errorf("order %s: %w", orderID, apperrors.ErrNotFound)
+}
+
+func TestGetOrderByID_NotFoundThroughWrapping(t *testing.T) {
+	svc := NewOrderServiceSQS(notFoundOrderRepo{}, &mockSNS{}, "")
+
+	_, serr := svc.GetOrderByID(context.Background(), uuid.New().String(), uuid.New())
+	if serr == nil || serr.StatusCode != 404 {
+		t.Fatalf("expected 404 service error, got %+v", serr)
+	}
+}
+
+func TestCreateOrder_MetadataRoundTrip(t *testing.T) {
+	sns := &mockSNS{}
+	svc := NewOrderServiceSQS(nil, sns, "arn:orders")
+	req := singleItemOrder()
+	req.Metadata = map[string]string{"gift_message": "  Happy birthday!\x07 ", "empty": " "}
+
+	if serr := svc.CreateOrder(context.Background(), "1", "buyer@example.com", req); serr != nil {
+		t.Fatalf("CreateOrder: %+v", serr)
+	}
+
+	var evt models.CheckoutEvent
+	if err := json.Unmarshal(sns.publishedMsg, &evt); err != nil {
+		t.Fatalf("decode checkout event: %v", err)
+	}
+	order := models.Order{Metadata: checkoutMetadata(evt)}
+
+	raw, _ := json.Marshal(order)
+	var resp struct{ Metadata map[string]string }
+	json.Unmarshal(raw, &resp)
+	if len(resp.Metadata) != 1 || resp.Metadata["gift_message"] != "Happy birthday!" {
+		t.Fatalf("expected sanitized gift message in order response, got %v", resp.Metadata)
+	}
+}
+
+func TestCreateOrder_OversizedMetadataRejected(t *testing.T) {
+	sns := &mockSNS{}
+	svc := NewOrderServiceSQS(nil, sns, "arn:orders")
+	req := singleItemOrder()
+	req.Metadata = map[string]string{"note": strings.Repeat("x", 501)}
+
+	serr := svc.CreateOrder(context.Background(), "1", "buyer@example.com", req)
+	if serr == nil || serr.StatusCode != 400 {
+		t.Fatalf("expected 400, got %+v", serr)
+	}
+	if sns.publishedMsg != nil {
+		t.Fatal("rejected order must not be published")
+	}
+}
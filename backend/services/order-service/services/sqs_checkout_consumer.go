@@ -3,29 +3,42 @@ package services
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"log"
 	"order-service/models"
+	"order-service/tracing"
 	"os"
 	"time"
 
 	"github.com/google/uuid"
 	aws_pkg "github.com/yashrajoria/E-Commerce-backend/backend/pkg/aws"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
 	"gorm.io/gorm"
 )
 
 // SQSCheckoutConsumer consumes checkout events from SQS and creates orders
 type SQSCheckoutConsumer struct {
-	sqsConsumer    *aws_pkg.SQSConsumer
-	sqsPublisher   *aws_pkg.SQSConsumer // For sending payment requests
-	db             *gorm.DB
+	sqsConsumer  *aws_pkg.SQSConsumer
+	sqsPublisher *aws_pkg.SQSConsumer // For sending payment requests
+	db           *gorm.DB
+	inventory    InventoryReserver
+	tax          TaxCalculator
+	currency     string
 }
 
-// NewSQSCheckoutConsumer creates a new SQS-based checkout consumer
-func NewSQSCheckoutConsumer(sqsConsumer *aws_pkg.SQSConsumer, sqsPublisher *aws_pkg.SQSConsumer, db *gorm.DB) *SQSCheckoutConsumer {
+// NewSQSCheckoutConsumer creates a new SQS-based checkout consumer. Stock for
+// each item is reserved through inventory; orders are priced in currency and
+// taxed with tax.
+func NewSQSCheckoutConsumer(sqsConsumer *aws_pkg.SQSConsumer, sqsPublisher *aws_pkg.SQSConsumer, db *gorm.DB, inventory InventoryReserver, tax TaxCalculator, currency string) *SQSCheckoutConsumer {
 	return &SQSCheckoutConsumer{
 		sqsConsumer:  sqsConsumer,
 		sqsPublisher: sqsPublisher,
 		db:           db,
+		inventory:    inventory,
+		tax:          tax,
+		currency:     currency,
 	}
 }
 
@@ -42,8 +55,6 @@ func (c *SQSCheckoutConsumer) Start(ctx context.Context) {
 }
 
 func (c *SQSCheckoutConsumer) handleMessage(ctx context.Context, body string) error {
-	log.Printf("[DEBUG] Raw SQS message: %s", body)
-
 	// Try to unwrap SNS envelope if present
 	var snsEnvelope struct {
 		Message string `json:"Message"`
@@ -58,6 +69,14 @@ func (c *SQSCheckoutConsumer) handleMessage(ctx context.Context, body string) er
 		return nil // Don't retry invalid JSON
 	}
 
+	// Continue the trace started by the checkout request
+	ctx = tracing.Extract(ctx, evt.TraceContext)
+	ctx, span := tracing.Tracer().Start(ctx, "order.create",
+		trace.WithSpanKind(trace.SpanKindConsumer),
+		trace.WithAttributes(attribute.String("order.id", evt.OrderID)),
+	)
+	defer span.End()
+
 	userUUID, err := uuid.Parse(evt.UserID)
 	if err != nil {
 		log.Printf("❌ user_id is not a valid UUID: %s", evt.UserID)
@@ -74,59 +93,53 @@ func (c *SQSCheckoutConsumer) handleMessage(ctx context.Context, body string) er
 		return nil
 	}
 
-	orderItems := make([]models.OrderItem, 0, len(evt.Items))
-	totalAmount := 0
-	validItems := 0
 	productServiceURL := os.Getenv("PRODUCT_SERVICE_URL")
+	orderItems, subtotal, err := buildOrderItems(ctx, evt.OrderID, evt.Items, func(ctx context.Context, pid uuid.UUID) (*Product, error) {
+		return FetchProductByID(ctx, productServiceURL, pid)
+	}, c.inventory)
+	if err != nil {
+		// Reservations are idempotent per order line, so the retry will not
+		// hold stock twice for the items already reserved
+		log.Printf("❌ inventory reservation failed for order=%s err=%v", evt.OrderID, err)
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "inventory reservation failed")
+		return err // Retry
+	}
 
-	for _, it := range evt.Items {
-		pid, err := uuid.Parse(it.ProductID)
-		if err != nil {
-			log.Printf("⚠️ skipping item with invalid product_id=%s", it.ProductID)
-			continue
-		}
-
-		if it.Quantity <= 0 {
-			log.Printf("⚠️ skipping item with invalid quantity product_id=%s qty=%d", it.ProductID, it.Quantity)
-			continue
-		}
-
-		product, err := FetchProductByID(ctx, productServiceURL, pid)
-		if err != nil {
-			log.Printf("⚠️ failed to fetch product for product_id=%s: %v", it.ProductID, err)
-			continue
-		}
-
-		if product.Stock < it.Quantity {
-			log.Printf("⚠️ insufficient stock for product_id=%s: available=%d requested=%d", it.ProductID, product.Stock, it.Quantity)
-			continue
-		}
+	if len(orderItems) == 0 {
+		log.Printf("❌ no valid items for user=%s, skipping order", evt.UserID)
+		return nil
+	}
 
-		orderItem := models.OrderItem{
-			ID:        uuid.New(),
-			ProductID: pid,
-			Quantity:  it.Quantity,
-			Price:     int(product.Price),
+	backordered := 0
+	for _, item := range orderItems {
+		if item.FulfillmentStatus == models.FulfillmentBackordered {
+			backordered++
 		}
-
-		totalAmount += it.Quantity * int(product.Price)
-		orderItems = append(orderItems, orderItem)
-		validItems++
 	}
-
-	if validItems == 0 {
-		log.Printf("❌ no valid items for user=%s, skipping order", evt.UserID)
+	if backordered == len(orderItems) {
+		log.Printf("❌ no items could be reserved for order=%s, skipping order", evt.OrderID)
 		return nil
 	}
 
 	order := models.Order{
-		UserID:      userUUID,
-		ID:          orderIDUUID,
-		Amount:      totalAmount,
-		Status:      "pending_payment",
-		OrderNumber: "ORD-" + time.Now().Format("20060102-150405") + "-" + uuid.New().String()[:8],
-		CreatedAt:   time.Now(),
-		UpdatedAt:   time.Now(),
+		UserID:        userUUID,
+		ID:            orderIDUUID,
+		Subtotal:      subtotal,
+		Currency:      c.currency,
+		Status:        "pending_payment",
+		CustomerEmail: evt.UserEmail,
+		Metadata:      checkoutMetadata(evt),
+		OrderNumber:   "ORD-" + time.Now().Format("20060102-150405") + "-" + uuid.New().String()[:8],
+		CreatedAt:     time.Now(),
+		UpdatedAt:     time.Now(),
+	}
+
+	if err := applyTax(ctx, c.tax, &order); err != nil {
+		log.Printf("❌ tax calculation failed for order=%s err=%v", order.ID.String(), err)
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "tax calculation failed")
+		return err // Retry
 	}
 
 	err = c.db.Transaction(func(tx *gorm.DB) error {
@@ -140,25 +153,108 @@ func (c *SQSCheckoutConsumer) handleMessage(ctx context.Context, body string) er
 	})
 	if err != nil {
 		log.Printf("❌ DB transaction failed for user=%s err=%v", evt.UserID, err)
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "order insert failed")
 		return err // Retry
 	}
 
-	log.Printf("✅ order created id=%s user=%s items=%d total_amount=%d",
-		order.ID.String(), order.UserID.String(), validItems, order.Amount)
+	log.Printf("✅ order created id=%s user=%s items=%d backordered=%d subtotal=%d tax=%d total=%d %s",
+		order.ID.String(), order.UserID.String(), len(orderItems), backordered, order.Subtotal, order.Tax, order.Total, order.Currency)
 
 	// Send payment request to SQS
+	pubCtx, pubSpan := tracing.Tracer().Start(ctx, "payment_request.publish", trace.WithSpanKind(trace.SpanKindProducer))
 	req := models.PaymentRequest{
-		OrderID: order.ID.String(),
-		UserID:  order.UserID.String(),
-		Amount:  order.Amount,
+		OrderID:      order.ID.String(),
+		UserID:       order.UserID.String(),
+		Amount:       order.Amount,
+		Currency:     order.Currency,
+		TraceContext: tracing.Inject(pubCtx),
 	}
 	reqBytes, _ := json.Marshal(req)
-	if err := c.sqsPublisher.SendMessage(ctx, string(reqBytes)); err != nil {
+	if err := c.sqsPublisher.SendMessage(pubCtx, string(reqBytes)); err != nil {
 		log.Printf("❌ failed to publish payment-request for order=%s: %v", order.ID.String(), err)
+		pubSpan.RecordError(err)
+		pubSpan.SetStatus(codes.Error, "payment request publish failed")
 		// Don't return error - order is created, payment request can be retried
 	} else {
 		log.Printf("✅ payment-request sent for order=%s", order.ID.String())
 	}
+	pubSpan.End()
 
 	return nil
 }
+
+// productFetcher looks up a product's current price and stock.
+type productFetcher func(ctx context.Context, productID uuid.UUID) (*Product, error)
+
+// buildOrderItems turns checkout items into order items and reserves stock
+// for each one, keyed by the item's position in items. Items inventory could reserve are marked reserved; the rest
+// are kept as backordered rather than dropped, so the order records what
+// cannot ship yet. Only reserved items count towards the returned subtotal.
+// Malformed items and products that cannot be fetched are skipped. A
+// reservation whose outcome is unknown fails the whole build.
+func buildOrderItems(ctx context.Context, orderID string, items []models.CheckoutItem, fetch productFetcher, inventory InventoryReserver) ([]models.OrderItem, int, error) {
+	orderItems := make([]models.OrderItem, 0, len(items))
+	totalAmount := 0
+
+	for line, it := range items {
+		pid, err := uuid.Parse(it.ProductID)
+		if err != nil {
+			log.Printf("⚠️ skipping item with invalid product_id=%s", it.ProductID)
+			continue
+		}
+
+		if it.Quantity <= 0 {
+			log.Printf("⚠️ skipping item with invalid quantity product_id=%s qty=%d", it.ProductID, it.Quantity)
+			continue
+		}
+
+		product, err := fetch(ctx, pid)
+		if err != nil {
+			log.Printf("⚠️ failed to fetch product for product_id=%s: %v", it.ProductID, err)
+			continue
+		}
+
+		reserved, err := inventory.Reserve(ctx, ReservationLine{
+			OrderID:   orderID,
+			Line:      line,
+			ProductID: pid.String(),
+			Quantity:  it.Quantity,
+		}, product.Stock)
+		if err != nil {
+			return nil, 0, fmt.Errorf("reserve product_id=%s: %w", it.ProductID, err)
+		}
+
+		status := models.FulfillmentReserved
+		if !reserved {
+			log.Printf("⚠️ could not reserve product_id=%s qty=%d, backordering", it.ProductID, it.Quantity)
+			status = models.FulfillmentBackordered
+		}
+
+		orderItems = append(orderItems, models.OrderItem{
+			ID:                uuid.New(),
+			ProductID:         pid,
+			Quantity:          it.Quantity,
+			Price:             int(product.Price),
+			FulfillmentStatus: status,
+			Line:              line,
+		})
+		if reserved {
+			totalAmount += it.Quantity * int(product.Price)
+		}
+	}
+
+	return orderItems, totalAmount, nil
+}
+
+// checkoutMetadata re-applies the metadata limits to an incoming event, since
+// the queue may carry events from producers other than CreateOrder. Invalid
+// metadata is dropped rather than failing the order.
+func checkoutMetadata(evt models.CheckoutEvent) map[string]string {
+	metadata, err := SanitizeMetadata(evt.Metadata)
+	if err != nil {
+		log.Printf("⚠️ dropping metadata for order=%s: %v", evt.OrderID, err)
+		return nil
+	}
+	return metadata
+}
@@ -0,0 +1,150 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"order-service/models"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+// fakeInventory reserves from a fixed stock table and records each call.
+type fakeInventory struct {
+	stock     map[string]int
+	err       error
+	reserved  []ReservationLine
+	committed []ReservationLine
+	released  []ReservationLine
+}
+
+func (f *fakeInventory) Reserve(ctx context.Context, line ReservationLine, stock int) (bool, error) {
+	if f.err != nil {
+		return false, f.err
+	}
+	if f.stock[line.ProductID] < line.Quantity {
+		return false, nil
+	}
+	f.stock[line.ProductID] -= line.Quantity
+	f.reserved = append(f.reserved, line)
+	return true, nil
+}
+
+func (f *fakeInventory) Commit(ctx context.Context, line ReservationLine) error {
+	if f.err != nil {
+		return f.err
+	}
+	f.committed = append(f.committed, line)
+	return nil
+}
+
+func (f *fakeInventory) Release(ctx context.Context, line ReservationLine) error {
+	if f.err != nil {
+		return f.err
+	}
+	f.released = append(f.released, line)
+	return nil
+}
+
+func TestBuildOrderItems_MixedAvailableAndBackordered(t *testing.T) {
+	inStock, shortStock, missing := uuid.New(), uuid.New(), uuid.New()
+	catalog := map[uuid.UUID]*Product{
+		inStock:    {ID: inStock, Price: 100},
+		shortStock: {ID: shortStock, Price: 40},
+	}
+	fetch := func(ctx context.Context, id uuid.UUID) (*Product, error) {
+		if p, ok := catalog[id]; ok {
+			return p, nil
+		}
+		return nil, errors.New("product service returned 404")
+	}
+	inventory := &fakeInventory{stock: map[string]int{inStock.String(): 5, shortStock.String(): 1}}
+
+	items, total, err := buildOrderItems(context.Background(), uuid.NewString(), []models.CheckoutItem{
+		{ProductID: inStock.String(), Quantity: 2},
+		{ProductID: shortStock.String(), Quantity: 3},
+		{ProductID: missing.String(), Quantity: 1},
+		{ProductID: "not-a-uuid", Quantity: 1},
+	}, fetch, inventory)
+	if err != nil {
+		t.Fatalf("buildOrderItems: %v", err)
+	}
+
+	if len(items) != 2 {
+		t.Fatalf("expected 2 order items, got %d", len(items))
+	}
+	status := map[uuid.UUID]string{}
+	for _, it := range items {
+		status[it.ProductID] = it.FulfillmentStatus
+	}
+	if status[inStock] != models.FulfillmentReserved {
+		t.Fatalf("expected in-stock item reserved, got %q", status[inStock])
+	}
+	if status[shortStock] != models.FulfillmentBackordered {
+		t.Fatalf("expected short-stock item backordered, got %q", status[shortStock])
+	}
+	// Backordered items are kept on the order but not charged
+	if total != 2*100 {
+		t.Fatalf("expected total 200, got %d", total)
+	}
+}
+
+func TestBuildOrderItems_AllBackordered(t *testing.T) {
+	pid := uuid.New()
+	fetch := func(ctx context.Context, id uuid.UUID) (*Product, error) {
+		return &Product{ID: id, Price: 10}, nil
+	}
+	inventory := &fakeInventory{stock: map[string]int{}}
+
+	items, total, err := buildOrderItems(context.Background(), uuid.NewString(), []models.CheckoutItem{{ProductID: pid.String(), Quantity: 1}}, fetch, inventory)
+	if err != nil {
+		t.Fatalf("buildOrderItems: %v", err)
+	}
+
+	if len(items) != 1 || items[0].FulfillmentStatus != models.FulfillmentBackordered {
+		t.Fatalf("expected a single backordered item, got %+v", items)
+	}
+	if total != 0 {
+		t.Fatalf("expected nothing charged, got %d", total)
+	}
+}
+
+func TestBuildOrderItems_SameProductOnTwoLines(t *testing.T) {
+	pid := uuid.New()
+	fetch := func(ctx context.Context, id uuid.UUID) (*Product, error) {
+		return &Product{ID: id, Price: 10}, nil
+	}
+	inventory := &fakeInventory{stock: map[string]int{pid.String(): 3}}
+
+	items, total, err := buildOrderItems(context.Background(), uuid.NewString(), []models.CheckoutItem{
+		{ProductID: pid.String(), Quantity: 2},
+		{ProductID: pid.String(), Quantity: 2},
+	}, fetch, inventory)
+	if err != nil {
+		t.Fatalf("buildOrderItems: %v", err)
+	}
+
+	// Each line holds its own stock, so the second one does not fit
+	if items[0].FulfillmentStatus != models.FulfillmentReserved || items[1].FulfillmentStatus != models.FulfillmentBackordered {
+		t.Fatalf("unexpected statuses %q, %q", items[0].FulfillmentStatus, items[1].FulfillmentStatus)
+	}
+	if items[0].Line != 0 || items[1].Line != 1 {
+		t.Fatalf("unexpected lines %d, %d", items[0].Line, items[1].Line)
+	}
+	if total != 20 {
+		t.Fatalf("expected total 20, got %d", total)
+	}
+}
+
+func TestBuildOrderItems_ReservationErrorFails(t *testing.T) {
+	pid := uuid.New()
+	fetch := func(ctx context.Context, id uuid.UUID) (*Product, error) {
+		return &Product{ID: id, Price: 10}, nil
+	}
+	inventory := &fakeInventory{err: errors.New("inventory service returned 503")}
+
+	_, _, err := buildOrderItems(context.Background(), uuid.NewString(), []models.CheckoutItem{{ProductID: pid.String(), Quantity: 1}}, fetch, inventory)
+	if err == nil {
+		t.Fatal("expected an error when the reservation outcome is unknown")
+	}
+}
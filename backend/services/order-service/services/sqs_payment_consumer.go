@@ -3,6 +3,7 @@ package services
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"log"
 	"order-service/models"
 	"time"
@@ -11,17 +12,60 @@ import (
 	"gorm.io/gorm"
 )
 
+// ConfirmationStore tracks which paid orders have had their confirmation
+// event published.
+type ConfirmationStore interface {
+	// PendingConfirmation returns the order, with items, if it is paid and no
+	// confirmation has been recorded yet; otherwise it returns nil.
+	PendingConfirmation(ctx context.Context, orderID string) (*models.Order, error)
+	MarkConfirmationSent(ctx context.Context, orderID string, at time.Time) error
+}
+
+// CartClearStore records which paid orders have had the buyer's cart emptied.
+type CartClearStore interface {
+	// ClaimCartClear marks a paid order's cart as cleared and reports whether
+	// this call set the mark, so a redelivered event does not empty the cart
+	// again.
+	ClaimCartClear(ctx context.Context, orderID string, at time.Time) (bool, error)
+	// UnclaimCartClear drops the mark again after the cart could not be
+	// emptied, so a redelivered event retries.
+	UnclaimCartClear(ctx context.Context, orderID string) error
+}
+
+// ReservedItemStore lists the items of an order that hold stock in inventory.
+type ReservedItemStore interface {
+	ReservedItems(ctx context.Context, orderID string) ([]models.OrderItem, error)
+}
+
 // SQSPaymentConsumer consumes payment events from SQS and updates order status
 type SQSPaymentConsumer struct {
-	sqsConsumer *aws_pkg.SQSConsumer
-	db          *gorm.DB
+	sqsConsumer   *aws_pkg.SQSConsumer
+	db            *gorm.DB
+	confirmations ConfirmationStore
+	snsClient     aws_pkg.SNSPublisher
+	snsTopicArn   string
+	carts         CartClearer
+	cartClears    CartClearStore
+	inventory     InventoryReserver
+	reservedItems ReservedItemStore
 }
 
-// NewSQSPaymentConsumer creates a new SQS-based payment event consumer
-func NewSQSPaymentConsumer(sqsConsumer *aws_pkg.SQSConsumer, db *gorm.DB) *SQSPaymentConsumer {
+// NewSQSPaymentConsumer creates a new SQS-based payment event consumer.
+// Paid orders are announced as order_confirmed on snsTopicArn, the buyer's
+// cart is emptied through carts and the stock held at checkout is committed
+// through inventory; failed payments release that stock instead.
+func NewSQSPaymentConsumer(sqsConsumer *aws_pkg.SQSConsumer, db *gorm.DB, snsClient aws_pkg.SNSPublisher, snsTopicArn string, carts CartClearer, inventory InventoryReserver) *SQSPaymentConsumer {
+	store := NewGormConfirmationStore(db)
 	return &SQSPaymentConsumer{
-		sqsConsumer: sqsConsumer,
-		db:          db,
+		sqsConsumer:   sqsConsumer,
+		db:            db,
+		confirmations: store,
+		snsClient:     snsClient,
+		snsTopicArn:   snsTopicArn,
+		carts:         carts,
+		cartClears:    store,
+		inventory:     inventory,
+		reservedItems: store,
 	}
 }
 
@@ -38,8 +82,6 @@ func (c *SQSPaymentConsumer) Start(ctx context.Context) {
 }
 
 func (c *SQSPaymentConsumer) handleMessage(ctx context.Context, body string) error {
-	log.Printf("[DEBUG] Raw payment event: %s", body)
-
 	// Try to unwrap SNS envelope if present
 	var snsEnvelope struct {
 		Message string `json:"Message"`
@@ -65,12 +107,22 @@ func (c *SQSPaymentConsumer) handleMessage(ctx context.Context, body string) err
 	switch evt.Type {
 	case "payment_succeeded":
 		c.updateOrderStatusWithTime(evt.OrderID, "paid", &now, nil)
+		c.clearCart(ctx, evt)
+		// Returning an error redelivers the message so the confirmation and
+		// the stock commit are retried; the order is already paid by then
+		settleErr := c.settleInventory(ctx, evt.OrderID, true)
+		if err := c.sendConfirmation(ctx, evt.OrderID); err != nil {
+			return err
+		}
+		return settleErr
 	case "payment_failed":
 		c.updateOrderStatusWithTime(evt.OrderID, "payment_failed", nil, &now)
+		return c.settleInventory(ctx, evt.OrderID, false)
 	case "checkout_session_created":
 		log.Printf("ℹ️  [OrderService][SQSPaymentConsumer] checkout session created for order=%s", evt.OrderID)
 	case "checkout_session_failed":
 		c.updateOrderStatusWithTime(evt.OrderID, "payment_failed", nil, &now)
+		return c.settleInventory(ctx, evt.OrderID, false)
 	default:
 		log.Printf("⚠️  [OrderService][SQSPaymentConsumer] unknown event type: %s", evt.Type)
 	}
@@ -117,3 +169,172 @@ func (c *SQSPaymentConsumer) updateOrderStatusWithTime(orderID, status string, c
 		log.Printf("✅ [OrderService][SQSPaymentConsumer] order=%s updated to %s", orderID, status)
 	}
 }
+
+// clearCart empties the buyer's cart once their order is paid. The order is
+// claimed first, so a redelivered payment_succeeded never empties a cart the
+// buyer has refilled since. It is best effort: a failure leaves the items in
+// the cart and is logged rather than holding up the confirmation, and the
+// claim is dropped so a redelivery tries again.
+func (c *SQSPaymentConsumer) clearCart(ctx context.Context, evt models.PaymentEvent) {
+	if c.carts == nil {
+		return
+	}
+	if evt.UserID == "" {
+		log.Printf("⚠️  [OrderService][SQSPaymentConsumer] no user_id on payment event for order=%s; cart not cleared", evt.OrderID)
+		return
+	}
+	claimed, err := c.cartClears.ClaimCartClear(ctx, evt.OrderID, time.Now().UTC())
+	if err != nil {
+		log.Printf("❌ [OrderService][SQSPaymentConsumer] failed to claim cart clear for order=%s: %v", evt.OrderID, err)
+		return
+	}
+	if !claimed {
+		log.Printf("ℹ️  [OrderService][SQSPaymentConsumer] cart already cleared for order=%s; skipping", evt.OrderID)
+		return
+	}
+	if err := c.carts.ClearCart(ctx, evt.UserID); err != nil {
+		log.Printf("❌ [OrderService][SQSPaymentConsumer] failed to clear cart for user=%s order=%s: %v", evt.UserID, evt.OrderID, err)
+		if err := c.cartClears.UnclaimCartClear(ctx, evt.OrderID); err != nil {
+			log.Printf("❌ [OrderService][SQSPaymentConsumer] failed to unclaim cart clear for order=%s: %v", evt.OrderID, err)
+		}
+		return
+	}
+	log.Printf("✅ [OrderService][SQSPaymentConsumer] cart cleared for user=%s order=%s", evt.UserID, evt.OrderID)
+}
+
+// settleInventory commits the stock held by an order's reserved items once it
+// is paid, or releases it when payment failed. Inventory ignores lines it no
+// longer holds, so a redelivered event settles nothing twice.
+func (c *SQSPaymentConsumer) settleInventory(ctx context.Context, orderID string, commit bool) error {
+	if c.inventory == nil {
+		return nil
+	}
+	items, err := c.reservedItems.ReservedItems(ctx, orderID)
+	if err != nil {
+		log.Printf("❌ [OrderService][SQSPaymentConsumer] failed to load reserved items for order=%s: %v", orderID, err)
+		return err
+	}
+
+	settle, action, done := c.inventory.Release, "release", "released"
+	if commit {
+		settle, action, done = c.inventory.Commit, "commit", "committed"
+	}
+	for _, item := range items {
+		line := ReservationLine{OrderID: orderID, Line: item.Line, ProductID: item.ProductID.String(), Quantity: item.Quantity}
+		if err := settle(ctx, line); err != nil {
+			log.Printf("❌ [OrderService][SQSPaymentConsumer] failed to %s stock for order=%s line=%d: %v", action, orderID, item.Line, err)
+			return err
+		}
+	}
+	if len(items) > 0 {
+		log.Printf("✅ [OrderService][SQSPaymentConsumer] stock %s for order=%s lines=%d", done, orderID, len(items))
+	}
+	return nil
+}
+
+// sendConfirmation publishes order_confirmed for a paid order exactly once.
+// The order is marked only after SNS accepts the event, so a crash in between
+// can repeat the event but never lose it.
+func (c *SQSPaymentConsumer) sendConfirmation(ctx context.Context, orderID string) error {
+	if c.snsClient == nil || c.snsTopicArn == "" {
+		log.Printf("⚠️  [OrderService][SQSPaymentConsumer] notification topic not configured; no confirmation for order=%s", orderID)
+		return nil
+	}
+
+	order, err := c.confirmations.PendingConfirmation(ctx, orderID)
+	if err != nil {
+		return err
+	}
+	if order == nil {
+		log.Printf("ℹ️  [OrderService][SQSPaymentConsumer] order=%s not awaiting confirmation; skipping", orderID)
+		return nil
+	}
+
+	now := time.Now().UTC()
+	evt := models.OrderConfirmedEvent{
+		Type:        "order_confirmed",
+		OrderID:     order.ID.String(),
+		OrderNumber: order.OrderNumber,
+		UserID:      order.UserID.String(),
+		Email:       order.CustomerEmail,
+		Amount:      order.Amount,
+		Items:       make([]models.OrderConfirmedItem, 0, len(order.OrderItems)),
+		ConfirmedAt: now,
+	}
+	for _, item := range order.OrderItems {
+		evt.Items = append(evt.Items, models.OrderConfirmedItem{
+			ProductID: item.ProductID.String(),
+			Quantity:  item.Quantity,
+			Price:     item.Price,
+		})
+	}
+
+	payload, err := json.Marshal(evt)
+	if err != nil {
+		return err
+	}
+	if err := c.snsClient.Publish(ctx, c.snsTopicArn, payload); err != nil {
+		log.Printf("❌ [OrderService][SQSPaymentConsumer] failed to publish confirmation for order=%s: %v", orderID, err)
+		return err
+	}
+	if err := c.confirmations.MarkConfirmationSent(ctx, orderID, now); err != nil {
+		return err
+	}
+	log.Printf("✅ [OrderService][SQSPaymentConsumer] confirmation published for order=%s", orderID)
+	return nil
+}
+
+// GormConfirmationStore is the Postgres-backed ConfirmationStore,
+// CartClearStore and ReservedItemStore.
+type GormConfirmationStore struct {
+	db *gorm.DB
+}
+
+func NewGormConfirmationStore(db *gorm.DB) *GormConfirmationStore {
+	return &GormConfirmationStore{db: db}
+}
+
+func (s *GormConfirmationStore) PendingConfirmation(ctx context.Context, orderID string) (*models.Order, error) {
+	var order models.Order
+	err := s.db.WithContext(ctx).Preload("OrderItems").
+		Where("id = ? AND status = ? AND confirmation_sent_at IS NULL", orderID, "paid").
+		First(&order).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &order, nil
+}
+
+func (s *GormConfirmationStore) MarkConfirmationSent(ctx context.Context, orderID string, at time.Time) error {
+	return s.db.WithContext(ctx).Model(&models.Order{}).
+		Where("id = ? AND confirmation_sent_at IS NULL", orderID).
+		Update("confirmation_sent_at", at).Error
+}
+
+func (s *GormConfirmationStore) ClaimCartClear(ctx context.Context, orderID string, at time.Time) (bool, error) {
+	res := s.db.WithContext(ctx).Model(&models.Order{}).
+		Where("id = ? AND status = ? AND cart_cleared_at IS NULL", orderID, "paid").
+		Update("cart_cleared_at", at)
+	if res.Error != nil {
+		return false, res.Error
+	}
+	return res.RowsAffected == 1, nil
+}
+
+func (s *GormConfirmationStore) UnclaimCartClear(ctx context.Context, orderID string) error {
+	return s.db.WithContext(ctx).Model(&models.Order{}).
+		Where("id = ?", orderID).
+		Update("cart_cleared_at", nil).Error
+}
+
+func (s *GormConfirmationStore) ReservedItems(ctx context.Context, orderID string) ([]models.OrderItem, error) {
+	var items []models.OrderItem
+	err := s.db.WithContext(ctx).
+		Where("order_id = ? AND fulfillment_status = ?", orderID, models.FulfillmentReserved).
+		Order("line").
+		Find(&items).Error
+	return items, err
+}
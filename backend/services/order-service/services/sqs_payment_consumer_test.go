@@ -0,0 +1,246 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"order-service/models"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// fakeConfirmationStore holds one paid order.
+type fakeConfirmationStore struct {
+	order  models.Order
+	sentAt *time.Time
+}
+
+func (s *fakeConfirmationStore) PendingConfirmation(ctx context.Context, orderID string) (*models.Order, error) {
+	if s.order.ID.String() != orderID || s.order.Status != "paid" || s.sentAt != nil {
+		return nil, nil
+	}
+	o := s.order
+	return &o, nil
+}
+
+func (s *fakeConfirmationStore) MarkConfirmationSent(ctx context.Context, orderID string, at time.Time) error {
+	s.sentAt = &at
+	return nil
+}
+
+func paidOrderStore() *fakeConfirmationStore {
+	orderID := uuid.New()
+	return &fakeConfirmationStore{order: models.Order{
+		ID:            orderID,
+		OrderNumber:   "ORD-1",
+		UserID:        uuid.New(),
+		Amount:        2500,
+		Status:        "paid",
+		CustomerEmail: "buyer@example.com",
+		OrderItems:    []models.OrderItem{{OrderID: orderID, ProductID: uuid.New(), Quantity: 2, Price: 1250}},
+	}}
+}
+
+// countingSNS records every published message.
+type countingSNS struct {
+	msgs [][]byte
+}
+
+func (s *countingSNS) Publish(ctx context.Context, topicArn string, message []byte) error {
+	s.msgs = append(s.msgs, message)
+	return nil
+}
+
+func TestPaymentConsumer_PaidOrderConfirmedOnce(t *testing.T) {
+	store := paidOrderStore()
+	sns := &countingSNS{}
+	c := &SQSPaymentConsumer{confirmations: store, snsClient: sns, snsTopicArn: "arn:notifications"}
+	orderID := store.order.ID.String()
+
+	// The second call stands in for a redelivered payment_succeeded message
+	for i := 0; i < 2; i++ {
+		if err := c.sendConfirmation(context.Background(), orderID); err != nil {
+			t.Fatalf("sendConfirmation: %v", err)
+		}
+	}
+	if len(sns.msgs) != 1 {
+		t.Fatalf("expected exactly one confirmation, got %d", len(sns.msgs))
+	}
+
+	var evt models.OrderConfirmedEvent
+	if err := json.Unmarshal(sns.msgs[0], &evt); err != nil {
+		t.Fatalf("decode event: %v", err)
+	}
+	if evt.Type != "order_confirmed" || evt.OrderID != orderID || evt.Email != "buyer@example.com" ||
+		evt.Amount != 2500 || len(evt.Items) != 1 || evt.Items[0].Quantity != 2 {
+		t.Fatalf("unexpected event %+v", evt)
+	}
+}
+
+func TestPaymentConsumer_FailedPublishIsRetried(t *testing.T) {
+	store := paidOrderStore()
+	sns := &mockSNS{err: errors.New("sns down")}
+	c := &SQSPaymentConsumer{confirmations: store, snsClient: sns, snsTopicArn: "arn:notifications"}
+
+	if err := c.sendConfirmation(context.Background(), store.order.ID.String()); err == nil {
+		t.Fatal("expected publish error to be returned so the message is redelivered")
+	}
+	if store.sentAt != nil {
+		t.Fatal("order must not be marked confirmed when publishing failed")
+	}
+
+	sns.err = nil
+	if err := c.sendConfirmation(context.Background(), store.order.ID.String()); err != nil {
+		t.Fatalf("retry: %v", err)
+	}
+	if store.sentAt == nil || sns.publishedMsg == nil {
+		t.Fatal("expected the retry to publish and mark the confirmation")
+	}
+}
+
+func TestPaymentConsumer_UnpaidOrderNotConfirmed(t *testing.T) {
+	store := paidOrderStore()
+	store.order.Status = "payment_failed"
+	sns := &mockSNS{}
+	c := &SQSPaymentConsumer{confirmations: store, snsClient: sns, snsTopicArn: "arn:notifications"}
+
+	if err := c.sendConfirmation(context.Background(), store.order.ID.String()); err != nil {
+		t.Fatalf("sendConfirmation: %v", err)
+	}
+	if sns.publishedMsg != nil {
+		t.Fatal("unpaid order must not be confirmed")
+	}
+}
+
+// recordingCarts records the users whose carts were cleared.
+type recordingCarts struct {
+	cleared []string
+	err     error
+}
+
+func (r *recordingCarts) ClearCart(ctx context.Context, userID string) error {
+	r.cleared = append(r.cleared, userID)
+	return r.err
+}
+
+// fakeCartClearStore remembers which orders have had their cart cleared.
+type fakeCartClearStore map[string]bool
+
+func (s fakeCartClearStore) ClaimCartClear(ctx context.Context, orderID string, at time.Time) (bool, error) {
+	if s[orderID] {
+		return false, nil
+	}
+	s[orderID] = true
+	return true, nil
+}
+
+func (s fakeCartClearStore) UnclaimCartClear(ctx context.Context, orderID string) error {
+	delete(s, orderID)
+	return nil
+}
+
+func TestPaymentConsumer_PaidOrderClearsCart(t *testing.T) {
+	carts := &recordingCarts{}
+	c := &SQSPaymentConsumer{carts: carts, cartClears: fakeCartClearStore{}}
+
+	c.clearCart(context.Background(), models.PaymentEvent{Type: "payment_succeeded", OrderID: "order-1", UserID: "user-1"})
+	if len(carts.cleared) != 1 || carts.cleared[0] != "user-1" {
+		t.Fatalf("expected user-1's cart to be cleared, got %v", carts.cleared)
+	}
+}
+
+func TestPaymentConsumer_RedeliveryDoesNotClearCartAgain(t *testing.T) {
+	carts := &recordingCarts{}
+	c := &SQSPaymentConsumer{carts: carts, cartClears: fakeCartClearStore{}}
+	evt := models.PaymentEvent{Type: "payment_succeeded", OrderID: "order-1", UserID: "user-1"}
+
+	// The second call stands in for a redelivery after a failed confirmation
+	c.clearCart(context.Background(), evt)
+	c.clearCart(context.Background(), evt)
+	if len(carts.cleared) != 1 {
+		t.Fatalf("expected the cart to be cleared once, got %d clears", len(carts.cleared))
+	}
+}
+
+func TestPaymentConsumer_FailedCartClearIsRetriedOnRedelivery(t *testing.T) {
+	carts := &recordingCarts{err: errors.New("cart service unavailable")}
+	store := fakeCartClearStore{}
+	c := &SQSPaymentConsumer{carts: carts, cartClears: store}
+	evt := models.PaymentEvent{Type: "payment_succeeded", OrderID: "order-1", UserID: "user-1"}
+
+	c.clearCart(context.Background(), evt)
+	if store["order-1"] {
+		t.Fatal("expected a failed clear to leave the order unmarked")
+	}
+
+	carts.err = nil
+	c.clearCart(context.Background(), evt)
+	if len(carts.cleared) != 2 || !store["order-1"] {
+		t.Fatalf("expected the redelivery to clear the cart and mark the order, got %d clears, marked=%v", len(carts.cleared), store["order-1"])
+	}
+}
+
+func TestPaymentConsumer_ClearCartWithoutUserIsSkipped(t *testing.T) {
+	carts := &recordingCarts{}
+	c := &SQSPaymentConsumer{carts: carts, cartClears: fakeCartClearStore{}}
+
+	c.clearCart(context.Background(), models.PaymentEvent{Type: "payment_succeeded", OrderID: "order-1"})
+	if len(carts.cleared) != 0 {
+		t.Fatalf("expected no cart to be cleared, got %v", carts.cleared)
+	}
+}
+
+// fakeReservedItems serves a fixed set of reserved items.
+type fakeReservedItems struct {
+	items []models.OrderItem
+}
+
+func (f fakeReservedItems) ReservedItems(ctx context.Context, orderID string) ([]models.OrderItem, error) {
+	return f.items, nil
+}
+
+func reservedItems() fakeReservedItems {
+	pid := uuid.New()
+	return fakeReservedItems{items: []models.OrderItem{
+		{ProductID: pid, Quantity: 2, Line: 0},
+		{ProductID: pid, Quantity: 1, Line: 2},
+	}}
+}
+
+func TestPaymentConsumer_PaidOrderCommitsStock(t *testing.T) {
+	inventory := &fakeInventory{}
+	c := &SQSPaymentConsumer{inventory: inventory, reservedItems: reservedItems()}
+
+	if err := c.settleInventory(context.Background(), "order-1", true); err != nil {
+		t.Fatalf("settleInventory: %v", err)
+	}
+	if len(inventory.committed) != 2 || len(inventory.released) != 0 {
+		t.Fatalf("expected both lines committed, got committed=%v released=%v", inventory.committed, inventory.released)
+	}
+	if got := inventory.committed[1]; got.OrderID != "order-1" || got.Line != 2 || got.Quantity != 1 {
+		t.Fatalf("unexpected line %+v", got)
+	}
+}
+
+func TestPaymentConsumer_FailedPaymentReleasesStock(t *testing.T) {
+	inventory := &fakeInventory{}
+	c := &SQSPaymentConsumer{inventory: inventory, reservedItems: reservedItems()}
+
+	if err := c.settleInventory(context.Background(), "order-1", false); err != nil {
+		t.Fatalf("settleInventory: %v", err)
+	}
+	if len(inventory.released) != 2 || len(inventory.committed) != 0 {
+		t.Fatalf("expected both lines released, got committed=%v released=%v", inventory.committed, inventory.released)
+	}
+}
+
+func TestPaymentConsumer_SettleErrorIsRetried(t *testing.T) {
+	inventory := &fakeInventory{err: errors.New("inventory service returned 503")}
+	c := &SQSPaymentConsumer{inventory: inventory, reservedItems: reservedItems()}
+
+	if err := c.settleInventory(context.Background(), "order-1", false); err == nil {
+		t.Fatal("expected the error to be returned so the message is redelivered")
+	}
+}
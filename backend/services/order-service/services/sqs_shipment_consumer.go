@@ -0,0 +1,176 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"order-service/models"
+	"strings"
+
+	"github.com/google/uuid"
+	aws_pkg "github.com/yashrajoria/E-Commerce-backend/backend/pkg/aws"
+	"gorm.io/gorm"
+)
+
+// shipmentOrderStatus maps shipment statuses to the order status they imply.
+// Statuses not listed here (e.g. "exception", "returned") leave the order alone.
+var shipmentOrderStatus = map[string]string{
+	"created":          "shipped",
+	"label_created":    "shipped",
+	"in_transit":       "shipped",
+	"out_for_delivery": "shipped",
+	"delivered":        "delivered",
+}
+
+// orderStatusRank orders the fulfilment statuses so a late or redelivered
+// event can never move an order backwards.
+var orderStatusRank = map[string]int{
+	"shipped":   1,
+	"delivered": 2,
+}
+
+// OrderStatusStore is the persistence the shipment consumer needs.
+type OrderStatusStore interface {
+	GetStatus(ctx context.Context, orderID string) (string, error)
+	// AdvanceStatus sets the order status and appends a timeline entry keyed
+	// by reference. It returns false without changing anything if an entry
+	// with that reference already exists.
+	AdvanceStatus(ctx context.Context, orderID, status, reference string) (bool, error)
+}
+
+// SQSShipmentConsumer consumes shipment events from SQS and moves orders to
+// shipped/delivered
+type SQSShipmentConsumer struct {
+	sqsConsumer *aws_pkg.SQSConsumer
+	store       OrderStatusStore
+}
+
+// NewSQSShipmentConsumer creates a new SQS-based shipment event consumer
+func NewSQSShipmentConsumer(sqsConsumer *aws_pkg.SQSConsumer, store OrderStatusStore) *SQSShipmentConsumer {
+	return &SQSShipmentConsumer{
+		sqsConsumer: sqsConsumer,
+		store:       store,
+	}
+}
+
+// Start begins polling the shipment events queue
+func (c *SQSShipmentConsumer) Start(ctx context.Context) {
+	log.Println("[OrderService][SQSShipmentConsumer] Starting shipment events queue consumer")
+
+	err := c.sqsConsumer.StartPolling(ctx, func(ctx context.Context, body string) error {
+		return c.handleMessage(ctx, body)
+	})
+	if err != nil && err != context.Canceled {
+		log.Printf("❌ [OrderService][SQSShipmentConsumer] polling error: %v", err)
+	}
+}
+
+func (c *SQSShipmentConsumer) handleMessage(ctx context.Context, body string) error {
+	// Try to unwrap SNS envelope if present
+	var snsEnvelope struct {
+		Message string `json:"Message"`
+	}
+	if err := json.Unmarshal([]byte(body), &snsEnvelope); err == nil && snsEnvelope.Message != "" {
+		body = snsEnvelope.Message
+	}
+
+	var evt models.ShipmentEvent
+	if err := json.Unmarshal([]byte(body), &evt); err != nil {
+		log.Printf("❌ [OrderService][SQSShipmentConsumer] invalid JSON: %v payload=%s", err, body)
+		return nil // Don't retry invalid JSON
+	}
+
+	if evt.OrderID == "" || evt.Type == "" {
+		log.Printf("❌ [OrderService][SQSShipmentConsumer] missing fields: order_id=%q type=%q", evt.OrderID, evt.Type)
+		return nil
+	}
+	if evt.Type != "shipment_created" && evt.Type != "shipment_updated" {
+		log.Printf("⚠️  [OrderService][SQSShipmentConsumer] unknown event type: %s", evt.Type)
+		return nil
+	}
+
+	shipmentStatus := strings.ToLower(evt.Status)
+	if shipmentStatus == "" && evt.Type == "shipment_created" {
+		shipmentStatus = "created"
+	}
+	target, ok := shipmentOrderStatus[shipmentStatus]
+	if !ok {
+		log.Printf("ℹ️  [OrderService][SQSShipmentConsumer] order=%s shipment status %q does not change order status", evt.OrderID, evt.Status)
+		return nil
+	}
+
+	current, err := c.store.GetStatus(ctx, evt.OrderID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			log.Printf("❌ [OrderService][SQSShipmentConsumer] order=%s not found", evt.OrderID)
+			return nil
+		}
+		return err
+	}
+	if orderStatusRank[current] >= orderStatusRank[target] {
+		log.Printf("ℹ️  [OrderService][SQSShipmentConsumer] order=%s already %s; skipping %s", evt.OrderID, current, target)
+		return nil
+	}
+
+	// One timeline entry per shipment status, so redelivery is a no-op
+	reference := fmt.Sprintf("shipment:%s:%s:%s", evt.OrderID, evt.ShipmentID, shipmentStatus)
+	applied, err := c.store.AdvanceStatus(ctx, evt.OrderID, target, reference)
+	if err != nil {
+		log.Printf("❌ [OrderService][SQSShipmentConsumer] failed to update order=%s: %v", evt.OrderID, err)
+		return err
+	}
+	if !applied {
+		log.Printf("ℹ️  [OrderService][SQSShipmentConsumer] order=%s event %s already applied; skipping", evt.OrderID, reference)
+		return nil
+	}
+	log.Printf("✅ [OrderService][SQSShipmentConsumer] order=%s updated to %s", evt.OrderID, target)
+	return nil
+}
+
+// GormOrderStatusStore implements OrderStatusStore using GORM
+type GormOrderStatusStore struct {
+	db *gorm.DB
+}
+
+// NewGormOrderStatusStore creates a new instance of GormOrderStatusStore
+func NewGormOrderStatusStore(db *gorm.DB) *GormOrderStatusStore {
+	return &GormOrderStatusStore{db: db}
+}
+
+func (s *GormOrderStatusStore) GetStatus(ctx context.Context, orderID string) (string, error) {
+	var order models.Order
+	if err := s.db.WithContext(ctx).Select("status").First(&order, "id = ?", orderID).Error; err != nil {
+		return "", err
+	}
+	return order.Status, nil
+}
+
+func (s *GormOrderStatusStore) AdvanceStatus(ctx context.Context, orderID, status, reference string) (bool, error) {
+	id, err := uuid.Parse(orderID)
+	if err != nil {
+		return false, err
+	}
+
+	applied := false
+	err = s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var count int64
+		if err := tx.Model(&models.OrderStatusHistory{}).Where("reference = ?", reference).Count(&count).Error; err != nil {
+			return err
+		}
+		if count > 0 {
+			return nil
+		}
+		entry := models.OrderStatusHistory{OrderID: id, Status: status, Reference: reference}
+		if err := tx.Create(&entry).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(&models.Order{}).Where("id = ?", id).Update("status", status).Error; err != nil {
+			return err
+		}
+		applied = true
+		return nil
+	})
+	return applied, err
+}
@@ -0,0 +1,106 @@
+package services
+
+import (
+	"context"
+	"testing"
+)
+
+type fakeOrderStatusStore struct {
+	statuses   map[string]string
+	references map[string]bool
+	timeline   []string
+}
+
+func newFakeOrderStatusStore(orderID, status string) *fakeOrderStatusStore {
+	return &fakeOrderStatusStore{
+		statuses:   map[string]string{orderID: status},
+		references: map[string]bool{},
+	}
+}
+
+func (s *fakeOrderStatusStore) GetStatus(ctx context.Context, orderID string) (string, error) {
+	return s.statuses[orderID], nil
+}
+
+func (s *fakeOrderStatusStore) AdvanceStatus(ctx context.Context, orderID, status, reference string) (bool, error) {
+	if s.references[reference] {
+		return false, nil
+	}
+	s.references[reference] = true
+	s.statuses[orderID] = status
+	s.timeline = append(s.timeline, status)
+	return true, nil
+}
+
+const shipmentTestOrderID = "6f1c1f3e-5d2a-4c55-9a39-2b8f7a2f0c11"
+
+func TestShipmentConsumer_CreatedMarksOrderShipped(t *testing.T) {
+	store := newFakeOrderStatusStore(shipmentTestOrderID, "paid")
+	c := NewSQSShipmentConsumer(nil, store)
+
+	body := `{"type":"shipment_created","shipment_id":"shp_1","order_id":"` + shipmentTestOrderID + `","status":"created"}`
+	if err := c.handleMessage(context.Background(), body); err != nil {
+		t.Fatalf("handleMessage returned error: %v", err)
+	}
+
+	if got := store.statuses[shipmentTestOrderID]; got != "shipped" {
+		t.Fatalf("expected order to be shipped, got %q", got)
+	}
+	if len(store.timeline) != 1 || store.timeline[0] != "shipped" {
+		t.Fatalf("expected one shipped timeline entry, got %v", store.timeline)
+	}
+}
+
+func TestShipmentConsumer_DeliveredMarksOrderDelivered(t *testing.T) {
+	store := newFakeOrderStatusStore(shipmentTestOrderID, "shipped")
+	c := NewSQSShipmentConsumer(nil, store)
+
+	// Wrapped in an SNS envelope, as delivered by an SNS→SQS subscription
+	body := `{"Type":"Notification","Message":"{\"type\":\"shipment_updated\",\"shipment_id\":\"shp_1\",\"order_id\":\"` + shipmentTestOrderID + `\",\"status\":\"DELIVERED\"}"}`
+	if err := c.handleMessage(context.Background(), body); err != nil {
+		t.Fatalf("handleMessage returned error: %v", err)
+	}
+
+	if got := store.statuses[shipmentTestOrderID]; got != "delivered" {
+		t.Fatalf("expected order to be delivered, got %q", got)
+	}
+}
+
+func TestShipmentConsumer_IdempotentAndNeverRegresses(t *testing.T) {
+	store := newFakeOrderStatusStore(shipmentTestOrderID, "paid")
+	c := NewSQSShipmentConsumer(nil, store)
+
+	msgs := []string{
+		`{"type":"shipment_created","shipment_id":"shp_1","order_id":"` + shipmentTestOrderID + `","status":"created"}`,
+		`{"type":"shipment_updated","shipment_id":"shp_1","order_id":"` + shipmentTestOrderID + `","status":"in_transit"}`,
+		`{"type":"shipment_updated","shipment_id":"shp_1","order_id":"` + shipmentTestOrderID + `","status":"delivered"}`,
+		// redelivered and out-of-order events
+		`{"type":"shipment_updated","shipment_id":"shp_1","order_id":"` + shipmentTestOrderID + `","status":"delivered"}`,
+		`{"type":"shipment_updated","shipment_id":"shp_1","order_id":"` + shipmentTestOrderID + `","status":"in_transit"}`,
+	}
+	for _, m := range msgs {
+		if err := c.handleMessage(context.Background(), m); err != nil {
+			t.Fatalf("handleMessage returned error: %v", err)
+		}
+	}
+
+	if got := store.statuses[shipmentTestOrderID]; got != "delivered" {
+		t.Fatalf("expected order to stay delivered, got %q", got)
+	}
+	if len(store.timeline) != 2 || store.timeline[0] != "shipped" || store.timeline[1] != "delivered" {
+		t.Fatalf("expected timeline [shipped delivered], got %v", store.timeline)
+	}
+}
+
+func TestShipmentConsumer_UnmappedStatusIgnored(t *testing.T) {
+	store := newFakeOrderStatusStore(shipmentTestOrderID, "paid")
+	c := NewSQSShipmentConsumer(nil, store)
+
+	body := `{"type":"shipment_updated","shipment_id":"shp_1","order_id":"` + shipmentTestOrderID + `","status":"exception"}`
+	if err := c.handleMessage(context.Background(), body); err != nil {
+		t.Fatalf("handleMessage returned error: %v", err)
+	}
+	if got := store.statuses[shipmentTestOrderID]; got != "paid" {
+		t.Fatalf("expected order status unchanged, got %q", got)
+	}
+}
@@ -0,0 +1,40 @@
+package services
+
+import (
+	"context"
+	"math"
+	"order-service/models"
+)
+
+// TaxCalculator works out the tax owed on an order from its items and
+// subtotal. Region-aware implementations can key off the order's user or
+// currency; the default is FlatRateTax.
+type TaxCalculator interface {
+	CalculateTax(ctx context.Context, order *models.Order) (int, error)
+}
+
+// FlatRateTax charges one rate on every order, e.g. 0.18 for 18%. A zero
+// rate charges no tax.
+type FlatRateTax struct {
+	Rate float64
+}
+
+func (t FlatRateTax) CalculateTax(ctx context.Context, order *models.Order) (int, error) {
+	return int(math.Round(float64(order.Subtotal) * t.Rate)), nil
+}
+
+// applyTax sets Tax and Total from the order's Subtotal. Amount mirrors
+// Total because payment-service charges Amount. A nil calculator means no tax.
+func applyTax(ctx context.Context, calc TaxCalculator, order *models.Order) error {
+	tax := 0
+	if calc != nil {
+		var err error
+		if tax, err = calc.CalculateTax(ctx, order); err != nil {
+			return err
+		}
+	}
+	order.Tax = tax
+	order.Total = order.Subtotal + tax
+	order.Amount = order.Total
+	return nil
+}
@@ -0,0 +1,50 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"order-service/models"
+	"testing"
+)
+
+func TestApplyTax_ZeroTax(t *testing.T) {
+	for name, calc := range map[string]TaxCalculator{
+		"no calculator": nil,
+		"zero rate":     FlatRateTax{Rate: 0},
+	} {
+		order := &models.Order{Subtotal: 2500}
+		if err := applyTax(context.Background(), calc, order); err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		if order.Tax != 0 || order.Total != 2500 || order.Amount != 2500 {
+			t.Fatalf("%s: expected untaxed total 2500, got tax=%d total=%d amount=%d", name, order.Tax, order.Total, order.Amount)
+		}
+	}
+}
+
+func TestApplyTax_FlatRate(t *testing.T) {
+	order := &models.Order{Subtotal: 1999}
+	if err := applyTax(context.Background(), FlatRateTax{Rate: 0.18}, order); err != nil {
+		t.Fatal(err)
+	}
+	// 1999 * 0.18 = 359.82, rounded to 360
+	if order.Subtotal != 1999 || order.Tax != 360 || order.Total != 2359 || order.Amount != 2359 {
+		t.Fatalf("unexpected breakdown subtotal=%d tax=%d total=%d amount=%d", order.Subtotal, order.Tax, order.Total, order.Amount)
+	}
+}
+
+type failingTax struct{}
+
+func (failingTax) CalculateTax(ctx context.Context, order *models.Order) (int, error) {
+	return 0, errors.New("tax service unavailable")
+}
+
+func TestApplyTax_CalculatorError(t *testing.T) {
+	order := &models.Order{Subtotal: 1000}
+	if err := applyTax(context.Background(), failingTax{}, order); err == nil {
+		t.Fatal("expected calculator error to be returned")
+	}
+	if order.Total != 0 {
+		t.Fatalf("order must not be priced when tax fails, got total=%d", order.Total)
+	}
+}
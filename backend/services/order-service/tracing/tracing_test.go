@@ -0,0 +1,62 @@
+package tracing
+
+import (
+	"context"
+	"testing"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func useTraceContext(t *testing.T) {
+	t.Helper()
+	prev := otel.GetTextMapPropagator()
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	t.Cleanup(func() { otel.SetTextMapPropagator(prev) })
+}
+
+func TestExtract_SpanContextFromEventHeader(t *testing.T) {
+	useTraceContext(t)
+
+	headers := map[string]string{
+		"traceparent": "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01",
+	}
+	sc := trace.SpanContextFromContext(Extract(context.Background(), headers))
+
+	if !sc.IsValid() || !sc.IsRemote() {
+		t.Fatalf("expected a valid remote span context, got %+v", sc)
+	}
+	if sc.TraceID().String() != "4bf92f3577b34da6a3ce929d0e0e4736" {
+		t.Fatalf("unexpected trace id %s", sc.TraceID())
+	}
+	if sc.SpanID().String() != "00f067aa0ba902b7" || !sc.IsSampled() {
+		t.Fatalf("unexpected parent span %s sampled=%v", sc.SpanID(), sc.IsSampled())
+	}
+}
+
+func TestExtract_NoHeadersKeepsContext(t *testing.T) {
+	useTraceContext(t)
+
+	if sc := trace.SpanContextFromContext(Extract(context.Background(), nil)); sc.IsValid() {
+		t.Fatalf("expected no span context, got %+v", sc)
+	}
+}
+
+func TestInjectExtract_ChildSpanJoinsTrace(t *testing.T) {
+	useTraceContext(t)
+	tp := sdktrace.NewTracerProvider()
+	defer tp.Shutdown(context.Background())
+
+	ctx, parent := tp.Tracer("test").Start(context.Background(), "checkout.publish")
+	headers := Inject(ctx)
+	parent.End()
+
+	_, child := tp.Tracer("test").Start(Extract(context.Background(), headers), "order.create")
+	defer child.End()
+
+	if child.SpanContext().TraceID() != parent.SpanContext().TraceID() {
+		t.Fatalf("child span is not part of the parent trace")
+	}
+}
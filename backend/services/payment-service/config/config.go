@@ -2,7 +2,10 @@ package config
 
 import (
 	"fmt"
+	"net/url"
 	"os"
+	"strings"
+	"time"
 )
 
 type Config struct {
@@ -18,6 +21,11 @@ type Config struct {
 	StripeWebhookKey       string
 	PaymentRequestQueueURL string // SQS queue URL for payment requests
 	PaymentSNSTopicARN     string // SNS topic ARN for payment events
+	OrderServiceURL        string // used to verify payment amounts against orders
+	RequestTimeout         time.Duration
+	// CheckoutRedirectHosts are the hosts a caller may pass as Stripe
+	// success/cancel URLs. Subdomains of a listed host are allowed too.
+	CheckoutRedirectHosts []string
 }
 
 func LoadConfig() (*Config, error) {
@@ -34,6 +42,19 @@ func LoadConfig() (*Config, error) {
 		StripeWebhookKey:       os.Getenv("STRIPE_WEBHOOK_SECRET"),
 		PaymentRequestQueueURL: os.Getenv("PAYMENT_REQUEST_QUEUE_URL"),
 		PaymentSNSTopicARN:     getEnv("PAYMENT_SNS_TOPIC_ARN", "arn:aws:sns:eu-west-2:000000000000:payment-events"),
+		OrderServiceURL:        getEnv("ORDER_SERVICE_URL", "http://order-service:8083"),
+	}
+
+	timeout, err := time.ParseDuration(getEnv("REQUEST_TIMEOUT", "30s"))
+	if err != nil || timeout <= 0 {
+		return nil, fmt.Errorf("invalid REQUEST_TIMEOUT: %q", os.Getenv("REQUEST_TIMEOUT"))
+	}
+	cfg.RequestTimeout = timeout
+
+	for _, host := range strings.Split(getEnv("CHECKOUT_REDIRECT_ALLOWED_HOSTS", frontendHost()), ",") {
+		if host = strings.TrimSpace(host); host != "" {
+			cfg.CheckoutRedirectHosts = append(cfg.CheckoutRedirectHosts, host)
+		}
 	}
 
 	if cfg.PostgresUser == "" || cfg.PostgresPassword == "" || cfg.PostgresDB == "" || cfg.PostgresHost == "" ||
@@ -50,3 +71,12 @@ func getEnv(key, fallback string) string {
 	}
 	return fallback
 }
+
+// frontendHost is the default redirect allow-list: the host of FRONTEND_URL.
+func frontendHost() string {
+	u, err := url.Parse(getEnv("FRONTEND_URL", "http://localhost:3000"))
+	if err != nil || u.Hostname() == "" {
+		return "localhost"
+	}
+	return u.Hostname()
+}
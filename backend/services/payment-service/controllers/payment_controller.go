@@ -3,6 +3,7 @@ package controllers
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"net/http"
 	"os"
 	"strings"
@@ -19,6 +20,7 @@ import (
 	"github.com/stripe/stripe-go/v80"
 	"github.com/stripe/stripe-go/v80/checkout/session"
 	aws_pkg "github.com/yashrajoria/E-Commerce-backend/backend/pkg/aws"
+	"github.com/yashrajoria/common/httputil"
 	"go.uber.org/zap"
 	"gorm.io/gorm"
 )
@@ -29,6 +31,8 @@ type PaymentController struct {
 	TopicArn string
 	Logger   *zap.Logger
 	Repo     repository.PaymentRepository
+	// AllowedRedirectHosts limits caller-supplied success/cancel URLs
+	AllowedRedirectHosts []string
 }
 
 // GetPaymentStatusByOrderID is the polling endpoint for the frontend
@@ -39,7 +43,7 @@ func (pc *PaymentController) GetPaymentStatusByOrderID(c *gin.Context) {
 	orderID, err := uuid.Parse(orderIDStr)
 	if err != nil {
 		pc.Logger.Warn("Invalid Order ID format", zap.String("order_id", orderIDStr), zap.Error(err))
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid order ID format"})
+		httputil.RespondError(c, http.StatusBadRequest, "invalid order ID format")
 		return
 	}
 
@@ -58,7 +62,7 @@ func (pc *PaymentController) GetPaymentStatusByOrderID(c *gin.Context) {
 		}
 		// This is a real database error
 		pc.Logger.Error("Error fetching payment by order_id", zap.String("order_id", orderIDStr), zap.Error(err))
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "database error"})
+		httputil.RespondError(c, http.StatusInternalServerError, "database error")
 		return
 	}
 
@@ -79,19 +83,32 @@ func (pc *PaymentController) GetPaymentStatusByOrderID(c *gin.Context) {
 // CreateCheckoutSession creates a Stripe Checkout Session and stores the URL in DB
 func (pc *PaymentController) CreateCheckoutSession(c *gin.Context) {
 	var req struct {
-		OrderID string `json:"order_id" binding:"required"`
+		OrderID    string `json:"order_id" binding:"required"`
+		SuccessURL string `json:"success_url"`
+		CancelURL  string `json:"cancel_url"`
 	}
 
 	if err := c.ShouldBindJSON(&req); err != nil {
 		pc.Logger.Warn("Invalid request body", zap.Error(err))
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		httputil.RespondError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	successURL, cancelURL, err := pc.checkoutRedirectURLs(req.SuccessURL, req.CancelURL)
+	if err != nil {
+		pc.Logger.Warn("Rejected checkout redirect URL",
+			zap.String("order_id", req.OrderID),
+			zap.String("success_url", req.SuccessURL),
+			zap.String("cancel_url", req.CancelURL),
+		)
+		httputil.RespondError(c, http.StatusBadRequest, err.Error())
 		return
 	}
 
 	orderUUID, err := uuid.Parse(req.OrderID)
 	if err != nil {
 		pc.Logger.Warn("Invalid order ID format", zap.String("order_id", req.OrderID), zap.Error(err))
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid order ID format"})
+		httputil.RespondError(c, http.StatusBadRequest, "invalid order ID format")
 		return
 	}
 
@@ -100,11 +117,11 @@ func (pc *PaymentController) CreateCheckoutSession(c *gin.Context) {
 	if err != nil {
 		if err == gorm.ErrRecordNotFound {
 			pc.Logger.Warn("Payment record not found for order", zap.String("order_id", req.OrderID))
-			c.JSON(http.StatusNotFound, gin.H{"error": "payment record not found"})
+			httputil.RespondError(c, http.StatusNotFound, "payment record not found")
 			return
 		}
 		pc.Logger.Error("Error fetching payment by order_id", zap.Error(err))
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "database error"})
+		httputil.RespondError(c, http.StatusInternalServerError, "database error")
 		return
 	}
 
@@ -112,7 +129,7 @@ func (pc *PaymentController) CreateCheckoutSession(c *gin.Context) {
 	amount := int64(payment.Amount)
 	if amount <= 0 {
 		pc.Logger.Warn("Payment amount is zero or invalid", zap.String("order_id", req.OrderID))
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid payment amount"})
+		httputil.RespondError(c, http.StatusBadRequest, "invalid payment amount")
 		return
 	}
 	currency := payment.Currency
@@ -120,14 +137,6 @@ func (pc *PaymentController) CreateCheckoutSession(c *gin.Context) {
 		currency = "usd"
 	}
 
-	// Build success/cancel URLs from FRONTEND_URL env
-	frontend := os.Getenv("FRONTEND_URL")
-	if frontend == "" {
-		frontend = "http://localhost:3000"
-	}
-	successURL := frontend + "/payment/success?session_id={CHECKOUT_SESSION_ID}"
-	cancelURL := frontend + "/payment/cancel"
-
 	pc.Logger.Info("Creating checkout session (server-populated fields)",
 		zap.String("order_id", req.OrderID),
 		zap.Int64("amount", amount),
@@ -137,6 +146,7 @@ func (pc *PaymentController) CreateCheckoutSession(c *gin.Context) {
 
 	// Create Stripe Checkout Session
 	params := &stripe.CheckoutSessionParams{
+		Params:             stripe.Params{Context: c.Request.Context()},
 		PaymentMethodTypes: stripe.StringSlice([]string{"card"}),
 		LineItems: []*stripe.CheckoutSessionLineItemParams{
 			{
@@ -165,7 +175,7 @@ func (pc *PaymentController) CreateCheckoutSession(c *gin.Context) {
 			zap.String("order_id", req.OrderID),
 			zap.Error(err),
 		)
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create checkout session"})
+		httputil.RespondError(c, http.StatusInternalServerError, "Failed to create checkout session")
 		return
 	}
 
@@ -230,6 +240,28 @@ func (pc *PaymentController) CreateCheckoutSession(c *gin.Context) {
 	})
 }
 
+// checkoutRedirectURLs returns the Stripe success/cancel URLs for a checkout.
+// Caller-supplied URLs must be on the allow-list; omitted ones default to
+// FRONTEND_URL.
+func (pc *PaymentController) checkoutRedirectURLs(successURL, cancelURL string) (string, string, error) {
+	frontend := os.Getenv("FRONTEND_URL")
+	if frontend == "" {
+		frontend = "http://localhost:3000"
+	}
+
+	if successURL == "" {
+		successURL = frontend + "/payment/success?session_id={CHECKOUT_SESSION_ID}"
+	} else if err := services.ValidateRedirectURL(successURL, pc.AllowedRedirectHosts); err != nil {
+		return "", "", fmt.Errorf("success_url: %w", err)
+	}
+	if cancelURL == "" {
+		cancelURL = frontend + "/payment/cancel"
+	} else if err := services.ValidateRedirectURL(cancelURL, pc.AllowedRedirectHosts); err != nil {
+		return "", "", fmt.Errorf("cancel_url: %w", err)
+	}
+	return successURL, cancelURL, nil
+}
+
 // Initiates a payment via Stripe PaymentIntent (legacy method - consider deprecating)
 func (pc *PaymentController) InitiatePayment(c *gin.Context) {
 	var req struct {
@@ -239,7 +271,7 @@ func (pc *PaymentController) InitiatePayment(c *gin.Context) {
 	}
 	if err := c.ShouldBindJSON(&req); err != nil {
 		pc.Logger.Warn("Invalid request body", zap.Error(err))
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		httputil.RespondError(c, http.StatusBadRequest, err.Error())
 		return
 	}
 
@@ -251,13 +283,13 @@ func (pc *PaymentController) InitiatePayment(c *gin.Context) {
 	)
 
 	// Create Stripe PaymentIntent
-	pi, err := pc.Stripe.CreatePaymentIntent(int64(req.Amount), strings.ToLower(req.Currency))
+	pi, err := pc.Stripe.CreatePaymentIntent(c.Request.Context(), int64(req.Amount), strings.ToLower(req.Currency))
 	if err != nil {
 		pc.Logger.Error("Failed to create payment intent",
 			zap.String("order_id", req.OrderID),
 			zap.Error(err),
 		)
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		httputil.RespondError(c, http.StatusInternalServerError, err.Error())
 		return
 	}
 
@@ -276,7 +308,7 @@ func (pc *PaymentController) InitiatePayment(c *gin.Context) {
 
 	if err := database.DB.Create(&payment).Error; err != nil {
 		pc.Logger.Error("Failed to save payment", zap.Error(err))
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save payment"})
+		httputil.RespondError(c, http.StatusInternalServerError, "Failed to save payment")
 		return
 	}
 
@@ -298,7 +330,7 @@ func (pc *PaymentController) StripeWebhook(c *gin.Context) {
 			zap.Error(err),
 			zap.String("stripe_signature", c.GetHeader("Stripe-Signature")),
 		)
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid webhook"})
+		httputil.RespondError(c, http.StatusBadRequest, "Invalid webhook")
 		return
 	}
 
@@ -514,7 +546,7 @@ func (pc *PaymentController) VerifyPayment(c *gin.Context) {
 
 	if err := c.ShouldBindJSON(&req); err != nil {
 		pc.Logger.Warn("Invalid request body for verify payment", zap.Error(err))
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		httputil.RespondError(c, http.StatusBadRequest, err.Error())
 		return
 	}
 
@@ -523,13 +555,15 @@ func (pc *PaymentController) VerifyPayment(c *gin.Context) {
 		zap.String("session_id", req.SessionID),
 	)
 
-	sess, err := session.Get(req.SessionID, nil)
+	sess, err := session.Get(req.SessionID, &stripe.CheckoutSessionParams{
+		Params: stripe.Params{Context: c.Request.Context()},
+	})
 	if err != nil {
 		pc.Logger.Error("Error fetching Stripe session",
 			zap.String("session_id", req.SessionID),
 			zap.Error(err),
 		)
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch Stripe session"})
+		httputil.RespondError(c, http.StatusInternalServerError, "failed to fetch Stripe session")
 		return
 	}
 
@@ -0,0 +1,47 @@
+package controllers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+func TestCheckoutRedirectURLs_AllowedAndDefaults(t *testing.T) {
+	t.Setenv("FRONTEND_URL", "https://shop.example.com")
+	pc := &PaymentController{Logger: zap.NewNop(), AllowedRedirectHosts: []string{"example.com"}}
+
+	success, cancel, err := pc.checkoutRedirectURLs("https://m.example.com/done", "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if success != "https://m.example.com/done" {
+		t.Fatalf("expected caller success_url, got %q", success)
+	}
+	if cancel != "https://shop.example.com/payment/cancel" {
+		t.Fatalf("expected default cancel_url, got %q", cancel)
+	}
+}
+
+func TestCreateCheckoutSession_RejectsDisallowedRedirect(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	pc := &PaymentController{Logger: zap.NewNop(), AllowedRedirectHosts: []string{"shop.example.com"}}
+	r := gin.New()
+	r.POST("/payment/create-checkout", pc.CreateCheckoutSession)
+
+	body := `{"order_id":"6f1c3c52-7f0e-4a38-9a5b-0d6c1f1e2a11","cancel_url":"https://evil.com/cancel"}`
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/payment/create-checkout", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	r.ServeHTTP(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400, got %d: %s", w.Code, w.Body.String())
+	}
+	if !strings.Contains(w.Body.String(), "cancel_url") {
+		t.Fatalf("expected error to name cancel_url, got %s", w.Body.String())
+	}
+}
@@ -19,6 +19,7 @@ import (
 
 	"github.com/gin-gonic/gin"
 	aws_pkg "github.com/yashrajoria/E-Commerce-backend/backend/pkg/aws"
+	commonmw "github.com/yashrajoria/common/middleware"
 	"go.uber.org/zap"
 )
 
@@ -81,6 +82,7 @@ func main() {
 		snsPublisher,
 		paymentTopicArn,
 		stripeSvc,
+		services.NewOrderClient(cfg.OrderServiceURL),
 		paymentRepo,
 		logger,
 	)
@@ -94,15 +96,11 @@ func main() {
 
 	// HTTP server
 	r := gin.New()
-	r.Use(gin.Recovery())
+	r.Use(commonmw.RequestID())
+	r.Use(commonmw.Recovery(logger, "payment-service", os.Stdout))
 
 	// Add request timeout middleware
-	r.Use(func(c *gin.Context) {
-		ctx, cancel := context.WithTimeout(c.Request.Context(), 30*time.Second)
-		defer cancel()
-		c.Request = c.Request.WithContext(ctx)
-		c.Next()
-	})
+	r.Use(commonmw.RequestTimeout(cfg.RequestTimeout))
 
 	pc := &controllers.PaymentController{
 		Stripe:   stripeSvc,
@@ -110,6 +108,8 @@ func main() {
 		TopicArn: paymentTopicArn,
 		Repo:     paymentRepo,
 		Logger:   logger,
+
+		AllowedRedirectHosts: cfg.CheckoutRedirectHosts,
 	}
 	routes.RegisterPaymentRoutes(r, pc)
 
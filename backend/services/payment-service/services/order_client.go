@@ -0,0 +1,58 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// OrderAmountFetcher looks up the stored total of an order.
+type OrderAmountFetcher interface {
+	GetOrderAmount(ctx context.Context, orderID, userID uuid.UUID) (int, error)
+}
+
+// OrderClient reads orders from order-service.
+type OrderClient struct {
+	BaseURL string
+	HTTP    *http.Client
+}
+
+func NewOrderClient(baseURL string) *OrderClient {
+	return &OrderClient{
+		BaseURL: baseURL,
+		HTTP:    &http.Client{Timeout: 5 * time.Second},
+	}
+}
+
+// GetOrderAmount fetches the order as its owner and returns its Amount.
+func (c *OrderClient) GetOrderAmount(ctx context.Context, orderID, userID uuid.UUID) (int, error) {
+	url := fmt.Sprintf("%s/orders/%s", c.BaseURL, orderID.String())
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("X-User-ID", userID.String())
+
+	resp, err := c.HTTP.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return 0, fmt.Errorf("order service returned %d", resp.StatusCode)
+	}
+
+	var order struct {
+		Amount int `json:"Amount"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&order); err != nil {
+		return 0, err
+	}
+	return order.Amount, nil
+}
@@ -0,0 +1,80 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+type fakeOrders struct {
+	amount int
+	err    error
+}
+
+func (f *fakeOrders) GetOrderAmount(ctx context.Context, orderID, userID uuid.UUID) (int, error) {
+	return f.amount, f.err
+}
+
+func TestVerifyAmount_Matches(t *testing.T) {
+	c := &PaymentRequestConsumer{orders: &fakeOrders{amount: 4200}, logger: zap.NewNop()}
+
+	if err := c.verifyAmount(context.Background(), uuid.New(), uuid.New(), 4200); err != nil {
+		t.Fatalf("expected matching amount to pass, got %v", err)
+	}
+}
+
+func TestVerifyAmount_Mismatch(t *testing.T) {
+	c := &PaymentRequestConsumer{orders: &fakeOrders{amount: 4200}, logger: zap.NewNop()}
+
+	err := c.verifyAmount(context.Background(), uuid.New(), uuid.New(), 1)
+	if !errors.Is(err, ErrAmountMismatch) {
+		t.Fatalf("expected ErrAmountMismatch, got %v", err)
+	}
+}
+
+func TestVerifyAmount_LookupFailureIsNotMismatch(t *testing.T) {
+	c := &PaymentRequestConsumer{orders: &fakeOrders{err: errors.New("connection refused")}, logger: zap.NewNop()}
+
+	err := c.verifyAmount(context.Background(), uuid.New(), uuid.New(), 4200)
+	if err == nil || errors.Is(err, ErrAmountMismatch) {
+		t.Fatalf("expected a retryable lookup error, got %v", err)
+	}
+}
+
+func TestOrderClient_GetOrderAmount(t *testing.T) {
+	orderID, userID := uuid.New(), uuid.New()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/orders/"+orderID.String() {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		if r.Header.Get("X-User-ID") != userID.String() {
+			t.Errorf("expected X-User-ID %s, got %q", userID, r.Header.Get("X-User-ID"))
+		}
+		w.Write([]byte(`{"ID":"` + orderID.String() + `","Amount":4200,"Status":"pending_payment"}`))
+	}))
+	defer srv.Close()
+
+	amount, err := NewOrderClient(srv.URL).GetOrderAmount(context.Background(), orderID, userID)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if amount != 4200 {
+		t.Fatalf("expected 4200, got %d", amount)
+	}
+}
+
+func TestOrderClient_NotFound(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer srv.Close()
+
+	if _, err := NewOrderClient(srv.URL).GetOrderAmount(context.Background(), uuid.New(), uuid.New()); err == nil {
+		t.Fatal("expected error for 404")
+	}
+}
@@ -3,8 +3,11 @@ package services
 import (
 	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"payment-service/models"
 	"payment-service/repository"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -12,11 +15,16 @@ import (
 	"go.uber.org/zap"
 )
 
+// ErrAmountMismatch means a payment request's amount differs from the stored
+// order total.
+var ErrAmountMismatch = errors.New("payment amount does not match order total")
+
 type PaymentRequestConsumer struct {
 	sqsConsumer     *aws_pkg.SQSConsumer
 	snsPublisher    *aws_pkg.SNSClient
 	paymentTopicArn string
 	stripeSvc       *StripeService
+	orders          OrderAmountFetcher
 	logger          *zap.Logger
 	repo            repository.PaymentRepository
 }
@@ -26,6 +34,7 @@ func NewPaymentRequestConsumer(
 	snsPublisher *aws_pkg.SNSClient,
 	paymentTopicArn string,
 	stripeSvc *StripeService,
+	orders OrderAmountFetcher,
 	repo repository.PaymentRepository,
 	logger *zap.Logger,
 ) *PaymentRequestConsumer {
@@ -34,6 +43,7 @@ func NewPaymentRequestConsumer(
 		snsPublisher:    snsPublisher,
 		paymentTopicArn: paymentTopicArn,
 		stripeSvc:       stripeSvc,
+		orders:          orders,
 		logger:          logger,
 		repo:            repo,
 	}
@@ -61,13 +71,41 @@ func (c *PaymentRequestConsumer) Start(ctx context.Context) {
 			return err
 		}
 
+		// Orders carry their currency; older requests without one are usd
+		currency := strings.ToLower(req.Currency)
+		if currency == "" {
+			currency = "usd"
+		}
+
+		if err := c.verifyAmount(ctx, orderID, userID, req.Amount); err != nil {
+			if !errors.Is(err, ErrAmountMismatch) {
+				c.logger.Warn("Could not verify payment amount", zap.String("order_id", req.OrderID), zap.Error(err))
+				return err
+			}
+			c.logger.Error("Rejecting payment request", zap.String("order_id", req.OrderID), zap.Error(err))
+
+			eventMsg := models.PaymentEvent{
+				Type:      "payment_failed",
+				OrderID:   orderID.String(),
+				UserID:    userID.String(),
+				Amount:    req.Amount,
+				Currency:  currency,
+				Status:    "FAILED",
+				Timestamp: time.Now().UTC(),
+			}
+			eventBytes, _ := json.Marshal(eventMsg)
+			c.snsPublisher.Publish(ctx, c.paymentTopicArn, eventBytes)
+			// A mismatch won't fix itself on redelivery, so ack the message
+			return nil
+		}
+
 		// Create payment record
 		payment := models.Payment{
 			Payment_ID: uuid.New(),
 			OrderID:    orderID,
 			UserID:     userID,
 			Amount:     req.Amount,
-			Currency:   "usd",
+			Currency:   currency,
 			Status:     "pending",
 			CreatedAt:  time.Now().UTC(),
 		}
@@ -80,7 +118,7 @@ func (c *PaymentRequestConsumer) Start(ctx context.Context) {
 		c.logger.Info("Payment record created", zap.String("payment_id", payment.Payment_ID.String()))
 
 		// Create Stripe PaymentIntent
-		pi, err := c.stripeSvc.CreatePaymentIntent(int64(req.Amount*100), "usd")
+		pi, err := c.stripeSvc.CreatePaymentIntent(ctx, int64(req.Amount*100), currency)
 		if err != nil {
 			c.logger.Error("Failed to create Stripe PaymentIntent", zap.Error(err))
 			payment.Status = "failed"
@@ -122,3 +160,16 @@ func (c *PaymentRequestConsumer) Start(ctx context.Context) {
 		c.logger.Error("SQS consumer error", zap.Error(err))
 	}
 }
+
+// verifyAmount checks the requested amount against the order's stored total so
+// a tampered request can't charge a different amount.
+func (c *PaymentRequestConsumer) verifyAmount(ctx context.Context, orderID, userID uuid.UUID, amount int) error {
+	total, err := c.orders.GetOrderAmount(ctx, orderID, userID)
+	if err != nil {
+		return fmt.Errorf("fetch order %s: %w", orderID, err)
+	}
+	if total != amount {
+		return fmt.Errorf("%w: requested %d, order total %d", ErrAmountMismatch, amount, total)
+	}
+	return nil
+}
@@ -0,0 +1,38 @@
+package services
+
+import (
+	"errors"
+	"net/url"
+	"strings"
+)
+
+// ErrRedirectNotAllowed is returned for checkout redirect URLs that are
+// malformed or point at a host outside the allow-list.
+var ErrRedirectNotAllowed = errors.New("redirect URL not allowed")
+
+// ValidateRedirectURL checks that raw is an absolute http(s) URL whose host is
+// one of allowedHosts or a subdomain of one.
+func ValidateRedirectURL(raw string, allowedHosts []string) error {
+	u, err := url.Parse(raw)
+	if err != nil || !u.IsAbs() || u.User != nil {
+		return ErrRedirectNotAllowed
+	}
+	if u.Scheme != "https" && u.Scheme != "http" {
+		return ErrRedirectNotAllowed
+	}
+
+	host := strings.ToLower(u.Hostname())
+	if host == "" {
+		return ErrRedirectNotAllowed
+	}
+	for _, allowed := range allowedHosts {
+		allowed = strings.ToLower(strings.TrimSpace(allowed))
+		if allowed == "" {
+			continue
+		}
+		if host == allowed || strings.HasSuffix(host, "."+allowed) {
+			return nil
+		}
+	}
+	return ErrRedirectNotAllowed
+}
@@ -0,0 +1,42 @@
+package services
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestValidateRedirectURL(t *testing.T) {
+	allowed := []string{"shop.example.com", "localhost"}
+
+	cases := []struct {
+		url string
+		ok  bool
+	}{
+		{"https://shop.example.com/payment/success?session_id={CHECKOUT_SESSION_ID}", true},
+		{"https://eu.shop.example.com/cancel", true},
+		{"http://localhost:3000/payment/cancel", true},
+		{"https://SHOP.EXAMPLE.COM/ok", true},
+		{"https://evil.com/payment/success", false},
+		{"https://shop.example.com.evil.com/", false},
+		{"https://notshop.example.com/", false},
+		{"https://user@shop.example.com/", false},
+		{"javascript:alert(1)", false},
+		{"/payment/success", false},
+		{"ftp://shop.example.com/", false},
+	}
+	for _, tc := range cases {
+		err := ValidateRedirectURL(tc.url, allowed)
+		if tc.ok && err != nil {
+			t.Errorf("%s: expected allowed, got %v", tc.url, err)
+		}
+		if !tc.ok && !errors.Is(err, ErrRedirectNotAllowed) {
+			t.Errorf("%s: expected ErrRedirectNotAllowed, got %v", tc.url, err)
+		}
+	}
+}
+
+func TestValidateRedirectURL_EmptyAllowList(t *testing.T) {
+	if err := ValidateRedirectURL("https://shop.example.com/", nil); !errors.Is(err, ErrRedirectNotAllowed) {
+		t.Fatalf("expected rejection with empty allow-list, got %v", err)
+	}
+}
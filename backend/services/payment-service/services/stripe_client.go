@@ -2,6 +2,7 @@ package services
 
 import (
 	"bytes"
+	"context"
 	"io/ioutil"
 	"net/http"
 
@@ -22,8 +23,9 @@ func NewStripeService(secretKey, webhookKey string) *StripeService {
 	return &StripeService{SecretKey: secretKey, WebhookKey: webhookKey}
 }
 
-func (s *StripeService) CreatePaymentIntent(amount int64, currency string) (*stripe.PaymentIntent, error) {
+func (s *StripeService) CreatePaymentIntent(ctx context.Context, amount int64, currency string) (*stripe.PaymentIntent, error) {
 	params := &stripe.PaymentIntentParams{
+		Params:   stripe.Params{Context: ctx},
 		Amount:   stripe.Int64(amount),
 		Currency: stripe.String(currency),
 	}
@@ -34,8 +36,9 @@ func (s *StripeService) CreatePaymentIntent(amount int64, currency string) (*str
 	return pi, nil
 }
 
-func (s *StripeService) CreateCheckoutSession(amount int64, currency, orderID, userID string) (*stripe.CheckoutSession, error) {
+func (s *StripeService) CreateCheckoutSession(ctx context.Context, amount int64, currency, orderID, userID string) (*stripe.CheckoutSession, error) {
 	params := &stripe.CheckoutSessionParams{
+		Params:             stripe.Params{Context: ctx},
 		PaymentMethodTypes: stripe.StringSlice([]string{"card"}),
 		Mode:               stripe.String(string(stripe.CheckoutSessionModePayment)),
 		SuccessURL:         stripe.String("http://localhost:3000/payment/success?session_id={CHECKOUT_SESSION_ID}"),
@@ -4,6 +4,9 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"product-service/controllers"
+	"strconv"
+	"strings"
 
 	aws_pkg "github.com/yashrajoria/E-Commerce-backend/backend/pkg/aws"
 )
@@ -12,6 +15,19 @@ import (
 type Config struct {
 	JWTSecret string // JWT secret for authentication
 	Port      string // Service port (default: 8082)
+
+	// PageSizes bounds perPage on list endpoints, from PRODUCT_DEFAULT_PAGE_SIZE
+	// and PRODUCT_MAX_PAGE_SIZE (defaults 10 and 100).
+	PageSizes controllers.PageSizes
+
+	// RecentlyViewedLimit caps each user's recently viewed list, from
+	// RECENTLY_VIEWED_LIMIT (default 20).
+	RecentlyViewedLimit int
+
+	// UnfeatureOn lists the inventory events (low_stock, out_of_stock) that
+	// clear is_featured, from UNFEATURE_ON_INVENTORY_EVENTS. Empty disables.
+	// No service sends these events yet, so this has no effect until one does.
+	UnfeatureOn []string
 }
 
 // LoadConfig loads environment variables into Config struct and validates them.
@@ -21,6 +37,9 @@ func LoadConfig() (*Config, error) {
 	cfg := &Config{
 		JWTSecret: os.Getenv("JWT_SECRET"),
 		Port:      os.Getenv("PORT"),
+		PageSizes: controllers.DefaultPageSizes,
+
+		RecentlyViewedLimit: controllers.DefaultRecentlyViewedLimit,
 	}
 
 	// Set default port if not provided
@@ -43,5 +62,37 @@ func LoadConfig() (*Config, error) {
 		return nil, fmt.Errorf("JWT_SECRET is required")
 	}
 
+	if err := envInt("PRODUCT_DEFAULT_PAGE_SIZE", &cfg.PageSizes.Default); err != nil {
+		return nil, err
+	}
+	if err := envInt("PRODUCT_MAX_PAGE_SIZE", &cfg.PageSizes.Max); err != nil {
+		return nil, err
+	}
+	if err := cfg.PageSizes.Validate(); err != nil {
+		return nil, err
+	}
+	if err := envInt("RECENTLY_VIEWED_LIMIT", &cfg.RecentlyViewedLimit); err != nil {
+		return nil, err
+	}
+	for _, e := range strings.Split(os.Getenv("UNFEATURE_ON_INVENTORY_EVENTS"), ",") {
+		if e = strings.TrimSpace(e); e != "" {
+			cfg.UnfeatureOn = append(cfg.UnfeatureOn, e)
+		}
+	}
+
 	return cfg, nil
 }
+
+// envInt overwrites *dst with the integer value of key when it is set.
+func envInt(key string, dst *int) error {
+	raw := os.Getenv(key)
+	if raw == "" {
+		return nil
+	}
+	v, err := strconv.Atoi(raw)
+	if err != nil {
+		return fmt.Errorf("%s must be an integer, got %q", key, raw)
+	}
+	*dst = v
+	return nil
+}
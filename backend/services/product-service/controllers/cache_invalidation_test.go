@@ -0,0 +1,211 @@
+package controllers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"product-service/models"
+	"product-service/services"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-redis/redis/v8"
+	"github.com/google/uuid"
+)
+
+// recordingHook captures every Redis command and fails it, standing in for
+// an unreachable Redis.
+type recordingHook struct {
+	cmds [][]interface{}
+}
+
+func (h *recordingHook) BeforeProcess(ctx context.Context, cmd redis.Cmder) (context.Context, error) {
+	h.cmds = append(h.cmds, cmd.Args())
+	return ctx, errors.New("redis unavailable")
+}
+
+func (h *recordingHook) AfterProcess(ctx context.Context, cmd redis.Cmder) error { return nil }
+
+func (h *recordingHook) BeforeProcessPipeline(ctx context.Context, cmds []redis.Cmder) (context.Context, error) {
+	return ctx, nil
+}
+
+func (h *recordingHook) AfterProcessPipeline(ctx context.Context, cmds []redis.Cmder) error {
+	return nil
+}
+
+// scannedProductCache reports whether a SCAN for the product listing keys was issued.
+func (h *recordingHook) scannedProductCache() bool {
+	for _, args := range h.cmds {
+		if len(args) == 0 || !strings.EqualFold(args[0].(string), "scan") {
+			continue
+		}
+		for i := 1; i+1 < len(args); i++ {
+			if args[i] == "match" && args[i+1] == productCachePattern {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func newRecordingController(svc *fakeProductService) (*ProductController, *recordingHook) {
+	client := newTestRedisClient()
+	hook := &recordingHook{}
+	client.AddHook(hook)
+	return NewProductController(svc, client), hook
+}
+
+func TestProductMutations_InvalidateListingCache(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	id := uuid.New().String()
+
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		body   string
+		want   int
+		route  func(r *gin.Engine, ctrl *ProductController)
+	}{
+		{
+			name: "update", method: http.MethodPut, path: "/products/" + id, body: `{"name":"New"}`, want: http.StatusOK,
+			route: func(r *gin.Engine, ctrl *ProductController) { r.PUT("/products/:id", ctrl.UpdateProduct) },
+		},
+		{
+			name: "delete", method: http.MethodDelete, path: "/products/" + id, want: http.StatusOK,
+			route: func(r *gin.Engine, ctrl *ProductController) { r.DELETE("/products/:id", ctrl.DeleteProduct) },
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl, hook := newRecordingController(&fakeProductService{modifiedCount: 1})
+			router := gin.New()
+			tt.route(router, ctrl)
+
+			req := httptest.NewRequest(tt.method, tt.path, strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			// Redis is down, so this also checks that invalidation is non-fatal
+			if w.Code != tt.want {
+				t.Fatalf("expected %d, got %d: %s", tt.want, w.Code, w.Body.String())
+			}
+			if !hook.scannedProductCache() {
+				t.Fatalf("expected product cache invalidation, got commands %v", hook.cmds)
+			}
+		})
+	}
+}
+
+func TestCreateProduct_InvalidatesListingCache(t *testing.T) {
+	svc := &fakeProductService{}
+	ctrl, hook := newRecordingController(svc)
+
+	w := postProductWithImageTo(t, ctrl, "photo.png", "image/png", pngHeader)
+
+	if w.Code != http.StatusCreated {
+		t.Fatalf("expected 201, got %d: %s", w.Code, w.Body.String())
+	}
+	if !hook.scannedProductCache() {
+		t.Fatalf("expected product cache invalidation, got commands %v", hook.cmds)
+	}
+}
+
+func TestUpdateProduct_NotFoundSkipsInvalidation(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	ctrl, hook := newRecordingController(&fakeProductService{})
+	router := gin.New()
+	router.PUT("/products/:id", ctrl.UpdateProduct)
+
+	req := httptest.NewRequest(http.MethodPut, "/products/"+uuid.New().String(), strings.NewReader(`{"name":"New"}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusNotFound {
+		t.Fatalf("expected 404, got %d", w.Code)
+	}
+	if len(hook.cmds) != 0 {
+		t.Fatalf("expected no cache commands when nothing changed, got %v", hook.cmds)
+	}
+}
+
+// cachedTTL returns the expiry of the SET that stored a product listing.
+func (h *recordingHook) cachedTTL() (time.Duration, bool) {
+	for _, args := range h.cmds {
+		if len(args) < 5 || !strings.EqualFold(args[0].(string), "set") {
+			continue
+		}
+		n, _ := args[4].(int64)
+		switch args[3] {
+		case "ex":
+			return time.Duration(n) * time.Second, true
+		case "px":
+			return time.Duration(n) * time.Millisecond, true
+		}
+	}
+	return 0, false
+}
+
+func TestGetProducts_CacheExpiresAtSaleBoundary(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	sale := 60.0
+	endsAt := time.Now().Add(90 * time.Second)
+	svc := &fakeProductService{
+		listProductsFn: func(ctx context.Context, params services.ListProductsParams) ([]*models.Product, int64, error) {
+			return []*models.Product{
+				{Name: "Regular", Price: 10},
+				{Name: "Flash sale", Price: 80, SalePrice: &sale, SaleEndsAt: &endsAt},
+			}, 2, nil
+		},
+	}
+	ctrl, hook := newRecordingController(svc)
+	router := gin.New()
+	router.GET("/products", ctrl.GetProducts)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/products", nil))
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", w.Code)
+	}
+	ttl, ok := hook.cachedTTL()
+	if !ok {
+		t.Fatalf("expected the listing to be cached, got commands %v", hook.cmds)
+	}
+	if ttl <= 0 || ttl > 90*time.Second {
+		t.Fatalf("expected the cache to expire when the sale ends, got TTL %v", ttl)
+	}
+}
+
+func TestListingCacheTTL(t *testing.T) {
+	now := time.Now()
+	sale := 5.0
+	startsAt, endsAt := now.Add(time.Minute), now.Add(time.Hour)
+	past := now.Add(-time.Minute)
+
+	cases := []struct {
+		name    string
+		product *models.Product
+		want    time.Duration
+	}{
+		{name: "no sale", product: &models.Product{Price: 10}, want: productListCacheTTL},
+		{name: "sale starting soon", product: &models.Product{SalePrice: &sale, SaleStartsAt: &startsAt, SaleEndsAt: &endsAt}, want: time.Minute},
+		{name: "sale ending later", product: &models.Product{SalePrice: &sale, SaleStartsAt: &past, SaleEndsAt: &endsAt}, want: productListCacheTTL},
+		{name: "boundaries without a sale price", product: &models.Product{SaleStartsAt: &startsAt}, want: productListCacheTTL},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := listingCacheTTL([]*models.Product{tc.product}, now); got != tc.want {
+				t.Fatalf("expected %v, got %v", tc.want, got)
+			}
+		})
+	}
+}
@@ -3,6 +3,8 @@ package controllers
 import (
 	"context"
 	"errors"
+	"fmt"
+	"math"
 	"net/http"
 	"strings"
 
@@ -11,6 +13,8 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
+	apperrors "github.com/yashrajoria/common/errors"
+	"github.com/yashrajoria/common/httputil"
 	"go.uber.org/zap"
 )
 
@@ -20,9 +24,17 @@ type CategoryServiceAPI interface {
 	GetCategoryTree(ctx context.Context) ([]*models.Category, error)
 	UpdateCategory(ctx context.Context, id uuid.UUID, req services.CategoryCreateRequest) (int64, error)
 	DeleteCategory(ctx context.Context, id uuid.UUID) error
+	RestoreCategory(ctx context.Context, id uuid.UUID) (*models.Category, error)
 	GetCategory(ctx context.Context, id uuid.UUID) (*models.Category, error)
+	ValidateCategoryImport(ctx context.Context, reqs []services.CategoryCreateRequest) (*models.CategoryImportValidation, error)
+	BulkCreateCategories(ctx context.Context, reqs []services.CategoryCreateRequest) ([]*models.Category, *models.CategoryImportValidation, error)
+	ListCategoryProducts(ctx context.Context, id uuid.UUID, page, perPage int) ([]*models.Product, int64, error)
+	PublicProducts(ctx context.Context, products []*models.Product, opts services.ProductDTOOptions) ([]services.ProductDTO, error)
 }
 
+// MaxBulkCategories caps the number of categories accepted by one import.
+const MaxBulkCategories = 1000
+
 type CategoryController struct {
 	service CategoryServiceAPI
 }
@@ -34,12 +46,12 @@ func NewCategoryController(s CategoryServiceAPI) *CategoryController {
 func (ctrl *CategoryController) CreateCategory(c *gin.Context) {
 	var req services.CategoryCreateRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body", "details": err.Error()})
+		httputil.RespondErrorDetails(c, http.StatusBadRequest, "Invalid request body", err.Error())
 		return
 	}
 
 	if err := validate.Struct(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Validation failed", "details": err.Error()})
+		httputil.RespondErrorDetails(c, http.StatusBadRequest, "Validation failed", err.Error())
 		return
 	}
 
@@ -47,15 +59,15 @@ func (ctrl *CategoryController) CreateCategory(c *gin.Context) {
 	if err != nil {
 		// Check for specific, known errors to give better feedback
 		if strings.Contains(err.Error(), "already exists") {
-			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+			httputil.RespondError(c, http.StatusConflict, err.Error())
 			return
 		}
-		if strings.Contains(err.Error(), "not found") {
-			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		if errors.Is(err, services.ErrParentCategoryNotFound) {
+			httputil.RespondError(c, http.StatusBadRequest, err.Error())
 			return
 		}
 		zap.L().Error("Service failed to create category", zap.Error(err))
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create category"})
+		httputil.RespondError(c, http.StatusInternalServerError, "Failed to create category")
 		return
 	}
 	c.JSON(http.StatusCreated, category)
@@ -65,7 +77,7 @@ func (ctrl *CategoryController) GetCategories(c *gin.Context) {
 	categoryTree, err := ctrl.service.GetCategoryTree(c.Request.Context())
 	if err != nil {
 		zap.L().Error("Service failed to get category tree", zap.Error(err))
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch categories"})
+		httputil.RespondError(c, http.StatusInternalServerError, "Failed to fetch categories")
 		return
 	}
 	c.JSON(http.StatusOK, categoryTree)
@@ -75,28 +87,28 @@ func (ctrl *CategoryController) UpdateCategory(c *gin.Context) {
 	id := c.Param("id")
 	categoryID, err := uuid.Parse(id)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid category ID format"})
+		httputil.RespondError(c, http.StatusBadRequest, "Invalid category ID format")
 		return
 	}
 
 	var req services.CategoryCreateRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body", "details": err.Error()})
+		httputil.RespondErrorDetails(c, http.StatusBadRequest, "Invalid request body", err.Error())
 		return
 	}
 	if err := validate.Struct(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Validation failed", "details": err.Error()})
+		httputil.RespondErrorDetails(c, http.StatusBadRequest, "Validation failed", err.Error())
 		return
 	}
 
 	modifiedCount, err := ctrl.service.UpdateCategory(c.Request.Context(), categoryID, req)
 	if err != nil {
 		zap.L().Error("Service failed to update category", zap.Error(err), zap.String("id", id))
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update category"})
+		httputil.RespondError(c, http.StatusInternalServerError, "Failed to update category")
 		return
 	}
 	if modifiedCount == 0 {
-		c.JSON(http.StatusNotFound, gin.H{"error": "Category not found or no changes made"})
+		httputil.RespondError(c, http.StatusNotFound, "Category not found or no changes made")
 		return
 	}
 
@@ -107,24 +119,160 @@ func (ctrl *CategoryController) DeleteCategory(c *gin.Context) {
 	id := c.Param("id")
 	categoryID, err := uuid.Parse(id)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid category ID format"})
+		httputil.RespondError(c, http.StatusBadRequest, "Invalid category ID format")
 		return
 	}
 
 	err = ctrl.service.DeleteCategory(c.Request.Context(), categoryID)
 	if err != nil {
-		if errors.Is(err, ErrNotFound) || strings.Contains(err.Error(), "not found") {
-			c.JSON(http.StatusNotFound, gin.H{"error": "Category not found"})
+		if errors.Is(err, services.ErrCategoryHasProducts) {
+			httputil.RespondError(c, http.StatusBadRequest, err.Error())
 			return
 		}
-		if strings.Contains(err.Error(), "associated products") {
-			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		if errors.Is(err, apperrors.ErrNotFound) {
+			httputil.RespondError(c, http.StatusNotFound, "Category not found")
 			return
 		}
 		zap.L().Error("Service failed to delete category", zap.Error(err), zap.String("id", id))
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete category"})
+		httputil.RespondError(c, http.StatusInternalServerError, "Failed to delete category")
 		return
 	}
 
 	c.JSON(http.StatusOK, gin.H{"message": "Category deleted successfully"})
 }
+
+func (ctrl *CategoryController) RestoreCategory(c *gin.Context) {
+	id := c.Param("id")
+	categoryID, err := uuid.Parse(id)
+	if err != nil {
+		httputil.RespondError(c, http.StatusBadRequest, "Invalid category ID format")
+		return
+	}
+
+	category, err := ctrl.service.RestoreCategory(c.Request.Context(), categoryID)
+	if err != nil {
+		if strings.Contains(err.Error(), "already exists") {
+			httputil.RespondError(c, http.StatusConflict, err.Error())
+			return
+		}
+		if errors.Is(err, apperrors.ErrNotFound) {
+			httputil.RespondError(c, http.StatusNotFound, "Deleted category not found")
+			return
+		}
+		zap.L().Error("Service failed to restore category", zap.Error(err), zap.String("id", id))
+		httputil.RespondError(c, http.StatusInternalServerError, "Failed to restore category")
+		return
+	}
+
+	c.JSON(http.StatusOK, category)
+}
+
+// bindCategoryImport reads a JSON array of categories, writing the error
+// response itself when the body is unusable.
+func bindCategoryImport(c *gin.Context) ([]services.CategoryCreateRequest, bool) {
+	var reqs []services.CategoryCreateRequest
+	if err := c.ShouldBindJSON(&reqs); err != nil {
+		httputil.RespondErrorDetails(c, http.StatusBadRequest, "Invalid request body", err.Error())
+		return nil, false
+	}
+	if len(reqs) == 0 {
+		httputil.RespondError(c, http.StatusBadRequest, "categories must not be empty")
+		return nil, false
+	}
+	if len(reqs) > MaxBulkCategories {
+		httputil.RespondError(c, http.StatusBadRequest, fmt.Sprintf("at most %d categories per request", MaxBulkCategories))
+		return nil, false
+	}
+	for i := range reqs {
+		if err := validate.Struct(&reqs[i]); err != nil {
+			httputil.RespondErrorDetails(c, http.StatusBadRequest, fmt.Sprintf("Validation failed for category %d", i), fieldErrors(err))
+			return nil, false
+		}
+	}
+	return reqs, true
+}
+
+// ValidateCategoryImport reports missing parents, duplicates and cycles
+// without creating anything
+func (ctrl *CategoryController) ValidateCategoryImport(c *gin.Context) {
+	reqs, ok := bindCategoryImport(c)
+	if !ok {
+		return
+	}
+
+	validation, err := ctrl.service.ValidateCategoryImport(c.Request.Context(), reqs)
+	if err != nil {
+		zap.L().Error("Service failed to validate category import", zap.Error(err))
+		httputil.RespondError(c, http.StatusInternalServerError, "Failed to validate categories")
+		return
+	}
+
+	c.JSON(http.StatusOK, validation)
+}
+
+// BulkCreateCategories creates several categories, parents before children
+func (ctrl *CategoryController) BulkCreateCategories(c *gin.Context) {
+	reqs, ok := bindCategoryImport(c)
+	if !ok {
+		return
+	}
+
+	categories, validation, err := ctrl.service.BulkCreateCategories(c.Request.Context(), reqs)
+	if err != nil {
+		if errors.Is(err, services.ErrInvalidCategoryImport) {
+			httputil.RespondErrorDetails(c, http.StatusBadRequest, "Category import failed validation", validation)
+			return
+		}
+		zap.L().Error("Service failed to bulk create categories", zap.Error(err), zap.Int("created", len(categories)))
+		httputil.RespondErrorDetails(c, http.StatusInternalServerError, "Failed to create categories", gin.H{"created_count": len(categories)})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"created_count": len(categories), "categories": categories})
+}
+
+// GetCategoryProducts lists the products in a category, one page at a time
+func (ctrl *CategoryController) GetCategoryProducts(c *gin.Context) {
+	id := c.Param("id")
+	categoryID, err := uuid.Parse(id)
+	if err != nil {
+		httputil.RespondError(c, http.StatusBadRequest, "Invalid category ID format")
+		return
+	}
+
+	page, perPage, ok := parsePagination(c)
+	if !ok {
+		return
+	}
+	expand, ok := parseExpand(c)
+	if !ok {
+		return
+	}
+
+	products, total, err := ctrl.service.ListCategoryProducts(c.Request.Context(), categoryID, page, perPage)
+	if err != nil {
+		if errors.Is(err, apperrors.ErrNotFound) {
+			httputil.RespondError(c, http.StatusNotFound, "Category not found")
+			return
+		}
+		zap.L().Error("Service failed to list category products", zap.Error(err), zap.String("id", id))
+		httputil.RespondError(c, http.StatusInternalServerError, "Failed to fetch products")
+		return
+	}
+	dtos, err := ctrl.service.PublicProducts(c.Request.Context(), products, expand)
+	if err != nil {
+		zap.L().Error("Service failed to build product responses", zap.Error(err), zap.String("id", id))
+		httputil.RespondError(c, http.StatusInternalServerError, "Failed to fetch products")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"products": dtos,
+		"meta": gin.H{
+			"page":       page,
+			"perPage":    perPage,
+			"total":      total,
+			"totalPages": int(math.Ceil(float64(total) / float64(perPage))),
+		},
+	})
+}
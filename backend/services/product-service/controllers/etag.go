@@ -0,0 +1,54 @@
+package controllers
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"product-service/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+// etagFor returns a strong ETag for a response body.
+func etagFor(body []byte) string {
+	sum := sha256.Sum256(body)
+	return `"` + hex.EncodeToString(sum[:16]) + `"`
+}
+
+// productETag derives a product's ETag from its version rather than its
+// serialized body. The rating fields are included because review updates
+// refresh them without touching UpdatedAt, and the sale state because the
+// effective price changes when a sale window opens or closes.
+func productETag(p *models.Product) string {
+	version := fmt.Sprintf("%s:%s:%d:%g:%t", p.ID, p.UpdatedAt.UTC().Format(time.RFC3339Nano), p.ReviewCount, p.AverageRating, p.OnSale(time.Now()))
+	return etagFor([]byte(version))
+}
+
+// checkNotModified sets the ETag header and, when the request's
+// If-None-Match already names it, answers 304 and reports true.
+func checkNotModified(c *gin.Context, etag string) bool {
+	c.Header("ETag", etag)
+	if !etagMatches(c.GetHeader("If-None-Match"), etag) {
+		return false
+	}
+	c.Status(http.StatusNotModified)
+	return true
+}
+
+// etagMatches applies the weak comparison If-None-Match calls for.
+func etagMatches(header, etag string) bool {
+	if header == "" {
+		return false
+	}
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || strings.TrimPrefix(candidate, "W/") == etag {
+			return true
+		}
+	}
+	return false
+}
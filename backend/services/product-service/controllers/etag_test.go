@@ -0,0 +1,79 @@
+package controllers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"product-service/models"
+	"product-service/services"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+func getWithETag(router *gin.Engine, path, ifNoneMatch string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, path, nil)
+	if ifNoneMatch != "" {
+		req.Header.Set("If-None-Match", ifNoneMatch)
+	}
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestGetProductByID_ConditionalGet(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	product := &models.Product{ID: uuid.New(), Name: "Widget", UpdatedAt: time.Now().UTC()}
+	svc := &fakeProductService{product: product}
+	router := gin.New()
+	router.GET("/products/:id", NewProductController(svc, newTestRedisClient()).GetProductByID)
+	path := "/products/" + product.ID.String()
+
+	first := getWithETag(router, path, "")
+	etag := first.Header().Get("ETag")
+	if first.Code != http.StatusOK || etag == "" {
+		t.Fatalf("expected 200 with ETag, got %d etag %q", first.Code, etag)
+	}
+
+	second := getWithETag(router, path, etag)
+	if second.Code != http.StatusNotModified || second.Body.Len() != 0 {
+		t.Fatalf("expected empty 304 on matching ETag, got %d: %s", second.Code, second.Body.String())
+	}
+
+	// An update moves UpdatedAt, so the old ETag no longer matches
+	product.UpdatedAt = product.UpdatedAt.Add(time.Second)
+	third := getWithETag(router, path, etag)
+	if third.Code != http.StatusOK || third.Header().Get("ETag") == etag {
+		t.Fatalf("expected 200 with a new ETag after update, got %d", third.Code)
+	}
+}
+
+func TestGetProducts_ConditionalGet(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	svc := &fakeProductService{
+		listProductsFn: func(ctx context.Context, params services.ListProductsParams) ([]*models.Product, int64, error) {
+			return []*models.Product{{Name: "Widget"}}, 1, nil
+		},
+	}
+	router := gin.New()
+	router.GET("/products", NewProductController(svc, newTestRedisClient()).GetProducts)
+
+	first := getWithETag(router, "/products", "")
+	etag := first.Header().Get("ETag")
+	if first.Code != http.StatusOK || etag == "" {
+		t.Fatalf("expected 200 with ETag, got %d etag %q", first.Code, etag)
+	}
+
+	second := getWithETag(router, "/products", `W/"stale", `+etag)
+	if second.Code != http.StatusNotModified {
+		t.Fatalf("expected 304 when If-None-Match lists the ETag, got %d", second.Code)
+	}
+
+	third := getWithETag(router, "/products", `"stale"`)
+	if third.Code != http.StatusOK {
+		t.Fatalf("expected 200 on mismatched ETag, got %d", third.Code)
+	}
+}
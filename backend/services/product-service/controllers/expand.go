@@ -0,0 +1,28 @@
+package controllers
+
+import (
+	"net/http"
+	"strings"
+
+	"product-service/services"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yashrajoria/common/httputil"
+)
+
+// parseExpand reads the comma-separated expand query parameter of the product
+// read endpoints. Only "categories" is supported; anything else is answered
+// with a 400 and ok=false.
+func parseExpand(c *gin.Context) (opts services.ProductDTOOptions, ok bool) {
+	for _, field := range strings.Split(c.Query("expand"), ",") {
+		switch strings.ToLower(strings.TrimSpace(field)) {
+		case "":
+		case "categories":
+			opts.ExpandCategories = true
+		default:
+			httputil.RespondErrorDetails(c, http.StatusBadRequest, "Invalid expand value", []string{"categories"})
+			return opts, false
+		}
+	}
+	return opts, true
+}
@@ -0,0 +1,64 @@
+package controllers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"product-service/models"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+func TestGetProductByID_Expand(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	product := &models.Product{ID: uuid.New(), Name: "Runner"}
+	cases := []struct {
+		query      string
+		wantStatus int
+		wantExpand bool
+	}{
+		{query: "", wantStatus: http.StatusOK},
+		{query: "?expand=categories", wantStatus: http.StatusOK, wantExpand: true},
+		{query: "?expand=reviews", wantStatus: http.StatusBadRequest},
+	}
+	for _, tc := range cases {
+		svc := &fakeProductService{product: product}
+		ctrl := NewProductController(svc, newTestRedisClient())
+		r := gin.New()
+		r.GET("/products/:id", ctrl.GetProductByID)
+
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/products/"+product.ID.String()+tc.query, nil))
+
+		if w.Code != tc.wantStatus {
+			t.Fatalf("%q: expected status %d, got %d", tc.query, tc.wantStatus, w.Code)
+		}
+		if svc.lastDTOOptions.ExpandCategories != tc.wantExpand {
+			t.Fatalf("%q: expected ExpandCategories=%v", tc.query, tc.wantExpand)
+		}
+	}
+}
+
+func TestGetProductByID_ExpandedETagDiffers(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	product := &models.Product{ID: uuid.New(), Name: "Runner"}
+	ctrl := NewProductController(&fakeProductService{product: product}, newTestRedisClient())
+	r := gin.New()
+	r.GET("/products/:id", ctrl.GetProductByID)
+
+	plain := httptest.NewRecorder()
+	r.ServeHTTP(plain, httptest.NewRequest(http.MethodGet, "/products/"+product.ID.String(), nil))
+
+	req := httptest.NewRequest(http.MethodGet, "/products/"+product.ID.String()+"?expand=categories", nil)
+	req.Header.Set("If-None-Match", plain.Header().Get("ETag"))
+	expanded := httptest.NewRecorder()
+	r.ServeHTTP(expanded, req)
+
+	if expanded.Code != http.StatusOK {
+		t.Fatalf("expected the plain ETag not to validate the expanded representation, got %d", expanded.Code)
+	}
+}
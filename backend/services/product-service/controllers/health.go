@@ -0,0 +1,106 @@
+package controllers
+
+import (
+	"context"
+	"net/http"
+	"runtime"
+	"runtime/debug"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// healthCheckTimeout bounds all dependency checks in a verbose health report.
+const healthCheckTimeout = 3 * time.Second
+
+// BuildInfo identifies the running binary.
+type BuildInfo struct {
+	GitSHA       string            `json:"git_sha"`
+	BuildTime    string            `json:"build_time"`
+	GoVersion    string            `json:"go_version"`
+	Dependencies map[string]string `json:"dependencies,omitempty"` // module path -> version
+}
+
+// NewBuildInfo combines the link-time gitSHA and buildTime with the Go
+// version and module versions embedded in the binary.
+func NewBuildInfo(gitSHA, buildTime string) BuildInfo {
+	info := BuildInfo{GitSHA: gitSHA, BuildTime: buildTime, GoVersion: runtime.Version()}
+	if bi, ok := debug.ReadBuildInfo(); ok {
+		info.Dependencies = make(map[string]string, len(bi.Deps))
+		for _, dep := range bi.Deps {
+			version := dep.Version
+			if dep.Replace != nil {
+				version = dep.Replace.Version
+				if version == "" {
+					version = dep.Replace.Path
+				}
+			}
+			info.Dependencies[dep.Path] = version
+		}
+	}
+	return info
+}
+
+// DependencyCheck reports whether a backing service is reachable.
+type DependencyCheck func(ctx context.Context) error
+
+// DependencyStatus is the outcome of one DependencyCheck.
+type DependencyStatus struct {
+	Status    string `json:"status"` // "ok" or "error"
+	Error     string `json:"error,omitempty"`
+	LatencyMS int64  `json:"latency_ms"`
+}
+
+type HealthController struct {
+	build  BuildInfo
+	checks map[string]DependencyCheck
+}
+
+func NewHealthController(build BuildInfo, checks map[string]DependencyCheck) *HealthController {
+	return &HealthController{build: build, checks: checks}
+}
+
+// Health answers {"status": "OK"} without touching any dependency. With
+// ?verbose=true it also reports build metadata and checks every dependency,
+// responding 503 with status "DEGRADED" if any is unreachable.
+func (h *HealthController) Health(c *gin.Context) {
+	if verbose, _ := strconv.ParseBool(c.Query("verbose")); !verbose {
+		c.JSON(http.StatusOK, gin.H{"status": "OK"})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), healthCheckTimeout)
+	defer cancel()
+
+	results := make(map[string]DependencyStatus, len(h.checks))
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	for name, check := range h.checks {
+		wg.Add(1)
+		go func(name string, check DependencyCheck) {
+			defer wg.Done()
+			start := time.Now()
+			err := check(ctx)
+			res := DependencyStatus{Status: "ok", LatencyMS: time.Since(start).Milliseconds()}
+			if err != nil {
+				res.Status = "error"
+				res.Error = err.Error()
+			}
+			mu.Lock()
+			results[name] = res
+			mu.Unlock()
+		}(name, check)
+	}
+	wg.Wait()
+
+	status, code := "OK", http.StatusOK
+	for _, res := range results {
+		if res.Status != "ok" {
+			status, code = "DEGRADED", http.StatusServiceUnavailable
+			break
+		}
+	}
+	c.JSON(code, gin.H{"status": status, "build": h.build, "dependencies": results})
+}
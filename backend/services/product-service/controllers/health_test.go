@@ -0,0 +1,111 @@
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func newHealthRouter(checks map[string]DependencyCheck) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	h := NewHealthController(BuildInfo{GitSHA: "abc123", BuildTime: "2024-01-02T03:04:05Z", GoVersion: "go1.25"}, checks)
+	r := gin.New()
+	r.GET("/health", h.Health)
+	return r
+}
+
+func TestHealth_DefaultIsTerse(t *testing.T) {
+	called := false
+	r := newHealthRouter(map[string]DependencyCheck{
+		"redis": func(ctx context.Context) error { called = true; return errors.New("down") },
+	})
+
+	for _, path := range []string{"/health", "/health?verbose=false", "/health?verbose=nope"} {
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+		if w.Code != http.StatusOK {
+			t.Fatalf("%s: expected 200, got %d", path, w.Code)
+		}
+		if got := w.Body.String(); got != `{"status":"OK"}` {
+			t.Fatalf("%s: expected terse body, got %s", path, got)
+		}
+	}
+	if called {
+		t.Fatal("terse health must not check dependencies")
+	}
+}
+
+func TestHealth_Verbose(t *testing.T) {
+	r := newHealthRouter(map[string]DependencyCheck{
+		"dynamodb": func(ctx context.Context) error { return nil },
+		"redis":    func(ctx context.Context) error { return nil },
+	})
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/health?verbose=true", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+
+	var body struct {
+		Status       string                      `json:"status"`
+		Build        BuildInfo                   `json:"build"`
+		Dependencies map[string]DependencyStatus `json:"dependencies"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+		t.Fatalf("invalid JSON body: %v", err)
+	}
+	if body.Status != "OK" {
+		t.Fatalf("expected status OK, got %q", body.Status)
+	}
+	if body.Build.GitSHA != "abc123" || body.Build.BuildTime != "2024-01-02T03:04:05Z" || body.Build.GoVersion != "go1.25" {
+		t.Fatalf("unexpected build info %+v", body.Build)
+	}
+	if len(body.Dependencies) != 2 || body.Dependencies["dynamodb"].Status != "ok" || body.Dependencies["redis"].Status != "ok" {
+		t.Fatalf("unexpected dependencies %+v", body.Dependencies)
+	}
+}
+
+func TestHealth_VerboseReportsFailedDependency(t *testing.T) {
+	r := newHealthRouter(map[string]DependencyCheck{
+		"dynamodb": func(ctx context.Context) error { return nil },
+		"redis":    func(ctx context.Context) error { return errors.New("connection refused") },
+	})
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/health?verbose=1", nil))
+	if w.Code != http.StatusServiceUnavailable {
+		t.Fatalf("expected 503, got %d", w.Code)
+	}
+	var body struct {
+		Status       string                      `json:"status"`
+		Dependencies map[string]DependencyStatus `json:"dependencies"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+		t.Fatalf("invalid JSON body: %v", err)
+	}
+	if body.Status != "DEGRADED" {
+		t.Fatalf("expected status DEGRADED, got %q", body.Status)
+	}
+	if redis := body.Dependencies["redis"]; redis.Status != "error" || redis.Error != "connection refused" {
+		t.Fatalf("unexpected redis status %+v", redis)
+	}
+	if body.Dependencies["dynamodb"].Status != "ok" {
+		t.Fatalf("expected dynamodb ok, got %+v", body.Dependencies["dynamodb"])
+	}
+}
+
+func TestNewBuildInfo(t *testing.T) {
+	info := NewBuildInfo("abc123", "2024-01-02T03:04:05Z")
+	if info.GitSHA != "abc123" || info.BuildTime != "2024-01-02T03:04:05Z" {
+		t.Fatalf("link-time values not kept: %+v", info)
+	}
+	if info.GoVersion == "" {
+		t.Fatal("expected the Go version to be filled in")
+	}
+}
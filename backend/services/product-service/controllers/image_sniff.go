@@ -0,0 +1,55 @@
+package controllers
+
+import (
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+)
+
+// allowedImageTypes are the content types accepted for product images, as
+// detected from the file's leading bytes.
+var allowedImageTypes = map[string]bool{
+	"image/jpeg": true,
+	"image/png":  true,
+	"image/gif":  true,
+	"image/webp": true,
+}
+
+// sniffImageType detects the real content type of an uploaded file from its
+// magic bytes, ignoring the client-supplied Content-Type and file extension.
+func sniffImageType(fh *multipart.FileHeader) (string, error) {
+	f, err := fh.Open()
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	// DetectContentType considers at most the first 512 bytes
+	buf := make([]byte, 512)
+	n, err := io.ReadFull(f, buf)
+	if err != nil && err != io.ErrUnexpectedEOF && err != io.EOF {
+		return "", err
+	}
+	return http.DetectContentType(buf[:n]), nil
+}
+
+// validateImageUploads rejects any file whose sniffed type isn't an allowed
+// image. On success each header's Content-Type is replaced with the detected
+// type so downstream storage never trusts the client value.
+func validateImageUploads(images []*multipart.FileHeader) []FieldError {
+	var errs []FieldError
+	for _, fh := range images {
+		detected, err := sniffImageType(fh)
+		if err != nil {
+			errs = append(errs, FieldError{Field: "images", Message: fmt.Sprintf("could not read file %q", fh.Filename)})
+			continue
+		}
+		if !allowedImageTypes[detected] {
+			errs = append(errs, FieldError{Field: "images", Message: fmt.Sprintf("file %q is not a supported image (detected %s)", fh.Filename, detected)})
+			continue
+		}
+		fh.Header.Set("Content-Type", detected)
+	}
+	return errs
+}
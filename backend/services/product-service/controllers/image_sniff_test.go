@@ -0,0 +1,84 @@
+package controllers
+
+import (
+	"bytes"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"net/textproto"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+// pngHeader is the PNG signature followed by an IHDR chunk start, enough for
+// content sniffing.
+var pngHeader = []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR\x00\x00\x00\x01\x00\x00\x00\x01\x08\x02\x00\x00\x00")
+
+func postProductWithImage(t *testing.T, svc *fakeProductService, filename, contentType string, data []byte) *httptest.ResponseRecorder {
+	t.Helper()
+	return postProductWithImageTo(t, NewProductController(svc, newTestRedisClient()), filename, contentType, data)
+}
+
+func postProductWithImageTo(t *testing.T, controller *ProductController, filename, contentType string, data []byte) *httptest.ResponseRecorder {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+
+	var buf bytes.Buffer
+	mw := multipart.NewWriter(&buf)
+	for k, v := range map[string]string{
+		"name":        "Widget",
+		"description": "A thing",
+		"brand":       "Acme",
+		"sku":         "SKU-1",
+		"price":       "9.99",
+		"quantity":    "3",
+		"category":    `["Books"]`,
+	} {
+		_ = mw.WriteField(k, v)
+	}
+	h := make(textproto.MIMEHeader)
+	h.Set("Content-Disposition", `form-data; name="images"; filename="`+filename+`"`)
+	h.Set("Content-Type", contentType)
+	part, _ := mw.CreatePart(h)
+	_, _ = part.Write(data)
+	_ = mw.Close()
+
+	router := gin.New()
+	router.POST("/products", controller.CreateProduct)
+
+	req := httptest.NewRequest(http.MethodPost, "/products", &buf)
+	req.Header.Set("Content-Type", mw.FormDataContentType())
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestCreateProduct_RejectsSpoofedImage(t *testing.T) {
+	svc := &fakeProductService{}
+	// An ELF executable renamed to .png with a forged Content-Type
+	w := postProductWithImage(t, svc, "evil.png", "image/png", []byte("\x7fELF\x02\x01\x01\x00\x00\x00\x00\x00\x00\x00\x00\x00"))
+
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400 for spoofed image, got %d: %s", w.Code, w.Body.String())
+	}
+	if svc.createImages != nil {
+		t.Fatalf("expected service not to be called")
+	}
+}
+
+func TestCreateProduct_AcceptsRealImage(t *testing.T) {
+	svc := &fakeProductService{}
+	// Real PNG bytes, even though the client mislabels it
+	w := postProductWithImage(t, svc, "photo.bin", "application/octet-stream", pngHeader)
+
+	if w.Code != http.StatusCreated {
+		t.Fatalf("expected 201 for valid image, got %d: %s", w.Code, w.Body.String())
+	}
+	if len(svc.createImages) != 1 {
+		t.Fatalf("expected one image passed to service, got %d", len(svc.createImages))
+	}
+	if got := svc.createImages[0].Header.Get("Content-Type"); got != "image/png" {
+		t.Fatalf("expected Content-Type replaced with sniffed image/png, got %q", got)
+	}
+}
@@ -0,0 +1,69 @@
+package controllers
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yashrajoria/common/httputil"
+)
+
+// PageSizes bounds the perPage query parameter on every list endpoint.
+type PageSizes struct {
+	Default int // used when perPage is omitted
+	Max     int // larger requests are clamped to this
+}
+
+// DefaultPageSizes applies when PRODUCT_DEFAULT_PAGE_SIZE and
+// PRODUCT_MAX_PAGE_SIZE are unset.
+var DefaultPageSizes = PageSizes{Default: 10, Max: 100}
+
+var pageSizes = DefaultPageSizes
+
+// Validate checks that 1 <= Default <= Max.
+func (p PageSizes) Validate() error {
+	if p.Default < 1 {
+		return fmt.Errorf("default page size must be at least 1, got %d", p.Default)
+	}
+	if p.Max < p.Default {
+		return fmt.Errorf("max page size %d is below the default page size %d", p.Max, p.Default)
+	}
+	return nil
+}
+
+// SetPageSizes replaces the page size bounds used by all controllers.
+func SetPageSizes(p PageSizes) error {
+	if err := p.Validate(); err != nil {
+		return err
+	}
+	pageSizes = p
+	return nil
+}
+
+// parsePagination reads the page and perPage query parameters, applying the
+// configured default and clamping both to their maximums. It writes a 400 and
+// returns ok=false when either is not a positive integer.
+func parsePagination(c *gin.Context) (page, perPage int, ok bool) {
+	page, err := strconv.Atoi(c.DefaultQuery("page", "1"))
+	if err != nil || page < 1 {
+		httputil.RespondError(c, http.StatusBadRequest, "Invalid page number")
+		return 0, 0, false
+	}
+	if page > MaxPageNumber {
+		page = MaxPageNumber
+	}
+
+	perPage = pageSizes.Default
+	if raw, present := c.GetQuery("perPage"); present {
+		perPage, err = strconv.Atoi(raw)
+		if err != nil || perPage < 1 {
+			httputil.RespondError(c, http.StatusBadRequest, "Invalid page size")
+			return 0, 0, false
+		}
+	}
+	if perPage > pageSizes.Max {
+		perPage = pageSizes.Max
+	}
+	return page, perPage, true
+}
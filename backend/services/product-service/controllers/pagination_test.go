@@ -0,0 +1,97 @@
+package controllers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func withPageSizes(t *testing.T, p PageSizes) {
+	t.Helper()
+	prev := pageSizes
+	if err := SetPageSizes(p); err != nil {
+		t.Fatalf("set page sizes: %v", err)
+	}
+	t.Cleanup(func() { pageSizes = prev })
+}
+
+func TestGetProducts_PageSizeFromConfig(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	withPageSizes(t, PageSizes{Default: 25, Max: 40})
+
+	cases := []struct {
+		query string
+		want  int
+	}{
+		{query: "", want: 25},
+		{query: "?perPage=30", want: 30},
+		{query: "?perPage=500", want: 40},
+	}
+	for _, tc := range cases {
+		fakeService := &fakeProductService{}
+		controller := NewProductController(fakeService, newTestRedisClient())
+		router := gin.New()
+		router.GET("/products", controller.GetProducts)
+
+		recorder := httptest.NewRecorder()
+		router.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/products"+tc.query, nil))
+
+		if recorder.Code != http.StatusOK {
+			t.Fatalf("%q: expected status 200, got %d", tc.query, recorder.Code)
+		}
+		if got := fakeService.lastParams.PerPage; got != tc.want {
+			t.Fatalf("%q: expected perPage %d, got %d", tc.query, tc.want, got)
+		}
+	}
+}
+
+func TestParsePagination_SharedAcrossEndpoints(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	withPageSizes(t, PageSizes{Default: 5, Max: 20})
+
+	cases := []struct {
+		query      string
+		wantStatus int
+		wantPer    int
+	}{
+		{query: "", wantStatus: http.StatusOK, wantPer: 5},
+		{query: "?perPage=21", wantStatus: http.StatusOK, wantPer: 20},
+		{query: "?perPage=", wantStatus: http.StatusBadRequest},
+		{query: "?perPage=0", wantStatus: http.StatusBadRequest},
+	}
+	for _, tc := range cases {
+		var perPage int
+		router := gin.New()
+		router.GET("/list", func(c *gin.Context) {
+			_, pp, ok := parsePagination(c)
+			if !ok {
+				return
+			}
+			perPage = pp
+			c.Status(http.StatusOK)
+		})
+
+		recorder := httptest.NewRecorder()
+		router.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/list"+tc.query, nil))
+
+		if recorder.Code != tc.wantStatus {
+			t.Fatalf("%q: expected status %d, got %d", tc.query, tc.wantStatus, recorder.Code)
+		}
+		if tc.wantStatus == http.StatusOK && perPage != tc.wantPer {
+			t.Fatalf("%q: expected perPage %d, got %d", tc.query, tc.wantPer, perPage)
+		}
+	}
+}
+
+func TestSetPageSizes_RejectsInvalid(t *testing.T) {
+	for _, p := range []PageSizes{{Default: 0, Max: 10}, {Default: 50, Max: 20}} {
+		if err := SetPageSizes(p); err == nil {
+			t.Fatalf("expected error for %+v", p)
+		}
+	}
+	if pageSizes != DefaultPageSizes {
+		t.Fatalf("rejected sizes must not be applied, got %+v", pageSizes)
+	}
+}
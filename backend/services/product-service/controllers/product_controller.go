@@ -23,32 +23,39 @@ import (
 	"github.com/go-playground/validator/v10"
 	"github.com/go-redis/redis/v8"
 	"github.com/google/uuid"
+	apperrors "github.com/yashrajoria/common/errors"
+	"github.com/yashrajoria/common/httputil"
 	"go.uber.org/zap"
 )
 
-// ErrNotFound is returned when a resource is not found
-var ErrNotFound = errors.New("not found")
-
 // Use a single instance of Validate, it caches struct info
 var validate = validator.New()
 
 // Validation constants
 const (
-	MaxPageSize   = 100
 	MaxPageNumber = 1000000
 	MaxUploadSize = 50 * 1024 * 1024 // 50MB
+	MaxBulkDelete = 1000
+
+	DefaultRelatedLimit = 10
+	MaxRelatedLimit     = 50
 )
 
 type ProductServiceAPI interface {
 	GetProduct(ctx context.Context, id uuid.UUID) (*models.Product, error)
 	ListProducts(ctx context.Context, params services.ListProductsParams) ([]*models.Product, int64, error)
 	CreateProduct(ctx context.Context, req services.ProductCreateRequest, images []*multipart.FileHeader) (*models.Product, error)
-	UpdateProduct(ctx context.Context, id uuid.UUID, updates map[string]interface{}) (int64, error)
+	UpdateProduct(ctx context.Context, id uuid.UUID, updates map[string]interface{}, changedBy string) (int64, error)
+	PriceHistory(ctx context.Context, id uuid.UUID) ([]models.PriceChange, error)
+	RelatedProducts(ctx context.Context, id uuid.UUID, limit int) ([]*models.Product, error)
 	DeleteProduct(ctx context.Context, id uuid.UUID) (int64, error)
+	BulkDeleteProducts(ctx context.Context, ids []uuid.UUID) (int64, error)
 	GetProductInternal(ctx context.Context, id uuid.UUID) (*services.ProductInternalDTO, error)
+	HandleInventoryEvent(ctx context.Context, ev services.InventoryEvent) (services.InventoryEventResult, error)
 	ValidateBulkImport(ctx context.Context, file multipart.File) (*models.BulkImportValidation, error)
 	ProcessBulkImport(ctx context.Context, file multipart.File) (*models.BulkImportResult, error)
 	GeneratePresignedUpload(ctx context.Context, sku, filename, contentType string, expiresSeconds int64) (string, string, string, error)
+	PublicProducts(ctx context.Context, products []*models.Product, opts services.ProductDTOOptions) ([]services.ProductDTO, error)
 }
 
 // CreateProductRequest defines the expected structure for creating a product via multipart-form.
@@ -75,49 +82,105 @@ func NewProductController(ps ProductServiceAPI, redis *redis.Client) *ProductCon
 	}
 }
 
+// productCachePattern matches every cached product listing key.
+const productCachePattern = "products:*"
+
+// productListCacheTTL is how long a product listing stays cached when no
+// sale on it starts or ends sooner.
+const productListCacheTTL = 10 * time.Minute
+
+// listingCacheTTL caps productListCacheTTL at the first upcoming sale
+// boundary among products.
+func listingCacheTTL(products []*models.Product, now time.Time) time.Duration {
+	ttl := productListCacheTTL
+	for _, p := range products {
+		if next, ok := p.NextPriceChange(now); ok && next.Sub(now) < ttl {
+			ttl = next.Sub(now)
+		}
+	}
+	return ttl
+}
+
+// invalidateProductCache drops cached product listings after a mutation.
+// Keys are removed by pattern rather than FlushDB so other data in the same
+// Redis survives. Failures are logged only: stale entries still expire via
+// their TTL, and the mutation itself has already succeeded.
+func (ctrl *ProductController) invalidateProductCache(ctx context.Context) {
+	var cursor uint64
+	for {
+		keys, next, err := ctrl.redis.Scan(ctx, cursor, productCachePattern, 100).Result()
+		if err != nil {
+			zap.L().Error("failed to scan product cache keys", zap.Error(err))
+			return
+		}
+		if len(keys) > 0 {
+			if err := ctrl.redis.Del(ctx, keys...).Err(); err != nil {
+				zap.L().Error("failed to invalidate product cache", zap.Error(err), zap.Int("keys", len(keys)))
+				return
+			}
+		}
+		if next == 0 {
+			return
+		}
+		cursor = next
+	}
+}
+
 func (ctrl *ProductController) GetProductByID(c *gin.Context) {
 	id := c.Param("id")
 	productID, err := uuid.Parse(id)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid UUID format"})
+		httputil.RespondError(c, http.StatusBadRequest, "Invalid UUID format")
+		return
+	}
+	expand, ok := parseExpand(c)
+	if !ok {
 		return
 	}
 
 	product, err := ctrl.productService.GetProduct(c.Request.Context(), productID)
 	if err != nil {
-		if errors.Is(err, ErrNotFound) || strings.Contains(err.Error(), "not found") {
-			c.JSON(http.StatusNotFound, gin.H{"error": "Product not found"})
+		if errors.Is(err, apperrors.ErrNotFound) {
+			httputil.RespondError(c, http.StatusNotFound, "Product not found")
 			return
 		}
 		zap.L().Error("Service failed to get product", zap.Error(err), zap.String("id", id))
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal Server Error"})
+		httputil.RespondError(c, http.StatusInternalServerError, "Internal Server Error")
+		return
+	}
+	if userID := c.GetHeader("X-User-ID"); userID != "" {
+		// Losing a view is preferable to failing the read
+		if err := ctrl.recordView(c.Request.Context(), userID, productID); err != nil {
+			zap.L().Warn("failed to record product view", zap.Error(err), zap.String("id", id))
+		}
+	}
+	etag := productETag(product)
+	if expand.ExpandCategories {
+		// The expanded body is a different representation of the same version
+		etag = etagFor([]byte(etag + ":expand=categories"))
+	}
+	if checkNotModified(c, etag) {
+		return
+	}
+	dtos, err := ctrl.productService.PublicProducts(c.Request.Context(), []*models.Product{product}, expand)
+	if err != nil {
+		zap.L().Error("Service failed to build product response", zap.Error(err), zap.String("id", id))
+		httputil.RespondError(c, http.StatusInternalServerError, "Internal Server Error")
 		return
 	}
-	c.JSON(http.StatusOK, product)
+	c.JSON(http.StatusOK, dtos[0])
 }
 
 func (ctrl *ProductController) GetProducts(c *gin.Context) {
 	// 1. Parse Parameters with validation
-	pageStr := c.DefaultQuery("page", "1")
-	perPageStr := c.DefaultQuery("perPage", "10")
-
-	page, err := strconv.Atoi(pageStr)
-	if err != nil || page < 1 {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid page number"})
+	page, perPage, ok := parsePagination(c)
+	if !ok {
 		return
 	}
-	if page > MaxPageNumber {
-		page = MaxPageNumber
-	}
-
-	perPage, err := strconv.Atoi(perPageStr)
-	if err != nil || perPage < 1 {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid page size"})
+	expand, ok := parseExpand(c)
+	if !ok {
 		return
 	}
-	if perPage > MaxPageSize {
-		perPage = MaxPageSize
-	}
 
 	// Parse filters for the Cache Key
 	isFeatured := c.Query("is_featured")
@@ -135,14 +198,14 @@ func (ctrl *ProductController) GetProducts(c *gin.Context) {
 			}
 			categoryUUID, err := uuid.Parse(trimmed)
 			if err != nil {
-				c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid category ID format"})
+				httputil.RespondError(c, http.StatusBadRequest, "Invalid category ID format")
 				return
 			}
 			categoryIDs = append(categoryIDs, categoryUUID)
 			categoryIDStrings = append(categoryIDStrings, categoryUUID.String())
 		}
 		if len(categoryIDs) == 0 {
-			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid category ID format"})
+			httputil.RespondError(c, http.StatusBadRequest, "Invalid category ID format")
 			return
 		}
 		sort.Strings(categoryIDStrings)
@@ -152,7 +215,7 @@ func (ctrl *ProductController) GetProducts(c *gin.Context) {
 	sortParam := strings.TrimSpace(c.Query("sort"))
 	normalizedSortParam := strings.ToLower(sortParam)
 	if sortParam != "" && !isSupportedSort(sortParam) {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid sort value"})
+		httputil.RespondError(c, http.StatusBadRequest, "Invalid sort value")
 		return
 	}
 
@@ -161,7 +224,7 @@ func (ctrl *ProductController) GetProducts(c *gin.Context) {
 	if minPriceStr != "" {
 		parsed, err := strconv.ParseFloat(minPriceStr, 64)
 		if err != nil {
-			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid minPrice value"})
+			httputil.RespondError(c, http.StatusBadRequest, "Invalid minPrice value")
 			return
 		}
 		minPrice = &parsed
@@ -172,21 +235,34 @@ func (ctrl *ProductController) GetProducts(c *gin.Context) {
 	if maxPriceStr != "" {
 		parsed, err := strconv.ParseFloat(maxPriceStr, 64)
 		if err != nil {
-			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid maxPrice value"})
+			httputil.RespondError(c, http.StatusBadRequest, "Invalid maxPrice value")
 			return
 		}
 		maxPrice = &parsed
 	}
 
 	if minPrice != nil && maxPrice != nil && *minPrice > *maxPrice {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "minPrice must be less than or equal to maxPrice"})
+		httputil.RespondError(c, http.StatusBadRequest, "minPrice must be less than or equal to maxPrice")
 		return
 	}
 
+	brand := strings.TrimSpace(c.Query("brand"))
+
+	var inStock *bool
+	inStockStr := strings.TrimSpace(c.Query("in_stock"))
+	if inStockStr != "" {
+		parsed, err := strconv.ParseBool(inStockStr)
+		if err != nil {
+			httputil.RespondError(c, http.StatusBadRequest, "Invalid boolean value for 'in_stock'")
+			return
+		}
+		inStock = &parsed
+	}
+
 	// 2. GENERATE A UNIQUE CACHE KEY
 	// The key MUST include every variable that changes the output
 	cacheKey := fmt.Sprintf(
-		"products:p:%d:l:%d:f:%s:c:%s:s:%s:min:%s:max:%s",
+		"products:p:%d:l:%d:f:%s:c:%s:s:%s:min:%s:max:%s:b:%s:stock:%s:x:%t",
 		page,
 		perPage,
 		normalizedIsFeatured,
@@ -194,6 +270,9 @@ func (ctrl *ProductController) GetProducts(c *gin.Context) {
 		normalizedSortParam,
 		formatFloatForCache(minPrice),
 		formatFloatForCache(maxPrice),
+		strings.ToLower(brand),
+		formatBoolForCache(inStock),
+		expand.ExpandCategories,
 	)
 
 	// 3. TRY TO GET FROM REDIS
@@ -206,7 +285,12 @@ func (ctrl *ProductController) GetProducts(c *gin.Context) {
 		// Unmarshal the JSON string back into a Go map/struct
 		if err := json.Unmarshal([]byte(val), &cachedResponse); err == nil {
 			zap.L().Info("Returning data from Redis Cache")
-			c.JSON(http.StatusOK, cachedResponse)
+			// The cached bytes are exactly what a miss would have served,
+			// so the ETag stays stable across hits and misses
+			if checkNotModified(c, etagFor([]byte(val))) {
+				return
+			}
+			c.Data(http.StatusOK, "application/json; charset=utf-8", []byte(val))
 			return // <--- RETURN IMMEDIATELY, SKIP DB
 		}
 	} else if err != redis.Nil {
@@ -226,7 +310,7 @@ func (ctrl *ProductController) GetProducts(c *gin.Context) {
 	if isFeaturedStr := c.Query("is_featured"); isFeaturedStr != "" {
 		isFeatured, err := strconv.ParseBool(isFeaturedStr)
 		if err != nil {
-			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid boolean value for 'is_featured'"})
+			httputil.RespondError(c, http.StatusBadRequest, "Invalid boolean value for 'is_featured'")
 			return
 		}
 		params.IsFeatured = &isFeatured
@@ -241,10 +325,17 @@ func (ctrl *ProductController) GetProducts(c *gin.Context) {
 	if maxPrice != nil {
 		params.MaxPrice = maxPrice
 	}
+	params.Brand = brand
+	params.InStock = inStock
 
 	products, total, err := ctrl.productService.ListProducts(c.Request.Context(), params)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch products"})
+		httputil.RespondError(c, http.StatusInternalServerError, "Failed to fetch products")
+		return
+	}
+	dtos, err := ctrl.productService.PublicProducts(c.Request.Context(), products, expand)
+	if err != nil {
+		httputil.RespondError(c, http.StatusInternalServerError, "Failed to fetch products")
 		return
 	}
 
@@ -252,7 +343,7 @@ func (ctrl *ProductController) GetProducts(c *gin.Context) {
 
 	// Construct Response
 	response := gin.H{
-		"products": products,
+		"products": dtos,
 		"meta": gin.H{
 			"page":       page,
 			"perPage":    perPage,
@@ -264,42 +355,54 @@ func (ctrl *ProductController) GetProducts(c *gin.Context) {
 	// 4. SAVE TO REDIS (Serialize to JSON)
 	// We store the whole response so we can return it instantly next time
 	jsonBytes, err := json.Marshal(response)
-	if err == nil {
-		// Set TTL to 10 minutes (or whatever fits your needs)
-		if err := ctrl.redis.Set(c.Request.Context(), cacheKey, jsonBytes, 10*time.Minute).Err(); err != nil {
+	if err != nil {
+		c.JSON(http.StatusOK, response)
+		return
+	}
+	// The body carries effective prices, so it must expire no later than the
+	// next sale boundary of any product on the page
+	if ttl := listingCacheTTL(products, time.Now()); ttl >= time.Second {
+		if err := ctrl.redis.Set(c.Request.Context(), cacheKey, jsonBytes, ttl).Err(); err != nil {
 			zap.L().Error("failed to cache products response in Redis", zap.Error(err), zap.String("cacheKey", cacheKey))
 		}
 	}
 
-	c.JSON(http.StatusOK, response)
+	if checkNotModified(c, etagFor(jsonBytes)) {
+		return
+	}
+	c.Data(http.StatusOK, "application/json; charset=utf-8", jsonBytes)
 }
 
 func (ctrl *ProductController) CreateProduct(c *gin.Context) {
 	var req CreateProductRequest
 	if err := c.ShouldBind(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid form data", "details": err.Error()})
+		httputil.RespondErrorDetails(c, http.StatusBadRequest, "Invalid form data", err.Error())
 		return
 	}
 
 	if err := validate.Struct(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Validation failed", "details": err.Error()})
+		httputil.RespondErrorDetails(c, http.StatusBadRequest, "Validation failed", fieldErrors(err))
 		return
 	}
 
 	var categoryNames []string
 	if err := json.Unmarshal([]byte(req.Categories), &categoryNames); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid category format, must be a JSON string array"})
+		httputil.RespondError(c, http.StatusBadRequest, "Invalid category format, must be a JSON string array")
 		return
 	}
 
 	form, err := c.MultipartForm()
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Expected multipart form data"})
+		httputil.RespondError(c, http.StatusBadRequest, "Expected multipart form data")
 		return
 	}
 	images := form.File["images"]
 	if len(images) == 0 {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "At least one image is required"})
+		httputil.RespondError(c, http.StatusBadRequest, "At least one image is required")
+		return
+	}
+	if errs := validateImageUploads(images); len(errs) > 0 {
+		httputil.RespondErrorDetails(c, http.StatusBadRequest, "Invalid image upload", errs)
 		return
 	}
 
@@ -316,18 +419,19 @@ func (ctrl *ProductController) CreateProduct(c *gin.Context) {
 
 	product, err := ctrl.productService.CreateProduct(c.Request.Context(), serviceReq, images)
 	if err != nil {
+		if errors.Is(err, services.ErrNoImagesUploaded) {
+			zap.L().Error("No product images could be uploaded", zap.Error(err))
+			httputil.RespondError(c, http.StatusBadGateway, "Failed to upload product images")
+			return
+		}
 		zap.L().Error("Service failed to create product", zap.Error(err))
 		// You can add more specific error checks here (e.g., for duplicate SKU)
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create product"})
+		httputil.RespondError(c, http.StatusInternalServerError, "Failed to create product")
 		return
 	}
 
 	// Invalidate cache after creating a product
-	// WARNING: FlushDB clears the ENTIRE Redis instance. Use specific key invalidation in production.
-	// TODO: Implement pattern-based deletion (e.g. SCAN for "products:*") or versioning.
-	// if err := ctrl.redis.FlushDB(c.Request.Context()).Err(); err != nil {
-	// 	zap.L().Error("failed to invalidate cache after product creation", zap.Error(err))
-	// }
+	ctrl.invalidateProductCache(c.Request.Context())
 
 	c.JSON(http.StatusCreated, product)
 }
@@ -336,91 +440,184 @@ func (ctrl *ProductController) UpdateProduct(c *gin.Context) {
 	id := c.Param("id")
 	productID, err := uuid.Parse(id)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid UUID format"})
+		httputil.RespondError(c, http.StatusBadRequest, "Invalid UUID format")
 		return
 	}
 
 	var updates map[string]interface{}
 	if err := c.ShouldBindJSON(&updates); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid JSON body"})
+		httputil.RespondError(c, http.StatusBadRequest, "Invalid JSON body")
 		return
 	}
 
-	modifiedCount, err := ctrl.productService.UpdateProduct(c.Request.Context(), productID, updates)
+	// Set by the gateway from the verified JWT; recorded against price changes
+	changedBy := c.GetHeader("X-User-ID")
+
+	modifiedCount, err := ctrl.productService.UpdateProduct(c.Request.Context(), productID, updates, changedBy)
 	if err != nil {
+		if errors.Is(err, services.ErrInvalidSale) {
+			httputil.RespondError(c, http.StatusBadRequest, err.Error())
+			return
+		}
 		zap.L().Error("Service failed to update product", zap.Error(err))
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update product"})
+		httputil.RespondError(c, http.StatusInternalServerError, "Failed to update product")
 		return
 	}
 	if modifiedCount == 0 {
-		c.JSON(http.StatusNotFound, gin.H{"error": "Product not found or no changes made"})
+		httputil.RespondError(c, http.StatusNotFound, "Product not found or no changes made")
 		return
 	}
 
 	// Invalidate cache after updating a product
-	// if err := ctrl.redis.FlushDB(c.Request.Context()).Err(); err != nil {
-	// 	zap.L().Error("failed to invalidate cache after product update", zap.Error(err))
-	// }
+	ctrl.invalidateProductCache(c.Request.Context())
 
 	c.JSON(http.StatusOK, gin.H{"message": "Product updated successfully"})
 }
 
+// GetPriceHistory lists a product's price changes, newest first.
+func (ctrl *ProductController) GetPriceHistory(c *gin.Context) {
+	id := c.Param("id")
+	productID, err := uuid.Parse(id)
+	if err != nil {
+		httputil.RespondError(c, http.StatusBadRequest, "Invalid UUID format")
+		return
+	}
+
+	history, err := ctrl.productService.PriceHistory(c.Request.Context(), productID)
+	if err != nil {
+		if errors.Is(err, apperrors.ErrNotFound) {
+			httputil.RespondError(c, http.StatusNotFound, "Product not found")
+			return
+		}
+		zap.L().Error("Service failed to get price history", zap.Error(err), zap.String("id", id))
+		httputil.RespondError(c, http.StatusInternalServerError, "Failed to fetch price history")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"product_id": productID, "history": history})
+}
+
+// GetRelatedProducts returns products sharing the most categories with the
+// given product, at most limit (default DefaultRelatedLimit).
+func (ctrl *ProductController) GetRelatedProducts(c *gin.Context) {
+	id := c.Param("id")
+	productID, err := uuid.Parse(id)
+	if err != nil {
+		httputil.RespondError(c, http.StatusBadRequest, "Invalid UUID format")
+		return
+	}
+	limit := DefaultRelatedLimit
+	if raw, present := c.GetQuery("limit"); present {
+		limit, err = strconv.Atoi(raw)
+		if err != nil || limit < 1 {
+			httputil.RespondError(c, http.StatusBadRequest, "Invalid limit")
+			return
+		}
+		limit = min(limit, MaxRelatedLimit)
+	}
+	expand, ok := parseExpand(c)
+	if !ok {
+		return
+	}
+
+	related, err := ctrl.productService.RelatedProducts(c.Request.Context(), productID, limit)
+	if err != nil {
+		if errors.Is(err, apperrors.ErrNotFound) {
+			httputil.RespondError(c, http.StatusNotFound, "Product not found")
+			return
+		}
+		zap.L().Error("Service failed to get related products", zap.Error(err), zap.String("id", id))
+		httputil.RespondError(c, http.StatusInternalServerError, "Failed to fetch related products")
+		return
+	}
+	dtos, err := ctrl.productService.PublicProducts(c.Request.Context(), related, expand)
+	if err != nil {
+		zap.L().Error("Service failed to build product response", zap.Error(err), zap.String("id", id))
+		httputil.RespondError(c, http.StatusInternalServerError, "Internal Server Error")
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"products": dtos})
+}
+
 func (ctrl *ProductController) DeleteProduct(c *gin.Context) {
 	id := c.Param("id")
 	productID, err := uuid.Parse(id)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid UUID format"})
+		httputil.RespondError(c, http.StatusBadRequest, "Invalid UUID format")
 		return
 	}
 
 	modifiedCount, err := ctrl.productService.DeleteProduct(c.Request.Context(), productID)
 	if err != nil {
 		zap.L().Error("Service failed to delete product", zap.Error(err))
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete product"})
+		httputil.RespondError(c, http.StatusInternalServerError, "Failed to delete product")
 		return
 	}
 	if modifiedCount == 0 {
-		c.JSON(http.StatusNotFound, gin.H{"error": "Product not found"})
+		httputil.RespondError(c, http.StatusNotFound, "Product not found")
 		return
 	}
 
 	// Invalidate cache after deleting a product
-	// if err := ctrl.redis.FlushDB(c.Request.Context()).Err(); err != nil {
-	// 	zap.L().Error("failed to invalidate cache after product deletion", zap.Error(err))
-	// }
+	ctrl.invalidateProductCache(c.Request.Context())
 
 	c.JSON(http.StatusOK, gin.H{"message": "Product deleted successfully"})
 }
 
+// BulkDeleteProducts deletes several products in one request
+func (ctrl *ProductController) BulkDeleteProducts(c *gin.Context) {
+	var req struct {
+		IDs []uuid.UUID `json:"ids"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		httputil.RespondError(c, http.StatusBadRequest, "Invalid JSON body")
+		return
+	}
+	if len(req.IDs) == 0 {
+		httputil.RespondError(c, http.StatusBadRequest, "ids must not be empty")
+		return
+	}
+	if len(req.IDs) > MaxBulkDelete {
+		httputil.RespondError(c, http.StatusBadRequest, fmt.Sprintf("at most %d ids per request", MaxBulkDelete))
+		return
+	}
+
+	deleted, err := ctrl.productService.BulkDeleteProducts(c.Request.Context(), req.IDs)
+	if err != nil {
+		zap.L().Error("Service failed to bulk delete products", zap.Error(err), zap.Int("count", len(req.IDs)))
+		httputil.RespondError(c, http.StatusInternalServerError, "Failed to delete products")
+		return
+	}
+	if deleted > 0 {
+		ctrl.invalidateProductCache(c.Request.Context())
+	}
+
+	c.JSON(http.StatusOK, gin.H{"deleted_count": deleted})
+}
+
 // ValidateBulkImport validates CSV before import
 func (ctrl *ProductController) ValidateBulkImport(c *gin.Context) {
 	file, err := c.FormFile("file")
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error": "File is required",
-		})
+		httputil.RespondError(c, http.StatusBadRequest, "File is required")
 		return
 	}
 
 	if file.Size > MaxUploadSize {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "File too large (max 50MB)"})
+		httputil.RespondError(c, http.StatusBadRequest, "File too large (max 50MB)")
 		return
 	}
 
 	fileHandle, err := file.Open()
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error": "Failed to open file",
-		})
+		httputil.RespondError(c, http.StatusInternalServerError, "Failed to open file")
 		return
 	}
 	defer fileHandle.Close()
 
 	validation, err := ctrl.productService.ValidateBulkImport(c.Request.Context(), fileHandle)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error": err.Error(),
-		})
+		httputil.RespondError(c, http.StatusInternalServerError, err.Error())
 		return
 	}
 
@@ -431,33 +628,30 @@ func (ctrl *ProductController) ValidateBulkImport(c *gin.Context) {
 func (ctrl *ProductController) CreateBulkProducts(c *gin.Context) {
 	file, err := c.FormFile("file")
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error": "File is required",
-		})
+		httputil.RespondError(c, http.StatusBadRequest, "File is required")
 		return
 	}
 
 	if file.Size > MaxUploadSize {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "File too large (max 50MB)"})
+		httputil.RespondError(c, http.StatusBadRequest, "File too large (max 50MB)")
 		return
 	}
 
 	fileHandle, err := file.Open()
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error": "Failed to open file",
-		})
+		httputil.RespondError(c, http.StatusInternalServerError, "Failed to open file")
 		return
 	}
 	defer fileHandle.Close()
 
 	result, err := ctrl.productService.ProcessBulkImport(c.Request.Context(), fileHandle)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error": err.Error(),
-		})
+		httputil.RespondError(c, http.StatusInternalServerError, err.Error())
 		return
 	}
+	if result.InsertedCount > 0 {
+		ctrl.invalidateProductCache(c.Request.Context())
+	}
 
 	c.JSON(http.StatusOK, result)
 }
@@ -466,7 +660,7 @@ func (ctrl *ProductController) CreateBulkProducts(c *gin.Context) {
 func (ctrl *ProductController) GetPresignUpload(c *gin.Context) {
 	sku := c.Query("sku")
 	if strings.TrimSpace(sku) == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "sku query parameter is required"})
+		httputil.RespondError(c, http.StatusBadRequest, "sku query parameter is required")
 		return
 	}
 
@@ -481,7 +675,7 @@ func (ctrl *ProductController) GetPresignUpload(c *gin.Context) {
 	uploadURL, key, publicURL, err := ctrl.productService.GeneratePresignedUpload(c.Request.Context(), sku, filename, contentType, expires)
 	if err != nil {
 		zap.L().Error("failed to generate presigned upload", zap.Error(err))
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to generate presigned upload"})
+		httputil.RespondError(c, http.StatusInternalServerError, "Failed to generate presigned upload")
 		return
 	}
 
@@ -498,19 +692,19 @@ func (ctrl *ProductController) PostPresignUpload(c *gin.Context) {
 	id := c.Param("id")
 	productID, err := uuid.Parse(id)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid UUID format"})
+		httputil.RespondError(c, http.StatusBadRequest, "Invalid UUID format")
 		return
 	}
 
 	// ensure product exists
 	_, err = ctrl.productService.GetProduct(c.Request.Context(), productID)
 	if err != nil {
-		if errors.Is(err, ErrNotFound) || strings.Contains(err.Error(), "not found") {
-			c.JSON(http.StatusNotFound, gin.H{"error": "Product not found"})
+		if errors.Is(err, apperrors.ErrNotFound) {
+			httputil.RespondError(c, http.StatusNotFound, "Product not found")
 			return
 		}
 		zap.L().Error("Service failed to get product", zap.Error(err), zap.String("id", id))
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal Server Error"})
+		httputil.RespondError(c, http.StatusInternalServerError, "Internal Server Error")
 		return
 	}
 
@@ -526,7 +720,7 @@ func (ctrl *ProductController) PostPresignUpload(c *gin.Context) {
 	cfg, err := aws_pkg.LoadAWSConfig(c.Request.Context())
 	if err != nil {
 		zap.L().Error("failed to load aws config", zap.Error(err))
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "AWS config error"})
+		httputil.RespondError(c, http.StatusInternalServerError, "AWS config error")
 		return
 	}
 
@@ -539,7 +733,7 @@ func (ctrl *ProductController) PostPresignUpload(c *gin.Context) {
 	url, _, err := aws_pkg.GeneratePresignedPutURL(c.Request.Context(), cfg, bucket, key, expires)
 	if err != nil {
 		zap.L().Error("failed to generate presigned url", zap.Error(err))
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to generate presigned upload"})
+		httputil.RespondError(c, http.StatusInternalServerError, "Failed to generate presigned upload")
 		return
 	}
 
@@ -550,18 +744,18 @@ func (ctrl *ProductController) GetProductByIDInternal(c *gin.Context) {
 	id := c.Param("id")
 	productID, err := uuid.Parse(id)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid UUID format"})
+		httputil.RespondError(c, http.StatusBadRequest, "Invalid UUID format")
 		return
 	}
 
 	productDTO, err := ctrl.productService.GetProductInternal(c.Request.Context(), productID)
 	if err != nil {
-		if errors.Is(err, ErrNotFound) || strings.Contains(err.Error(), "not found") {
-			c.JSON(http.StatusNotFound, gin.H{"error": "Product not found"})
+		if errors.Is(err, apperrors.ErrNotFound) {
+			httputil.RespondError(c, http.StatusNotFound, "Product not found")
 			return
 		}
 		zap.L().Error("Service failed to get internal product", zap.Error(err), zap.String("id", id))
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal Server Error"})
+		httputil.RespondError(c, http.StatusInternalServerError, "Internal Server Error")
 		return
 	}
 
@@ -577,9 +771,51 @@ func isSupportedSort(sortParam string) bool {
 	}
 }
 
+func formatBoolForCache(value *bool) string {
+	if value == nil {
+		return ""
+	}
+	return strconv.FormatBool(*value)
+}
+
 func formatFloatForCache(value *float64) string {
 	if value == nil {
 		return ""
 	}
 	return strconv.FormatFloat(*value, 'f', -1, 64)
 }
+
+// HandleInventoryEvent applies a low_stock or out_of_stock event from
+// inventory-service to the product. It serves POST /internal/inventory-events,
+// which nothing produces yet; see routes.RegisterRoutes.
+func (ctrl *ProductController) HandleInventoryEvent(c *gin.Context) {
+	var ev services.InventoryEvent
+	if err := c.ShouldBindJSON(&ev); err != nil {
+		httputil.RespondErrorDetails(c, http.StatusBadRequest, "Invalid inventory event", err.Error())
+		return
+	}
+	if ev.ProductID == uuid.Nil {
+		httputil.RespondError(c, http.StatusBadRequest, "product_id is required")
+		return
+	}
+
+	result, err := ctrl.productService.HandleInventoryEvent(c.Request.Context(), ev)
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrUnknownInventoryEvent):
+			httputil.RespondError(c, http.StatusBadRequest, err.Error())
+		case errors.Is(err, apperrors.ErrNotFound):
+			httputil.RespondError(c, http.StatusNotFound, "Product not found")
+		default:
+			zap.L().Error("Service failed to handle inventory event", zap.Error(err),
+				zap.String("event", ev.Event), zap.String("product_id", ev.ProductID.String()))
+			httputil.RespondError(c, http.StatusInternalServerError, "Internal Server Error")
+		}
+		return
+	}
+
+	if result.Changed() {
+		ctrl.invalidateProductCache(c.Request.Context())
+	}
+	c.JSON(http.StatusOK, result)
+}
@@ -26,7 +26,19 @@ func (n *noopProductService) ListProducts(ctx context.Context, params services.L
 func (n *noopProductService) CreateProduct(ctx context.Context, req services.ProductCreateRequest, images []*multipart.FileHeader) (*models.Product, error) {
 	return nil, nil
 }
-func (n *noopProductService) UpdateProduct(ctx context.Context, id uuid.UUID, updates map[string]interface{}) (int64, error) {
+func (n *noopProductService) UpdateProduct(ctx context.Context, id uuid.UUID, updates map[string]interface{}, changedBy string) (int64, error) {
+	return 0, nil
+}
+func (n *noopProductService) PriceHistory(ctx context.Context, id uuid.UUID) ([]models.PriceChange, error) {
+	return nil, nil
+}
+func (n *noopProductService) RelatedProducts(ctx context.Context, id uuid.UUID, limit int) ([]*models.Product, error) {
+	return nil, nil
+}
+func (n *noopProductService) HandleInventoryEvent(ctx context.Context, ev services.InventoryEvent) (services.InventoryEventResult, error) {
+	return services.InventoryEventResult{}, nil
+}
+func (n *noopProductService) BulkDeleteProducts(ctx context.Context, ids []uuid.UUID) (int64, error) {
 	return 0, nil
 }
 func (n *noopProductService) DeleteProduct(ctx context.Context, id uuid.UUID) (int64, error) {
@@ -44,6 +56,13 @@ func (n *noopProductService) ProcessBulkImport(ctx context.Context, file multipa
 func (n *noopProductService) GeneratePresignedUpload(ctx context.Context, sku, filename, contentType string, expiresSeconds int64) (string, string, string, error) {
 	return "", "", "", nil
 }
+func (n *noopProductService) PublicProducts(ctx context.Context, products []*models.Product, opts services.ProductDTOOptions) ([]services.ProductDTO, error) {
+	dtos := make([]services.ProductDTO, 0, len(products))
+	for _, p := range products {
+		dtos = append(dtos, services.NewProductDTO(p, nil))
+	}
+	return dtos, nil
+}
 
 func TestPostPresignUpload_InvalidUUID(t *testing.T) {
 	gin.SetMode(gin.TestMode)
@@ -15,16 +15,33 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/go-redis/redis/v8"
 	"github.com/google/uuid"
+	apperrors "github.com/yashrajoria/common/errors"
 )
 
 type fakeProductService struct {
 	lastParams         services.ListProductsParams
 	listProductsCalled int
 	listProductsFn     func(ctx context.Context, params services.ListProductsParams) ([]*models.Product, int64, error)
+	createImages       []*multipart.FileHeader
+	modifiedCount      int64
+	product            *models.Product
+	products           map[uuid.UUID]*models.Product // looked up by ID when set
+	related            []*models.Product
+	lastRelatedLimit   int
+	lastDTOOptions     services.ProductDTOOptions
 }
 
 func (f *fakeProductService) GetProduct(ctx context.Context, id uuid.UUID) (*models.Product, error) {
-	return nil, nil
+	if f.products != nil {
+		if p, ok := f.products[id]; ok {
+			return p, nil
+		}
+		return nil, apperrors.ErrNotFound
+	}
+	if f.product == nil {
+		return nil, apperrors.ErrNotFound
+	}
+	return f.product, nil
 }
 
 func (f *fakeProductService) ListProducts(ctx context.Context, params services.ListProductsParams) ([]*models.Product, int64, error) {
@@ -37,15 +54,36 @@ func (f *fakeProductService) ListProducts(ctx context.Context, params services.L
 }
 
 func (f *fakeProductService) CreateProduct(ctx context.Context, req services.ProductCreateRequest, images []*multipart.FileHeader) (*models.Product, error) {
+	f.createImages = images
+	return &models.Product{Name: req.Name}, nil
+}
+
+func (f *fakeProductService) UpdateProduct(ctx context.Context, id uuid.UUID, updates map[string]interface{}, changedBy string) (int64, error) {
+	return f.modifiedCount, nil
+}
+
+func (f *fakeProductService) PriceHistory(ctx context.Context, id uuid.UUID) ([]models.PriceChange, error) {
 	return nil, nil
 }
 
-func (f *fakeProductService) UpdateProduct(ctx context.Context, id uuid.UUID, updates map[string]interface{}) (int64, error) {
+func (f *fakeProductService) HandleInventoryEvent(ctx context.Context, ev services.InventoryEvent) (services.InventoryEventResult, error) {
+	return services.InventoryEventResult{}, nil
+}
+
+func (f *fakeProductService) RelatedProducts(ctx context.Context, id uuid.UUID, limit int) ([]*models.Product, error) {
+	f.lastRelatedLimit = limit
+	if _, err := f.GetProduct(ctx, id); err != nil {
+		return nil, err
+	}
+	return f.related, nil
+}
+
+func (f *fakeProductService) BulkDeleteProducts(ctx context.Context, ids []uuid.UUID) (int64, error) {
 	return 0, nil
 }
 
 func (f *fakeProductService) DeleteProduct(ctx context.Context, id uuid.UUID) (int64, error) {
-	return 0, nil
+	return f.modifiedCount, nil
 }
 
 func (f *fakeProductService) GetProductInternal(ctx context.Context, id uuid.UUID) (*services.ProductInternalDTO, error) {
@@ -64,6 +102,15 @@ func (f *fakeProductService) GeneratePresignedUpload(ctx context.Context, sku, f
 	return "", "", "", nil
 }
 
+func (f *fakeProductService) PublicProducts(ctx context.Context, products []*models.Product, opts services.ProductDTOOptions) ([]services.ProductDTO, error) {
+	f.lastDTOOptions = opts
+	dtos := make([]services.ProductDTO, 0, len(products))
+	for _, p := range products {
+		dtos = append(dtos, services.NewProductDTO(p, nil))
+	}
+	return dtos, nil
+}
+
 func newTestRedisClient() *redis.Client {
 	return redis.NewClient(&redis.Options{
 		Addr: "localhost:0",
@@ -167,3 +214,29 @@ func TestGetProductsInvalidCategoryID(t *testing.T) {
 		t.Fatalf("expected list products not to be called, got %d", fakeService.listProductsCalled)
 	}
 }
+
+func TestGetProducts_BrandAndInStockFilters(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	fakeService := &fakeProductService{}
+	controller := NewProductController(fakeService, newTestRedisClient())
+	router := gin.New()
+	router.GET("/products", controller.GetProducts)
+
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/products?brand=%20Acme%20&in_stock=true", nil))
+
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d", http.StatusOK, recorder.Code)
+	}
+	params := fakeService.lastParams
+	if params.Brand != "Acme" || params.InStock == nil || !*params.InStock {
+		t.Fatalf("expected brand=Acme in_stock=true, got brand=%q in_stock=%v", params.Brand, params.InStock)
+	}
+
+	recorder = httptest.NewRecorder()
+	router.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/products?in_stock=maybe", nil))
+	if recorder.Code != http.StatusBadRequest {
+		t.Fatalf("expected status %d for invalid in_stock, got %d", http.StatusBadRequest, recorder.Code)
+	}
+}
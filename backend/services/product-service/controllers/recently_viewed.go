@@ -0,0 +1,126 @@
+package controllers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"product-service/models"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-redis/redis/v8"
+	"github.com/google/uuid"
+	apperrors "github.com/yashrajoria/common/errors"
+	"github.com/yashrajoria/common/httputil"
+	"go.uber.org/zap"
+)
+
+// DefaultRecentlyViewedLimit applies when RECENTLY_VIEWED_LIMIT is unset.
+const DefaultRecentlyViewedLimit = 20
+
+// recentlyViewedTTL lets the lists of users who stop browsing expire.
+const recentlyViewedTTL = 30 * 24 * time.Hour
+
+var recentlyViewedLimit = DefaultRecentlyViewedLimit
+
+// SetRecentlyViewedLimit sets how many distinct products are kept per user.
+func SetRecentlyViewedLimit(n int) error {
+	if n < 1 {
+		return fmt.Errorf("recently viewed limit must be at least 1, got %d", n)
+	}
+	recentlyViewedLimit = n
+	return nil
+}
+
+// recentlyViewedKey is outside productCachePattern so cache invalidation
+// leaves it alone.
+func recentlyViewedKey(userID string) string {
+	return "recently_viewed:" + userID
+}
+
+// recordView moves productID to the front of the user's recently viewed
+// list, dropping any earlier view of it and anything past the limit.
+func (ctrl *ProductController) recordView(ctx context.Context, userID string, productID uuid.UUID) error {
+	key := recentlyViewedKey(userID)
+	id := productID.String()
+	_, err := ctrl.redis.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.LRem(ctx, key, 0, id)
+		pipe.LPush(ctx, key, id)
+		pipe.LTrim(ctx, key, 0, int64(recentlyViewedLimit-1))
+		pipe.Expire(ctx, key, recentlyViewedTTL)
+		return nil
+	})
+	return err
+}
+
+// recentlyViewedIDs returns up to n product IDs, most recently viewed first.
+func (ctrl *ProductController) recentlyViewedIDs(ctx context.Context, userID string, n int) ([]uuid.UUID, error) {
+	raw, err := ctrl.redis.LRange(ctx, recentlyViewedKey(userID), 0, int64(n-1)).Result()
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]uuid.UUID, 0, len(raw))
+	for _, s := range raw {
+		if id, err := uuid.Parse(s); err == nil {
+			ids = append(ids, id)
+		}
+	}
+	return ids, nil
+}
+
+// GetRecentlyViewed returns the caller's most recently viewed products, most
+// recent first. Products deleted since they were viewed are left out.
+func (ctrl *ProductController) GetRecentlyViewed(c *gin.Context) {
+	userID := c.GetHeader("X-User-ID")
+	if userID == "" {
+		httputil.RespondError(c, http.StatusUnauthorized, "Authentication required")
+		return
+	}
+
+	limit := recentlyViewedLimit
+	if raw, present := c.GetQuery("limit"); present {
+		n, err := strconv.Atoi(raw)
+		if err != nil || n < 1 {
+			httputil.RespondError(c, http.StatusBadRequest, "Invalid limit")
+			return
+		}
+		limit = min(n, recentlyViewedLimit)
+	}
+	expand, ok := parseExpand(c)
+	if !ok {
+		return
+	}
+
+	ctx := c.Request.Context()
+	ids, err := ctrl.recentlyViewedIDs(ctx, userID, limit)
+	if err != nil {
+		zap.L().Error("failed to read recently viewed products", zap.Error(err), zap.String("user_id", userID))
+		httputil.RespondError(c, http.StatusInternalServerError, "Failed to fetch recently viewed products")
+		return
+	}
+
+	products := make([]*models.Product, 0, len(ids))
+	for _, id := range ids {
+		product, err := ctrl.productService.GetProduct(ctx, id)
+		if err != nil {
+			if errors.Is(err, apperrors.ErrNotFound) {
+				continue
+			}
+			zap.L().Error("Service failed to get product", zap.Error(err), zap.String("id", id.String()))
+			httputil.RespondError(c, http.StatusInternalServerError, "Failed to fetch recently viewed products")
+			return
+		}
+		products = append(products, product)
+	}
+
+	dtos, err := ctrl.productService.PublicProducts(ctx, products, expand)
+	if err != nil {
+		zap.L().Error("Service failed to build product response", zap.Error(err))
+		httputil.RespondError(c, http.StatusInternalServerError, "Internal Server Error")
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"products": dtos})
+}
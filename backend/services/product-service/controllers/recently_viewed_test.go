@@ -0,0 +1,166 @@
+package controllers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"product-service/models"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/gin-gonic/gin"
+	"github.com/go-redis/redis/v8"
+	"github.com/google/uuid"
+)
+
+func newRecentlyViewedRouter(t *testing.T, products ...*models.Product) (*gin.Engine, *miniredis.Miniredis, *fakeProductService) {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+	mr := miniredis.RunT(t)
+
+	svc := &fakeProductService{products: make(map[uuid.UUID]*models.Product)}
+	for _, p := range products {
+		svc.products[p.ID] = p
+	}
+	ctrl := NewProductController(svc, redis.NewClient(&redis.Options{Addr: mr.Addr()}))
+	r := gin.New()
+	r.GET("/products/recently-viewed", ctrl.GetRecentlyViewed)
+	r.GET("/products/:id", ctrl.GetProductByID)
+	return r, mr, svc
+}
+
+func viewProduct(r *gin.Engine, userID string, id uuid.UUID) {
+	req := httptest.NewRequest(http.MethodGet, "/products/"+id.String(), nil)
+	if userID != "" {
+		req.Header.Set("X-User-ID", userID)
+	}
+	r.ServeHTTP(httptest.NewRecorder(), req)
+}
+
+func recentlyViewed(t *testing.T, r *gin.Engine, userID, query string) []uuid.UUID {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodGet, "/products/recently-viewed"+query, nil)
+	req.Header.Set("X-User-ID", userID)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var body struct {
+		Products []struct {
+			ID uuid.UUID `json:"_id"`
+		} `json:"products"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+		t.Fatalf("invalid JSON body: %v", err)
+	}
+	ids := make([]uuid.UUID, 0, len(body.Products))
+	for _, p := range body.Products {
+		ids = append(ids, p.ID)
+	}
+	return ids
+}
+
+func newProducts(n int) []*models.Product {
+	products := make([]*models.Product, n)
+	for i := range products {
+		products[i] = &models.Product{ID: uuid.New(), Name: "Product"}
+	}
+	return products
+}
+
+func assertIDs(t *testing.T, got []uuid.UUID, want ...*models.Product) {
+	t.Helper()
+	if len(got) != len(want) {
+		t.Fatalf("expected %d products, got %d", len(want), len(got))
+	}
+	for i, p := range want {
+		if got[i] != p.ID {
+			t.Fatalf("position %d: expected %s, got %s", i, p.ID, got[i])
+		}
+	}
+}
+
+func TestRecentlyViewed_RecordsAuthenticatedViews(t *testing.T) {
+	p := newProducts(2)
+	r, mr, _ := newRecentlyViewedRouter(t, p...)
+
+	viewProduct(r, "user-1", p[0].ID)
+	viewProduct(r, "user-1", p[1].ID)
+	viewProduct(r, "", p[0].ID)
+
+	assertIDs(t, recentlyViewed(t, r, "user-1", ""), p[1], p[0])
+	assertIDs(t, recentlyViewed(t, r, "user-2", ""))
+	if keys := mr.Keys(); len(keys) != 1 {
+		t.Fatalf("expected only user-1's list to be stored, got keys %v", keys)
+	}
+}
+
+func TestRecentlyViewed_DedupsReviews(t *testing.T) {
+	p := newProducts(3)
+	r, _, _ := newRecentlyViewedRouter(t, p...)
+
+	for _, prod := range []*models.Product{p[0], p[1], p[2], p[0], p[0]} {
+		viewProduct(r, "user-1", prod.ID)
+	}
+
+	assertIDs(t, recentlyViewed(t, r, "user-1", ""), p[0], p[2], p[1])
+}
+
+func TestRecentlyViewed_EnforcesCap(t *testing.T) {
+	old := recentlyViewedLimit
+	t.Cleanup(func() { recentlyViewedLimit = old })
+	if err := SetRecentlyViewedLimit(3); err != nil {
+		t.Fatal(err)
+	}
+
+	p := newProducts(5)
+	r, mr, _ := newRecentlyViewedRouter(t, p...)
+	for _, prod := range p {
+		viewProduct(r, "user-1", prod.ID)
+	}
+
+	stored, err := mr.List(recentlyViewedKey("user-1"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(stored) != 3 {
+		t.Fatalf("expected list capped at 3, got %d", len(stored))
+	}
+	assertIDs(t, recentlyViewed(t, r, "user-1", ""), p[4], p[3], p[2])
+	// limit can narrow the result but not exceed the cap
+	assertIDs(t, recentlyViewed(t, r, "user-1", "?limit=2"), p[4], p[3])
+	assertIDs(t, recentlyViewed(t, r, "user-1", "?limit=50"), p[4], p[3], p[2])
+}
+
+func TestRecentlyViewed_SkipsDeletedProducts(t *testing.T) {
+	p := newProducts(2)
+	r, _, svc := newRecentlyViewedRouter(t, p...)
+	viewProduct(r, "user-1", p[0].ID)
+	viewProduct(r, "user-1", p[1].ID)
+
+	delete(svc.products, p[1].ID)
+
+	assertIDs(t, recentlyViewed(t, r, "user-1", ""), p[0])
+}
+
+func TestRecentlyViewed_Errors(t *testing.T) {
+	r, _, _ := newRecentlyViewedRouter(t)
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/products/recently-viewed", nil))
+	if w.Code != http.StatusUnauthorized {
+		t.Fatalf("expected 401 without a user, got %d", w.Code)
+	}
+
+	for _, q := range []string{"?limit=0", "?limit=abc"} {
+		req := httptest.NewRequest(http.MethodGet, "/products/recently-viewed"+q, nil)
+		req.Header.Set("X-User-ID", "user-1")
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		if w.Code != http.StatusBadRequest {
+			t.Fatalf("%s: expected 400, got %d", q, w.Code)
+		}
+	}
+}
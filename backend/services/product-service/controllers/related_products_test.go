@@ -0,0 +1,66 @@
+package controllers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"product-service/models"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+func TestGetRelatedProducts(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	product := &models.Product{ID: uuid.New(), Name: "Runner"}
+	neighbor := &models.Product{ID: uuid.New(), Name: "Trail Runner"}
+
+	cases := []struct {
+		name       string
+		path       string
+		wantStatus int
+		wantLimit  int
+	}{
+		{name: "default limit", path: "/products/" + product.ID.String() + "/related", wantStatus: http.StatusOK, wantLimit: DefaultRelatedLimit},
+		{name: "explicit limit", path: "/products/" + product.ID.String() + "/related?limit=3", wantStatus: http.StatusOK, wantLimit: 3},
+		{name: "limit clamped", path: "/products/" + product.ID.String() + "/related?limit=1000", wantStatus: http.StatusOK, wantLimit: MaxRelatedLimit},
+		{name: "bad limit", path: "/products/" + product.ID.String() + "/related?limit=0", wantStatus: http.StatusBadRequest},
+		{name: "bad id", path: "/products/nope/related", wantStatus: http.StatusBadRequest},
+		{name: "unknown product", path: "/products/" + uuid.New().String() + "/related", wantStatus: http.StatusNotFound},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			svc := &fakeProductService{
+				products: map[uuid.UUID]*models.Product{product.ID: product},
+				related:  []*models.Product{neighbor},
+			}
+			r := gin.New()
+			r.GET("/products/:id/related", NewProductController(svc, newTestRedisClient()).GetRelatedProducts)
+
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, tc.path, nil))
+			if w.Code != tc.wantStatus {
+				t.Fatalf("expected status %d, got %d: %s", tc.wantStatus, w.Code, w.Body.String())
+			}
+			if tc.wantStatus != http.StatusOK {
+				return
+			}
+			if svc.lastRelatedLimit != tc.wantLimit {
+				t.Fatalf("expected limit %d, got %d", tc.wantLimit, svc.lastRelatedLimit)
+			}
+			var body struct {
+				Products []struct {
+					ID uuid.UUID `json:"_id"`
+				} `json:"products"`
+			}
+			if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+				t.Fatalf("invalid JSON body: %v", err)
+			}
+			if len(body.Products) != 1 || body.Products[0].ID != neighbor.ID {
+				t.Fatalf("expected the neighbor product, got %+v", body.Products)
+			}
+		})
+	}
+}
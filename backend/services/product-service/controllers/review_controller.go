@@ -0,0 +1,110 @@
+package controllers
+
+import (
+	"context"
+	"errors"
+	"math"
+	"net/http"
+	"strings"
+
+	"product-service/models"
+	"product-service/services"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	apperrors "github.com/yashrajoria/common/errors"
+	"github.com/yashrajoria/common/httputil"
+	"go.uber.org/zap"
+)
+
+// ReviewServiceAPI defines the interface for review service operations
+type ReviewServiceAPI interface {
+	CreateReview(ctx context.Context, productID uuid.UUID, userID string, req services.ReviewCreateRequest) (*models.Review, error)
+	ListReviews(ctx context.Context, productID uuid.UUID, page, perPage int) ([]models.Review, int64, error)
+}
+
+type ReviewController struct {
+	service ReviewServiceAPI
+}
+
+func NewReviewController(s ReviewServiceAPI) *ReviewController {
+	return &ReviewController{service: s}
+}
+
+func (ctrl *ReviewController) CreateReview(c *gin.Context) {
+	id := c.Param("id")
+	productID, err := uuid.Parse(id)
+	if err != nil {
+		httputil.RespondError(c, http.StatusBadRequest, "Invalid UUID format")
+		return
+	}
+
+	// Set by the gateway from the verified JWT
+	userID := c.GetHeader("X-User-ID")
+	if userID == "" {
+		httputil.RespondError(c, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	var req services.ReviewCreateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		httputil.RespondErrorDetails(c, http.StatusBadRequest, "Invalid request body", err.Error())
+		return
+	}
+	if err := validate.Struct(&req); err != nil {
+		httputil.RespondErrorDetails(c, http.StatusBadRequest, "Validation failed", fieldErrors(err))
+		return
+	}
+
+	review, err := ctrl.service.CreateReview(c.Request.Context(), productID, userID, req)
+	if err != nil {
+		if strings.Contains(err.Error(), "already exists") {
+			httputil.RespondError(c, http.StatusConflict, "You have already reviewed this product")
+			return
+		}
+		if errors.Is(err, apperrors.ErrNotFound) {
+			httputil.RespondError(c, http.StatusNotFound, "Product not found")
+			return
+		}
+		zap.L().Error("Service failed to create review", zap.Error(err), zap.String("product_id", id))
+		httputil.RespondError(c, http.StatusInternalServerError, "Failed to create review")
+		return
+	}
+
+	c.JSON(http.StatusCreated, review)
+}
+
+func (ctrl *ReviewController) GetReviews(c *gin.Context) {
+	id := c.Param("id")
+	productID, err := uuid.Parse(id)
+	if err != nil {
+		httputil.RespondError(c, http.StatusBadRequest, "Invalid UUID format")
+		return
+	}
+
+	page, perPage, ok := parsePagination(c)
+	if !ok {
+		return
+	}
+
+	reviews, total, err := ctrl.service.ListReviews(c.Request.Context(), productID, page, perPage)
+	if err != nil {
+		if errors.Is(err, apperrors.ErrNotFound) {
+			httputil.RespondError(c, http.StatusNotFound, "Product not found")
+			return
+		}
+		zap.L().Error("Service failed to list reviews", zap.Error(err), zap.String("product_id", id))
+		httputil.RespondError(c, http.StatusInternalServerError, "Failed to fetch reviews")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"reviews": reviews,
+		"meta": gin.H{
+			"page":       page,
+			"perPage":    perPage,
+			"total":      total,
+			"totalPages": int(math.Ceil(float64(total) / float64(perPage))),
+		},
+	})
+}
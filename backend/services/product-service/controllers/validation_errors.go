@@ -0,0 +1,68 @@
+package controllers
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// FieldError is a single per-field validation failure returned to clients.
+type FieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+func init() {
+	// Report fields by the name clients send (form or json tag) rather than
+	// the Go struct field name.
+	validate.RegisterTagNameFunc(func(fld reflect.StructField) string {
+		for _, tag := range []string{"form", "json"} {
+			name := strings.SplitN(fld.Tag.Get(tag), ",", 2)[0]
+			if name != "" && name != "-" {
+				return name
+			}
+		}
+		return fld.Name
+	})
+}
+
+// fieldErrors converts a validator error into one FieldError per failing
+// field. Errors that aren't from the validator are returned as a single entry
+// without a field.
+func fieldErrors(err error) []FieldError {
+	var verrs validator.ValidationErrors
+	if !errors.As(err, &verrs) {
+		return []FieldError{{Message: err.Error()}}
+	}
+	out := make([]FieldError, 0, len(verrs))
+	for _, fe := range verrs {
+		out = append(out, FieldError{Field: fe.Field(), Message: fieldErrorMessage(fe)})
+	}
+	return out
+}
+
+func fieldErrorMessage(fe validator.FieldError) string {
+	switch fe.Tag() {
+	case "required":
+		return fmt.Sprintf("%s is required", fe.Field())
+	case "gt":
+		return fmt.Sprintf("%s must be greater than %s", fe.Field(), fe.Param())
+	case "gte":
+		return fmt.Sprintf("%s must be at least %s", fe.Field(), fe.Param())
+	case "lt":
+		return fmt.Sprintf("%s must be less than %s", fe.Field(), fe.Param())
+	case "lte":
+		return fmt.Sprintf("%s must be at most %s", fe.Field(), fe.Param())
+	case "min":
+		return fmt.Sprintf("%s must be at least %s", fe.Field(), fe.Param())
+	case "max":
+		return fmt.Sprintf("%s must be at most %s", fe.Field(), fe.Param())
+	case "oneof":
+		return fmt.Sprintf("%s must be one of: %s", fe.Field(), fe.Param())
+	default:
+		return fmt.Sprintf("%s is invalid", fe.Field())
+	}
+}
@@ -0,0 +1,71 @@
+package controllers
+
+import (
+	"bytes"
+	"encoding/json"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func postCreateProductForm(t *testing.T, fields map[string]string) *httptest.ResponseRecorder {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+
+	var buf bytes.Buffer
+	mw := multipart.NewWriter(&buf)
+	for k, v := range fields {
+		_ = mw.WriteField(k, v)
+	}
+	_ = mw.Close()
+
+	controller := NewProductController(&fakeProductService{}, newTestRedisClient())
+	router := gin.New()
+	router.POST("/products", controller.CreateProduct)
+
+	req := httptest.NewRequest(http.MethodPost, "/products", &buf)
+	req.Header.Set("Content-Type", mw.FormDataContentType())
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestCreateProduct_FieldLevelValidationErrors(t *testing.T) {
+	w := postCreateProductForm(t, map[string]string{
+		"description": "A thing",
+		"brand":       "Acme",
+		"sku":         "SKU-1",
+		"price":       "-5",
+		"quantity":    "3",
+		"category":    `["Books"]`,
+	})
+
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400, got %d", w.Code)
+	}
+
+	var body struct {
+		Error   string       `json:"error"`
+		Details []FieldError `json:"details"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+		t.Fatalf("invalid JSON response: %v", err)
+	}
+
+	got := map[string]string{}
+	for _, d := range body.Details {
+		got[d.Field] = d.Message
+	}
+	if got["name"] != "name is required" {
+		t.Fatalf("expected name error, got %v", body.Details)
+	}
+	if got["price"] != "price must be greater than 0" {
+		t.Fatalf("expected price error, got %v", body.Details)
+	}
+	if len(body.Details) != 2 {
+		t.Fatalf("expected exactly 2 field errors, got %v", body.Details)
+	}
+}
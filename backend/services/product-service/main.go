@@ -21,11 +21,20 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/go-redis/redis/v8"
 	"github.com/joho/godotenv"
+	commonmw "github.com/yashrajoria/common/middleware"
 	"go.uber.org/zap"
 )
 
 var ProductRedis *redis.Client
 
+// Set at build time:
+//
+//	go build -ldflags "-X main.gitSHA=$(git rev-parse HEAD) -X main.buildTime=$(date -u +%Y-%m-%dT%H:%M:%SZ)"
+var (
+	gitSHA    = "unknown"
+	buildTime = "unknown"
+)
+
 func main() {
 	// Initialize structured logger
 	logger, err := zap.NewProduction()
@@ -55,6 +64,12 @@ func main() {
 	if err != nil {
 		zap.L().Fatal("Failed to load configuration", zap.Error(err))
 	}
+	if err := controllers.SetPageSizes(cfg.PageSizes); err != nil {
+		zap.L().Fatal("Invalid page size configuration", zap.Error(err))
+	}
+	if err := controllers.SetRecentlyViewedLimit(cfg.RecentlyViewedLimit); err != nil {
+		zap.L().Fatal("Invalid RECENTLY_VIEWED_LIMIT", zap.Error(err))
+	}
 
 	// Initialize AWS configuration (LocalStack-compatible) using AWS SDK v2
 	awsRegion := os.Getenv("AWS_REGION")
@@ -149,18 +164,38 @@ func main() {
 	}
 	categoryRepo := repository.NewDynamoCategoryAdapter(ddbClient, ddbCategoryTable, ddbTable)
 
+	// Reviews table
+	ddbReviewTable := os.Getenv("DDB_TABLE_REVIEWS")
+	if ddbReviewTable == "" {
+		ddbReviewTable = "Reviews"
+	}
+	reviewRepo := repository.NewDynamoReviewAdapter(ddbClient, ddbReviewTable)
+
+	// Price history table
+	ddbPriceHistoryTable := os.Getenv("DDB_TABLE_PRICE_HISTORY")
+	if ddbPriceHistoryTable == "" {
+		ddbPriceHistoryTable = "ProductPriceHistory"
+	}
+	priceHistoryRepo := repository.NewDynamoPriceHistoryAdapter(ddbClient, ddbPriceHistoryTable)
+
 	// Initialize Services using DynamoDB repositories
-	productService := services.NewProductServiceDDB(productRepo, categoryRepo, s3Client, presignClient, bucket, prefix, endpoint, cloudfrontDomain)
+	productService := services.NewProductServiceDDB(productRepo, categoryRepo, priceHistoryRepo, s3Client, presignClient, bucket, prefix, endpoint, cloudfrontDomain)
+	if err := productService.SetUnfeatureOn(cfg.UnfeatureOn); err != nil {
+		zap.L().Fatal("Invalid UNFEATURE_ON_INVENTORY_EVENTS", zap.Error(err))
+	}
 	categoryService := services.NewCategoryServiceDDB(categoryRepo, productRepo)
+	reviewService := services.NewReviewServiceDDB(reviewRepo, productRepo)
 
 	// Initialize Controllers, injecting services
 	productController := controllers.NewProductController(productService, ProductRedis)
 	categoryController := controllers.NewCategoryController(categoryService)
+	reviewController := controllers.NewReviewController(reviewService)
 
 	// --- 3. HTTP Server & Middleware ---
 
 	r := gin.New()
-	r.Use(gin.Recovery()) // Recover from panics
+	r.Use(commonmw.RequestID())
+	r.Use(commonmw.Recovery(logger, "product-service", os.Stdout)) // Recover from panics
 
 	// Add request timeout middleware
 	r.Use(func(c *gin.Context) {
@@ -175,11 +210,22 @@ func main() {
 	// --- 4. Route Registration ---
 
 	// Register all application routes, passing in the controllers
-	routes.RegisterRoutes(r, productController, categoryController)
-
-	r.GET("/health", func(c *gin.Context) {
-		c.JSON(http.StatusOK, gin.H{"status": "OK"})
+	routes.RegisterRoutes(r, productController, categoryController, reviewController)
+
+	healthController := controllers.NewHealthController(controllers.NewBuildInfo(gitSHA, buildTime), map[string]controllers.DependencyCheck{
+		"dynamodb": func(ctx context.Context) error {
+			_, err := ddbClient.DescribeTable(ctx, &dynamodb.DescribeTableInput{TableName: aws.String(ddbTable)})
+			return err
+		},
+		"redis": func(ctx context.Context) error {
+			return ProductRedis.Ping(ctx).Err()
+		},
+		"s3": func(ctx context.Context) error {
+			_, err := s3Client.HeadBucket(ctx, &s3.HeadBucketInput{Bucket: aws.String(bucket)})
+			return err
+		},
 	})
+	r.GET("/health", healthController.Health)
 
 	// --- 5. Graceful Shutdown ---
 
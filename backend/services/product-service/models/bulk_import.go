@@ -4,6 +4,7 @@ type BulkImportValidation struct {
     TotalProducts      int                      `json:"total_products"`
     ValidProducts      int                      `json:"valid_products"`
     InvalidProducts    int                      `json:"invalid_products"`
+    SkippedRows        int                      `json:"skipped_rows"` // blank or unparseable rows, not counted in TotalProducts
     MissingCategories  []string                 `json:"missing_categories"`
     DuplicateSKUs      []string                 `json:"duplicate_skus"`
     Errors             []map[string]interface{} `json:"errors"`
@@ -0,0 +1,16 @@
+package models
+
+// CategoryImportValidation reports problems with a category import file
+// before anything is written.
+type CategoryImportValidation struct {
+	TotalCategories int                      `json:"total_categories"`
+	MissingParents  []string                 `json:"missing_parents"`
+	DuplicateNames  []string                 `json:"duplicate_names"`
+	Cycles          [][]string               `json:"cycles"`
+	Errors          []map[string]interface{} `json:"errors"`
+}
+
+// Valid reports whether the import can be committed.
+func (v *CategoryImportValidation) Valid() bool {
+	return len(v.Errors) == 0
+}
@@ -0,0 +1,16 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// PriceChange records one edit of a product's price.
+type PriceChange struct {
+	ProductID uuid.UUID `json:"product_id"`
+	OldPrice  float64   `json:"old_price"`
+	NewPrice  float64   `json:"new_price"`
+	ChangedBy string    `json:"changed_by"` // user ID from X-User-ID; empty if unknown
+	ChangedAt time.Time `json:"changed_at"`
+}
@@ -18,7 +18,61 @@ type Product struct {
 	CategoryIDs  []uuid.UUID `bson:"category_ids,omitempty" json:"category_ids,omitempty"`
 	CategoryPath []string    `bson:"category_path,omitempty" json:"category_path,omitempty"`
 	IsFeatured   bool        `bson:"is_featured" json:"is_featured"`
-	CreatedAt    time.Time   `bson:"created_at" json:"created_at"`
-	UpdatedAt    time.Time   `bson:"updated_at" json:"updated_at"`
-	DeletedAt    *time.Time  `bson:"deleted_at,omitempty" json:"deleted_at,omitempty"`
+	// Optional sale; see EffectivePrice
+	SalePrice    *float64   `bson:"sale_price,omitempty" json:"sale_price,omitempty"`
+	SaleStartsAt *time.Time `bson:"sale_starts_at,omitempty" json:"sale_starts_at,omitempty"`
+	SaleEndsAt   *time.Time `bson:"sale_ends_at,omitempty" json:"sale_ends_at,omitempty"`
+	// Denormalized from reviews; recomputed whenever a review is added
+	AverageRating float64    `bson:"average_rating" json:"average_rating"`
+	ReviewCount   int        `bson:"review_count" json:"review_count"`
+	CreatedAt     time.Time  `bson:"created_at" json:"created_at"`
+	UpdatedAt     time.Time  `bson:"updated_at" json:"updated_at"`
+	DeletedAt     *time.Time `bson:"deleted_at,omitempty" json:"deleted_at,omitempty"`
+
+	ImageUploads []ImageUploadResult `bson:"-" json:"image_uploads,omitempty"` // transient, set on create
+}
+
+// OnSale reports whether the sale price applies at now: a sale price is set,
+// the sale has started (or has no start) and has not yet ended (or has no end).
+func (p *Product) OnSale(now time.Time) bool {
+	if p.SalePrice == nil {
+		return false
+	}
+	if p.SaleStartsAt != nil && now.Before(*p.SaleStartsAt) {
+		return false
+	}
+	if p.SaleEndsAt != nil && !now.Before(*p.SaleEndsAt) {
+		return false
+	}
+	return true
+}
+
+// EffectivePrice is the price a customer pays at now.
+func (p *Product) EffectivePrice(now time.Time) float64 {
+	if p.OnSale(now) {
+		return *p.SalePrice
+	}
+	return p.Price
+}
+
+// NextPriceChange returns the first sale boundary after now, the moment
+// EffectivePrice may next change. It reports false when no boundary is ahead.
+func (p *Product) NextPriceChange(now time.Time) (time.Time, bool) {
+	if p.SalePrice == nil {
+		return time.Time{}, false
+	}
+	var next time.Time
+	for _, t := range []*time.Time{p.SaleStartsAt, p.SaleEndsAt} {
+		if t != nil && t.After(now) && (next.IsZero() || t.Before(next)) {
+			next = *t
+		}
+	}
+	return next, !next.IsZero()
+}
+
+// ImageUploadResult reports the outcome of uploading one product image.
+type ImageUploadResult struct {
+	Filename string `json:"filename"`
+	URL      string `json:"url,omitempty"`
+	Error    string `json:"error,omitempty"`
 }
@@ -0,0 +1,17 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Review is a single user's rating of a product. A user may review a given
+// product only once.
+type Review struct {
+	ProductID uuid.UUID `json:"product_id"`
+	UserID    string    `json:"user_id"`
+	Rating    int       `json:"rating"`
+	Comment   string    `json:"comment,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
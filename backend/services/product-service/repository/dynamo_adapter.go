@@ -2,7 +2,6 @@ package repository
 
 import (
 	"context"
-	"errors"
 	"fmt"
 	"product-service/models"
 	"time"
@@ -11,6 +10,7 @@ import (
 	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
 	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
 	"github.com/google/uuid"
+	apperrors "github.com/yashrajoria/common/errors"
 )
 
 // DynamoAdapter is a starter DynamoDB-backed ProductRepo implementation.
@@ -25,20 +25,25 @@ func NewDynamoAdapter(client *dynamodb.Client, table string) *DynamoAdapter {
 }
 
 type ddbProduct struct {
-	ProductID    string   `dynamodbav:"product_id"`
-	Name         string   `dynamodbav:"name"`
-	Price        float64  `dynamodbav:"price"`
-	Quantity     int      `dynamodbav:"quantity"`
-	Description  *string  `dynamodbav:"description,omitempty"`
-	Images       []string `dynamodbav:"images,omitempty"`
-	Brand        *string  `dynamodbav:"brand,omitempty"`
-	SKU          string   `dynamodbav:"sku"`
-	CategoryIDs  []string `dynamodbav:"category_ids,omitempty"`
-	CategoryPath []string `dynamodbav:"category_path,omitempty"`
-	IsFeatured   bool     `dynamodbav:"is_featured"`
-	CreatedAt    string   `dynamodbav:"created_at"`
-	UpdatedAt    string   `dynamodbav:"updated_at"`
-	DeletedAt    *string  `dynamodbav:"deleted_at,omitempty"`
+	ProductID     string   `dynamodbav:"product_id"`
+	Name          string   `dynamodbav:"name"`
+	Price         float64  `dynamodbav:"price"`
+	Quantity      int      `dynamodbav:"quantity"`
+	Description   *string  `dynamodbav:"description,omitempty"`
+	Images        []string `dynamodbav:"images,omitempty"`
+	Brand         *string  `dynamodbav:"brand,omitempty"`
+	SKU           string   `dynamodbav:"sku"`
+	CategoryIDs   []string `dynamodbav:"category_ids,omitempty"`
+	CategoryPath  []string `dynamodbav:"category_path,omitempty"`
+	IsFeatured    bool     `dynamodbav:"is_featured"`
+	SalePrice     *float64 `dynamodbav:"sale_price,omitempty"`
+	SaleStartsAt  *string  `dynamodbav:"sale_starts_at,omitempty"`
+	SaleEndsAt    *string  `dynamodbav:"sale_ends_at,omitempty"`
+	AverageRating float64  `dynamodbav:"average_rating,omitempty"`
+	ReviewCount   int      `dynamodbav:"review_count,omitempty"`
+	CreatedAt     string   `dynamodbav:"created_at"`
+	UpdatedAt     string   `dynamodbav:"updated_at"`
+	DeletedAt     *string  `dynamodbav:"deleted_at,omitempty"`
 }
 
 func (d *DynamoAdapter) FindByID(ctx context.Context, id uuid.UUID) (*models.Product, error) {
@@ -51,46 +56,9 @@ func (d *DynamoAdapter) FindByID(ctx context.Context, id uuid.UUID) (*models.Pro
 		return nil, fmt.Errorf("dynamodb GetItem failed: %w", err)
 	}
 	if len(out.Item) == 0 {
-		return nil, errors.New("record not found")
+		return nil, fmt.Errorf("product %s: %w", id, apperrors.ErrNotFound)
 	}
-	var dp ddbProduct
-	if err := attributevalue.UnmarshalMap(out.Item, &dp); err != nil {
-		return nil, fmt.Errorf("unmarshal item: %w", err)
-	}
-	// Map to models.Product
-	p := &models.Product{}
-	p.ID, _ = uuid.Parse(dp.ProductID)
-	p.Name = dp.Name
-	p.Price = dp.Price
-	p.Quantity = dp.Quantity
-	if dp.Description != nil {
-		p.Description = *dp.Description
-	}
-	p.Images = dp.Images
-	if dp.Brand != nil {
-		p.Brand = *dp.Brand
-	}
-	p.SKU = dp.SKU
-	// convert category ids
-	for _, s := range dp.CategoryIDs {
-		if u, err := uuid.Parse(s); err == nil {
-			p.CategoryIDs = append(p.CategoryIDs, u)
-		}
-	}
-	p.CategoryPath = dp.CategoryPath
-	p.IsFeatured = dp.IsFeatured
-	if t, err := time.Parse(time.RFC3339, dp.CreatedAt); err == nil {
-		p.CreatedAt = t
-	}
-	if t, err := time.Parse(time.RFC3339, dp.UpdatedAt); err == nil {
-		p.UpdatedAt = t
-	}
-	if dp.DeletedAt != nil {
-		if t, err := time.Parse(time.RFC3339, *dp.DeletedAt); err == nil {
-			p.DeletedAt = &t
-		}
-	}
-	return p, nil
+	return productFromItem(out.Item)
 }
 
 func (d *DynamoAdapter) Create(ctx context.Context, product *models.Product) error {
@@ -103,11 +71,11 @@ func (d *DynamoAdapter) Create(ctx context.Context, product *models.Product) err
 		SKU:          product.SKU,
 		CategoryPath: product.CategoryPath,
 		IsFeatured:   product.IsFeatured,
-		CreatedAt:    product.CreatedAt.Format(time.RFC3339),
-		UpdatedAt:    product.UpdatedAt.Format(time.RFC3339),
+		CreatedAt:    FormatTimestamp(product.CreatedAt),
+		UpdatedAt:    FormatTimestamp(product.UpdatedAt),
 	}
 	if product.DeletedAt != nil {
-		s := product.DeletedAt.Format(time.RFC3339)
+		s := FormatTimestamp(*product.DeletedAt)
 		dp.DeletedAt = &s
 	}
 	if product.Description != "" {
@@ -116,6 +84,15 @@ func (d *DynamoAdapter) Create(ctx context.Context, product *models.Product) err
 	if product.Brand != "" {
 		dp.Brand = &product.Brand
 	}
+	dp.SalePrice = product.SalePrice
+	if product.SaleStartsAt != nil {
+		s := FormatTimestamp(*product.SaleStartsAt)
+		dp.SaleStartsAt = &s
+	}
+	if product.SaleEndsAt != nil {
+		s := FormatTimestamp(*product.SaleEndsAt)
+		dp.SaleEndsAt = &s
+	}
 	for _, uid := range product.CategoryIDs {
 		dp.CategoryIDs = append(dp.CategoryIDs, uid.String())
 	}
@@ -131,12 +108,31 @@ func (d *DynamoAdapter) Create(ctx context.Context, product *models.Product) err
 	return nil
 }
 
-// Find performs a Scan with basic pagination. Filter support is limited to nil (no filter).
+// Find scans the table, applying filter (see matchesFilter) before skip and
+// limit. When filter[SortKey] is set every match is read and sorted before
+// paging, since a scan returns items in no particular order.
 func (d *DynamoAdapter) Find(ctx context.Context, filter map[string]interface{}, limit, skip int) ([]*models.Product, error) {
-	// Simple implementation: Scan table and apply skip/limit
+	if key, _ := filter[SortKey].(string); key != "" {
+		conditions := make(map[string]interface{}, len(filter))
+		for k, v := range filter {
+			if k != SortKey {
+				conditions[k] = v
+			}
+		}
+		all, err := d.Find(ctx, conditions, 0, 0)
+		if err != nil {
+			return nil, err
+		}
+		if err := sortProducts(all, key, time.Now()); err != nil {
+			return nil, err
+		}
+		return pageOf(all, limit, skip), nil
+	}
+
 	input := &dynamodb.ScanInput{TableName: &d.table}
 	var results []*models.Product
 	paginator := dynamodb.NewScanPaginator(d.client, input)
+	now := time.Now()
 	seen := 0
 	for paginator.HasMorePages() {
 		page, err := paginator.NextPage(ctx)
@@ -144,45 +140,17 @@ func (d *DynamoAdapter) Find(ctx context.Context, filter map[string]interface{},
 			return nil, fmt.Errorf("scan page failed: %w", err)
 		}
 		for _, it := range page.Items {
+			p, err := productFromItem(it)
+			if err != nil {
+				return nil, err
+			}
+			if !matchesFilter(p, filter, now) {
+				continue
+			}
 			if skip > 0 && seen < skip {
 				seen++
 				continue
 			}
-			var dp ddbProduct
-			if err := attributevalue.UnmarshalMap(it, &dp); err != nil {
-				return nil, fmt.Errorf("unmarshal item: %w", err)
-			}
-			p := &models.Product{}
-			p.ID, _ = uuid.Parse(dp.ProductID)
-			p.Name = dp.Name
-			p.Price = dp.Price
-			p.Quantity = dp.Quantity
-			if dp.Description != nil {
-				p.Description = *dp.Description
-			}
-			p.Images = dp.Images
-			if dp.Brand != nil {
-				p.Brand = *dp.Brand
-			}
-			p.SKU = dp.SKU
-			for _, s := range dp.CategoryIDs {
-				if u, err := uuid.Parse(s); err == nil {
-					p.CategoryIDs = append(p.CategoryIDs, u)
-				}
-			}
-			p.CategoryPath = dp.CategoryPath
-			p.IsFeatured = dp.IsFeatured
-			if t, err := time.Parse(time.RFC3339, dp.CreatedAt); err == nil {
-				p.CreatedAt = t
-			}
-			if t, err := time.Parse(time.RFC3339, dp.UpdatedAt); err == nil {
-				p.UpdatedAt = t
-			}
-			if dp.DeletedAt != nil {
-				if t, err := time.Parse(time.RFC3339, *dp.DeletedAt); err == nil {
-					p.DeletedAt = &t
-				}
-			}
 			results = append(results, p)
 			if limit > 0 && len(results) >= limit {
 				return results, nil
@@ -192,64 +160,129 @@ func (d *DynamoAdapter) Find(ctx context.Context, filter map[string]interface{},
 	return results, nil
 }
 
-// Count returns the item count (full table scan Count)
-func (d *DynamoAdapter) Count(ctx context.Context, filter map[string]interface{}) (int64, error) {
-	input := &dynamodb.ScanInput{TableName: &d.table, Select: types.SelectCount}
-	paginator := dynamodb.NewScanPaginator(d.client, input)
-	var total int64
-	for paginator.HasMorePages() {
-		page, err := paginator.NextPage(ctx)
-		if err != nil {
-			return 0, fmt.Errorf("scan count failed: %w", err)
+// FindPage reads every match once and takes both the page and the total from
+// that single scan, rather than scanning again to count.
+func (d *DynamoAdapter) FindPage(ctx context.Context, filter map[string]interface{}, limit, skip int) ([]*models.Product, int64, error) {
+	all, err := d.Find(ctx, filter, 0, 0)
+	if err != nil {
+		return nil, 0, err
+	}
+	return pageOf(all, limit, skip), int64(len(all)), nil
+}
+
+// pageOf returns the window of products after skipping skip, at most limit long
+// (0 means no limit).
+func pageOf(products []*models.Product, limit, skip int) []*models.Product {
+	if skip >= len(products) {
+		return []*models.Product{}
+	}
+	products = products[skip:]
+	if limit > 0 && limit < len(products) {
+		products = products[:limit]
+	}
+	return products
+}
+
+// productFromItem maps a stored item to models.Product.
+func productFromItem(item map[string]types.AttributeValue) (*models.Product, error) {
+	var dp ddbProduct
+	if err := attributevalue.UnmarshalMap(item, &dp); err != nil {
+		return nil, fmt.Errorf("unmarshal item: %w", err)
+	}
+	p := &models.Product{}
+	p.ID, _ = uuid.Parse(dp.ProductID)
+	p.Name = dp.Name
+	p.Price = dp.Price
+	p.Quantity = dp.Quantity
+	if dp.Description != nil {
+		p.Description = *dp.Description
+	}
+	p.Images = dp.Images
+	if dp.Brand != nil {
+		p.Brand = *dp.Brand
+	}
+	p.SKU = dp.SKU
+	for _, s := range dp.CategoryIDs {
+		if u, err := uuid.Parse(s); err == nil {
+			p.CategoryIDs = append(p.CategoryIDs, u)
 		}
-		total += int64(page.Count)
 	}
-	return total, nil
+	p.CategoryPath = dp.CategoryPath
+	p.IsFeatured = dp.IsFeatured
+	p.SalePrice = dp.SalePrice
+	if dp.SaleStartsAt != nil {
+		if t, ok := ParseTimestamp(*dp.SaleStartsAt); ok {
+			p.SaleStartsAt = &t
+		}
+	}
+	if dp.SaleEndsAt != nil {
+		if t, ok := ParseTimestamp(*dp.SaleEndsAt); ok {
+			p.SaleEndsAt = &t
+		}
+	}
+	p.AverageRating = dp.AverageRating
+	p.ReviewCount = dp.ReviewCount
+	if t, ok := ParseTimestamp(dp.CreatedAt); ok {
+		p.CreatedAt = t
+	}
+	if t, ok := ParseTimestamp(dp.UpdatedAt); ok {
+		p.UpdatedAt = t
+	}
+	if dp.DeletedAt != nil {
+		if t, ok := ParseTimestamp(*dp.DeletedAt); ok {
+			p.DeletedAt = &t
+		}
+	}
+	return p, nil
 }
 
-// CreateMany uses BatchWriteItem (chunks of 25)
+// CreateMany uses BatchWriteItem (chunks of 25), retrying unprocessed items
 func (d *DynamoAdapter) CreateMany(ctx context.Context, products []models.Product) error {
-	const chunkSize = 25
-	for i := 0; i < len(products); i += chunkSize {
-		end := i + chunkSize
-		if end > len(products) {
-			end = len(products)
+	writeReqs := make([]types.WriteRequest, 0, len(products))
+	for _, p := range products {
+		dp := ddbProduct{
+			ProductID:    p.ID.String(),
+			Name:         p.Name,
+			Price:        p.Price,
+			Quantity:     p.Quantity,
+			Images:       p.Images,
+			SKU:          p.SKU,
+			CategoryPath: p.CategoryPath,
+			IsFeatured:   p.IsFeatured,
+			CreatedAt:    FormatTimestamp(p.CreatedAt),
+			UpdatedAt:    FormatTimestamp(p.UpdatedAt),
 		}
-		writeReqs := make([]types.WriteRequest, 0, end-i)
-		for _, p := range products[i:end] {
-			dp := ddbProduct{
-				ProductID:    p.ID.String(),
-				Name:         p.Name,
-				Price:        p.Price,
-				Quantity:     p.Quantity,
-				Images:       p.Images,
-				SKU:          p.SKU,
-				CategoryPath: p.CategoryPath,
-				IsFeatured:   p.IsFeatured,
-				CreatedAt:    p.CreatedAt.Format(time.RFC3339),
-				UpdatedAt:    p.UpdatedAt.Format(time.RFC3339),
-			}
-			if p.Description != "" {
-				dp.Description = &p.Description
-			}
-			if p.Brand != "" {
-				dp.Brand = &p.Brand
-			}
-			for _, uid := range p.CategoryIDs {
-				dp.CategoryIDs = append(dp.CategoryIDs, uid.String())
-			}
-			item, err := attributevalue.MarshalMap(dp)
-			if err != nil {
-				return fmt.Errorf("marshal batch item: %w", err)
-			}
-			writeReqs = append(writeReqs, types.WriteRequest{PutRequest: &types.PutRequest{Item: item}})
+		if p.Description != "" {
+			dp.Description = &p.Description
+		}
+		if p.Brand != "" {
+			dp.Brand = &p.Brand
+		}
+		for _, uid := range p.CategoryIDs {
+			dp.CategoryIDs = append(dp.CategoryIDs, uid.String())
+		}
+		item, err := attributevalue.MarshalMap(dp)
+		if err != nil {
+			return fmt.Errorf("marshal batch item: %w", err)
 		}
-		req := &dynamodb.BatchWriteItemInput{RequestItems: map[string][]types.WriteRequest{d.table: writeReqs}}
-		if _, err := d.client.BatchWriteItem(ctx, req); err != nil {
-			return fmt.Errorf("batch write failed: %w", err)
+		writeReqs = append(writeReqs, types.WriteRequest{PutRequest: &types.PutRequest{Item: item}})
+	}
+	return batchWriteAll(ctx, d.client, d.table, writeReqs)
+}
+
+// DeleteMany removes products by ID using BatchWriteItem (chunks of 25),
+// retrying unprocessed items. It returns an error if any delete could not be
+// applied.
+func (d *DynamoAdapter) DeleteMany(ctx context.Context, ids []uuid.UUID) error {
+	writeReqs := make([]types.WriteRequest, 0, len(ids))
+	for _, id := range ids {
+		key, err := attributevalue.MarshalMap(map[string]string{"product_id": id.String()})
+		if err != nil {
+			return fmt.Errorf("marshal key: %w", err)
 		}
+		writeReqs = append(writeReqs, types.WriteRequest{DeleteRequest: &types.DeleteRequest{Key: key}})
 	}
-	return nil
+	return batchWriteAll(ctx, d.client, d.table, writeReqs)
 }
 
 // Update performs UpdateItem by setting provided attributes
@@ -328,42 +361,11 @@ func (d *DynamoAdapter) FindBySKUs(ctx context.Context, skus []string) ([]models
 	}
 	var res []models.Product
 	for _, it := range out.Items {
-		var dp ddbProduct
-		if err := attributevalue.UnmarshalMap(it, &dp); err != nil {
-			return nil, fmt.Errorf("unmarshal item: %w", err)
-		}
-		p := models.Product{}
-		p.ID, _ = uuid.Parse(dp.ProductID)
-		p.Name = dp.Name
-		p.Price = dp.Price
-		p.Quantity = dp.Quantity
-		if dp.Description != nil {
-			p.Description = *dp.Description
-		}
-		p.Images = dp.Images
-		if dp.Brand != nil {
-			p.Brand = *dp.Brand
-		}
-		p.SKU = dp.SKU
-		for _, s := range dp.CategoryIDs {
-			if u, err := uuid.Parse(s); err == nil {
-				p.CategoryIDs = append(p.CategoryIDs, u)
-			}
-		}
-		p.CategoryPath = dp.CategoryPath
-		p.IsFeatured = dp.IsFeatured
-		if t, err := time.Parse(time.RFC3339, dp.CreatedAt); err == nil {
-			p.CreatedAt = t
-		}
-		if t, err := time.Parse(time.RFC3339, dp.UpdatedAt); err == nil {
-			p.UpdatedAt = t
-		}
-		if dp.DeletedAt != nil {
-			if t, err := time.Parse(time.RFC3339, *dp.DeletedAt); err == nil {
-				p.DeletedAt = &t
-			}
+		p, err := productFromItem(it)
+		if err != nil {
+			return nil, err
 		}
-		res = append(res, p)
+		res = append(res, *p)
 	}
 	return res, nil
 }
@@ -0,0 +1,63 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+const (
+	// batchWriteLimit is DynamoDB's maximum number of requests per BatchWriteItem.
+	batchWriteLimit = 25
+	// batchWriteMaxRetries bounds how often unprocessed items are resubmitted.
+	batchWriteMaxRetries = 5
+)
+
+// batchWriteAPI is the subset of the DynamoDB client used for batch writes.
+type batchWriteAPI interface {
+	BatchWriteItem(ctx context.Context, params *dynamodb.BatchWriteItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.BatchWriteItemOutput, error)
+}
+
+// batchRetryBackoff returns the wait before retry attempt n (1-based).
+var batchRetryBackoff = func(attempt int) time.Duration {
+	return time.Duration(50<<uint(attempt-1)) * time.Millisecond
+}
+
+// batchWriteAll writes reqs to table in chunks of 25, resubmitting any
+// UnprocessedItems with exponential backoff. It fails if items remain
+// unprocessed after batchWriteMaxRetries so callers never report a partial
+// write as success.
+func batchWriteAll(ctx context.Context, client batchWriteAPI, table string, reqs []types.WriteRequest) error {
+	for i := 0; i < len(reqs); i += batchWriteLimit {
+		end := i + batchWriteLimit
+		if end > len(reqs) {
+			end = len(reqs)
+		}
+		pending := map[string][]types.WriteRequest{table: reqs[i:end]}
+
+		for attempt := 0; ; attempt++ {
+			if attempt > 0 {
+				select {
+				case <-ctx.Done():
+					return ctx.Err()
+				case <-time.After(batchRetryBackoff(attempt)):
+				}
+			}
+			out, err := client.BatchWriteItem(ctx, &dynamodb.BatchWriteItemInput{RequestItems: pending})
+			if err != nil {
+				return fmt.Errorf("batch write failed: %w", err)
+			}
+			if len(out.UnprocessedItems[table]) == 0 {
+				break
+			}
+			pending = out.UnprocessedItems
+			if attempt == batchWriteMaxRetries {
+				return fmt.Errorf("batch write incomplete: %d items unprocessed after %d retries", len(pending[table]), batchWriteMaxRetries)
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,112 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+// fakeBatchWriter records BatchWriteItem calls. unprocessed, if set, decides
+// how many of a call's requests to hand back as UnprocessedItems.
+type fakeBatchWriter struct {
+	calls       [][]types.WriteRequest
+	written     int
+	unprocessed func(call int, reqs []types.WriteRequest) int
+}
+
+func (f *fakeBatchWriter) BatchWriteItem(ctx context.Context, in *dynamodb.BatchWriteItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.BatchWriteItemOutput, error) {
+	var reqs []types.WriteRequest
+	var table string
+	for t, r := range in.RequestItems {
+		table, reqs = t, r
+	}
+	if len(reqs) > batchWriteLimit {
+		return nil, fmt.Errorf("batch of %d exceeds limit", len(reqs))
+	}
+	f.calls = append(f.calls, reqs)
+
+	n := 0
+	if f.unprocessed != nil {
+		n = f.unprocessed(len(f.calls), reqs)
+	}
+	f.written += len(reqs) - n
+	out := &dynamodb.BatchWriteItemOutput{}
+	if n > 0 {
+		out.UnprocessedItems = map[string][]types.WriteRequest{table: reqs[len(reqs)-n:]}
+	}
+	return out, nil
+}
+
+func deleteRequests(n int) []types.WriteRequest {
+	reqs := make([]types.WriteRequest, n)
+	for i := range reqs {
+		reqs[i] = types.WriteRequest{DeleteRequest: &types.DeleteRequest{Key: map[string]types.AttributeValue{
+			"product_id": &types.AttributeValueMemberS{Value: fmt.Sprintf("p-%d", i)},
+		}}}
+	}
+	return reqs
+}
+
+func noBackoff(t *testing.T) {
+	orig := batchRetryBackoff
+	batchRetryBackoff = func(int) time.Duration { return 0 }
+	t.Cleanup(func() { batchRetryBackoff = orig })
+}
+
+func TestBatchWriteAll_ChunksAcross25ItemBoundary(t *testing.T) {
+	client := &fakeBatchWriter{}
+
+	if err := batchWriteAll(context.Background(), client, "Products", deleteRequests(60)); err != nil {
+		t.Fatalf("batchWriteAll returned error: %v", err)
+	}
+
+	if len(client.calls) != 3 {
+		t.Fatalf("expected 3 batches, got %d", len(client.calls))
+	}
+	sizes := []int{len(client.calls[0]), len(client.calls[1]), len(client.calls[2])}
+	if sizes[0] != 25 || sizes[1] != 25 || sizes[2] != 10 {
+		t.Fatalf("expected batch sizes [25 25 10], got %v", sizes)
+	}
+	if client.written != 60 {
+		t.Fatalf("expected 60 items written, got %d", client.written)
+	}
+}
+
+func TestBatchWriteAll_RetriesUnprocessedItems(t *testing.T) {
+	noBackoff(t)
+	// First call leaves 5 items unprocessed; the retry takes them all
+	client := &fakeBatchWriter{unprocessed: func(call int, reqs []types.WriteRequest) int {
+		if call == 1 {
+			return 5
+		}
+		return 0
+	}}
+
+	if err := batchWriteAll(context.Background(), client, "Products", deleteRequests(20)); err != nil {
+		t.Fatalf("batchWriteAll returned error: %v", err)
+	}
+	if len(client.calls) != 2 || len(client.calls[1]) != 5 {
+		t.Fatalf("expected a retry with the 5 unprocessed items, got calls %d", len(client.calls))
+	}
+	if client.written != 20 {
+		t.Fatalf("expected all 20 items written, got %d", client.written)
+	}
+}
+
+func TestBatchWriteAll_FailsWhenItemsRemainUnprocessed(t *testing.T) {
+	noBackoff(t)
+	client := &fakeBatchWriter{unprocessed: func(call int, reqs []types.WriteRequest) int { return 1 }}
+
+	err := batchWriteAll(context.Background(), client, "Products", deleteRequests(3))
+	if err == nil || !strings.Contains(err.Error(), "unprocessed") {
+		t.Fatalf("expected unprocessed error, got %v", err)
+	}
+	if len(client.calls) != batchWriteMaxRetries+1 {
+		t.Fatalf("expected %d attempts, got %d", batchWriteMaxRetries+1, len(client.calls))
+	}
+}
@@ -6,12 +6,12 @@ import (
 	"fmt"
 	"product-service/models"
 	"strings"
-	"time"
 
 	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
 	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
 	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
 	"github.com/google/uuid"
+	apperrors "github.com/yashrajoria/common/errors"
 )
 
 // DynamoCategoryAdapter is a DynamoDB-backed CategoryRepo implementation.
@@ -60,14 +60,14 @@ func (d *DynamoCategoryAdapter) toModel(dc *ddbCategory) *models.Category {
 			cat.Ancestors = append(cat.Ancestors, u)
 		}
 	}
-	if t, err := time.Parse(time.RFC3339, dc.CreatedAt); err == nil {
+	if t, ok := ParseTimestamp(dc.CreatedAt); ok {
 		cat.CreatedAt = t
 	}
-	if t, err := time.Parse(time.RFC3339, dc.UpdatedAt); err == nil {
+	if t, ok := ParseTimestamp(dc.UpdatedAt); ok {
 		cat.UpdatedAt = t
 	}
 	if dc.DeletedAt != nil {
-		if t, err := time.Parse(time.RFC3339, *dc.DeletedAt); err == nil {
+		if t, ok := ParseTimestamp(*dc.DeletedAt); ok {
 			cat.DeletedAt = &t
 		}
 	}
@@ -83,8 +83,8 @@ func (d *DynamoCategoryAdapter) toDDB(cat *models.Category) *ddbCategory {
 		Path:       cat.Path,
 		Level:      cat.Level,
 		IsActive:   cat.IsActive,
-		CreatedAt:  cat.CreatedAt.Format(time.RFC3339),
-		UpdatedAt:  cat.UpdatedAt.Format(time.RFC3339),
+		CreatedAt:  FormatTimestamp(cat.CreatedAt),
+		UpdatedAt:  FormatTimestamp(cat.UpdatedAt),
 	}
 	for _, uid := range cat.ParentIDs {
 		dc.ParentIDs = append(dc.ParentIDs, uid.String())
@@ -93,7 +93,7 @@ func (d *DynamoCategoryAdapter) toDDB(cat *models.Category) *ddbCategory {
 		dc.Ancestors = append(dc.Ancestors, uid.String())
 	}
 	if cat.DeletedAt != nil {
-		s := cat.DeletedAt.Format(time.RFC3339)
+		s := FormatTimestamp(*cat.DeletedAt)
 		dc.DeletedAt = &s
 	}
 	return dc
@@ -109,7 +109,7 @@ func (d *DynamoCategoryAdapter) FindByID(ctx context.Context, id uuid.UUID) (*mo
 		return nil, fmt.Errorf("dynamodb GetItem failed: %w", err)
 	}
 	if len(out.Item) == 0 {
-		return nil, errors.New("record not found")
+		return nil, fmt.Errorf("category %s: %w", id, apperrors.ErrNotFound)
 	}
 	var dc ddbCategory
 	if err := attributevalue.UnmarshalMap(out.Item, &dc); err != nil {
@@ -117,7 +117,7 @@ func (d *DynamoCategoryAdapter) FindByID(ctx context.Context, id uuid.UUID) (*mo
 	}
 	// Skip soft-deleted
 	if dc.DeletedAt != nil {
-		return nil, errors.New("record not found")
+		return nil, fmt.Errorf("category %s: %w", id, apperrors.ErrNotFound)
 	}
 	return d.toModel(&dc), nil
 }
@@ -139,7 +139,7 @@ func (d *DynamoCategoryAdapter) FindByName(ctx context.Context, name string) (*m
 		return nil, fmt.Errorf("scan failed: %w", err)
 	}
 	if len(out.Items) == 0 {
-		return nil, errors.New("record not found")
+		return nil, fmt.Errorf("category %q: %w", name, apperrors.ErrNotFound)
 	}
 	var dc ddbCategory
 	if err := attributevalue.UnmarshalMap(out.Items[0], &dc); err != nil {
@@ -271,13 +271,64 @@ func (d *DynamoCategoryAdapter) Update(ctx context.Context, id uuid.UUID, update
 
 func (d *DynamoCategoryAdapter) Delete(ctx context.Context, id uuid.UUID) error {
 	// Soft delete
-	now := time.Now().UTC().Format(time.RFC3339)
+	now := FormatTimestamp(Now())
 	return d.Update(ctx, id, map[string]interface{}{
 		"deleted_at": now,
 		"updated_at": now,
 	})
 }
 
+// FindDeletedByID returns a soft-deleted category by ID.
+func (d *DynamoCategoryAdapter) FindDeletedByID(ctx context.Context, id uuid.UUID) (*models.Category, error) {
+	key, err := attributevalue.MarshalMap(map[string]string{"category_id": id.String()})
+	if err != nil {
+		return nil, fmt.Errorf("marshal key: %w", err)
+	}
+	out, err := d.client.GetItem(ctx, &dynamodb.GetItemInput{TableName: &d.table, Key: key})
+	if err != nil {
+		return nil, fmt.Errorf("dynamodb GetItem failed: %w", err)
+	}
+	if len(out.Item) == 0 {
+		return nil, fmt.Errorf("category %s: %w", id, apperrors.ErrNotFound)
+	}
+	var dc ddbCategory
+	if err := attributevalue.UnmarshalMap(out.Item, &dc); err != nil {
+		return nil, fmt.Errorf("unmarshal item: %w", err)
+	}
+	// Only soft-deleted records are eligible
+	if dc.DeletedAt == nil {
+		return nil, fmt.Errorf("category %s: %w", id, apperrors.ErrNotFound)
+	}
+	return d.toModel(&dc), nil
+}
+
+// Restore clears deleted_at on a soft-deleted category.
+func (d *DynamoCategoryAdapter) Restore(ctx context.Context, id uuid.UUID) error {
+	key, err := attributevalue.MarshalMap(map[string]string{"category_id": id.String()})
+	if err != nil {
+		return fmt.Errorf("marshal key: %w", err)
+	}
+	expr := "REMOVE deleted_at SET updated_at = :now"
+	cond := "attribute_exists(category_id) AND attribute_exists(deleted_at)"
+	exprVals, _ := attributevalue.MarshalMap(map[string]string{":now": FormatTimestamp(Now())})
+
+	_, err = d.client.UpdateItem(ctx, &dynamodb.UpdateItemInput{
+		TableName:                 &d.table,
+		Key:                       key,
+		UpdateExpression:          &expr,
+		ConditionExpression:       &cond,
+		ExpressionAttributeValues: exprVals,
+	})
+	if err != nil {
+		var ccf *types.ConditionalCheckFailedException
+		if errors.As(err, &ccf) {
+			return fmt.Errorf("category %s: %w", id, apperrors.ErrNotFound)
+		}
+		return fmt.Errorf("update item failed: %w", err)
+	}
+	return nil
+}
+
 // HasProducts checks if any products reference this category
 func (d *DynamoCategoryAdapter) HasProducts(ctx context.Context, categoryID uuid.UUID) (bool, error) {
 	// Scan products table for category_ids containing this ID
@@ -288,16 +339,19 @@ func (d *DynamoCategoryAdapter) HasProducts(ctx context.Context, categoryID uuid
 		TableName:                 &d.productTable,
 		FilterExpression:          &filterExpr,
 		ExpressionAttributeValues: exprVals,
-		Limit:                     ptrInt32(1), // We only need to know if at least one exists
 	}
 
-	out, err := d.client.Scan(ctx, input)
-	if err != nil {
-		return false, fmt.Errorf("scan products failed: %w", err)
+	// Scan Limit is applied before the filter, so keep paging until a match
+	// turns up or the table is exhausted.
+	paginator := dynamodb.NewScanPaginator(d.client, input)
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return false, fmt.Errorf("scan products failed: %w", err)
+		}
+		if len(page.Items) > 0 {
+			return true, nil
+		}
 	}
-	return len(out.Items) > 0, nil
-}
-
-func ptrInt32(v int32) *int32 {
-	return &v
+	return false, nil
 }
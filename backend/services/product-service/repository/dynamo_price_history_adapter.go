@@ -0,0 +1,89 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"product-service/models"
+	"sort"
+
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/google/uuid"
+)
+
+// DynamoPriceHistoryAdapter is a DynamoDB-backed PriceHistoryRepo. Changes are
+// keyed by `product_id` (hash) and `change_id` (range); change_id starts with
+// the timestamp so a product's changes sort chronologically, and ends with a
+// random suffix so two edits in the same second don't collide.
+type DynamoPriceHistoryAdapter struct {
+	client *dynamodb.Client
+	table  string
+}
+
+func NewDynamoPriceHistoryAdapter(client *dynamodb.Client, table string) *DynamoPriceHistoryAdapter {
+	return &DynamoPriceHistoryAdapter{client: client, table: table}
+}
+
+type ddbPriceChange struct {
+	ProductID string  `dynamodbav:"product_id"`
+	ChangeID  string  `dynamodbav:"change_id"`
+	OldPrice  float64 `dynamodbav:"old_price"`
+	NewPrice  float64 `dynamodbav:"new_price"`
+	ChangedBy string  `dynamodbav:"changed_by,omitempty"`
+	ChangedAt string  `dynamodbav:"changed_at"`
+}
+
+func (d *DynamoPriceHistoryAdapter) Create(ctx context.Context, change *models.PriceChange) error {
+	changedAt := FormatTimestamp(change.ChangedAt)
+	item, err := attributevalue.MarshalMap(ddbPriceChange{
+		ProductID: change.ProductID.String(),
+		ChangeID:  changedAt + "#" + uuid.NewString(),
+		OldPrice:  change.OldPrice,
+		NewPrice:  change.NewPrice,
+		ChangedBy: change.ChangedBy,
+		ChangedAt: changedAt,
+	})
+	if err != nil {
+		return fmt.Errorf("marshal price change: %w", err)
+	}
+	if _, err := d.client.PutItem(ctx, &dynamodb.PutItemInput{TableName: &d.table, Item: item}); err != nil {
+		return fmt.Errorf("dynamodb PutItem failed: %w", err)
+	}
+	return nil
+}
+
+func (d *DynamoPriceHistoryAdapter) ListByProduct(ctx context.Context, productID uuid.UUID) ([]models.PriceChange, error) {
+	keyCond := "product_id = :pid"
+	input := &dynamodb.QueryInput{
+		TableName:              &d.table,
+		KeyConditionExpression: &keyCond,
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":pid": &types.AttributeValueMemberS{Value: productID.String()},
+		},
+	}
+	var items []ddbPriceChange
+	paginator := dynamodb.NewQueryPaginator(d.client, input)
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("query price history failed: %w", err)
+		}
+		var pageItems []ddbPriceChange
+		if err := attributevalue.UnmarshalListOfMaps(page.Items, &pageItems); err != nil {
+			return nil, fmt.Errorf("unmarshal price history: %w", err)
+		}
+		items = append(items, pageItems...)
+	}
+	sort.Slice(items, func(i, j int) bool { return items[i].ChangeID > items[j].ChangeID })
+
+	changes := make([]models.PriceChange, 0, len(items))
+	for _, it := range items {
+		c := models.PriceChange{ProductID: productID, OldPrice: it.OldPrice, NewPrice: it.NewPrice, ChangedBy: it.ChangedBy}
+		if t, ok := ParseTimestamp(it.ChangedAt); ok {
+			c.ChangedAt = t
+		}
+		changes = append(changes, c)
+	}
+	return changes, nil
+}
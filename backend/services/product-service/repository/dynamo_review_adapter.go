@@ -0,0 +1,130 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"product-service/models"
+	"sort"
+
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/google/uuid"
+)
+
+// DynamoReviewAdapter is a DynamoDB-backed ReviewRepo implementation.
+// Reviews are keyed by `product_id` (hash) and `user_id` (range), which makes
+// one review per user per product a property of the table itself.
+type DynamoReviewAdapter struct {
+	client *dynamodb.Client
+	table  string
+}
+
+func NewDynamoReviewAdapter(client *dynamodb.Client, table string) *DynamoReviewAdapter {
+	return &DynamoReviewAdapter{client: client, table: table}
+}
+
+type ddbReview struct {
+	ProductID string `dynamodbav:"product_id"`
+	UserID    string `dynamodbav:"user_id"`
+	Rating    int    `dynamodbav:"rating"`
+	Comment   string `dynamodbav:"comment,omitempty"`
+	CreatedAt string `dynamodbav:"created_at"`
+}
+
+func (d *DynamoReviewAdapter) Create(ctx context.Context, review *models.Review) error {
+	item, err := attributevalue.MarshalMap(ddbReview{
+		ProductID: review.ProductID.String(),
+		UserID:    review.UserID,
+		Rating:    review.Rating,
+		Comment:   review.Comment,
+		CreatedAt: FormatTimestamp(review.CreatedAt),
+	})
+	if err != nil {
+		return fmt.Errorf("marshal review: %w", err)
+	}
+	cond := "attribute_not_exists(product_id) AND attribute_not_exists(user_id)"
+	_, err = d.client.PutItem(ctx, &dynamodb.PutItemInput{
+		TableName:           &d.table,
+		Item:                item,
+		ConditionExpression: &cond,
+	})
+	if err != nil {
+		var ccf *types.ConditionalCheckFailedException
+		if errors.As(err, &ccf) {
+			return errors.New("review already exists")
+		}
+		return fmt.Errorf("dynamodb PutItem failed: %w", err)
+	}
+	return nil
+}
+
+// queryAll returns every review for a product.
+func (d *DynamoReviewAdapter) queryAll(ctx context.Context, productID uuid.UUID) ([]ddbReview, error) {
+	keyCond := "product_id = :pid"
+	input := &dynamodb.QueryInput{
+		TableName:              &d.table,
+		KeyConditionExpression: &keyCond,
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":pid": &types.AttributeValueMemberS{Value: productID.String()},
+		},
+	}
+	var out []ddbReview
+	paginator := dynamodb.NewQueryPaginator(d.client, input)
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("query reviews failed: %w", err)
+		}
+		var items []ddbReview
+		if err := attributevalue.UnmarshalListOfMaps(page.Items, &items); err != nil {
+			return nil, fmt.Errorf("unmarshal reviews: %w", err)
+		}
+		out = append(out, items...)
+	}
+	return out, nil
+}
+
+func (d *DynamoReviewAdapter) ListByProduct(ctx context.Context, productID uuid.UUID, limit, skip int) ([]models.Review, int64, error) {
+	items, err := d.queryAll(ctx, productID)
+	if err != nil {
+		return nil, 0, err
+	}
+	// RFC3339 timestamps sort lexically
+	sort.Slice(items, func(i, j int) bool { return items[i].CreatedAt > items[j].CreatedAt })
+
+	total := int64(len(items))
+	if skip >= len(items) {
+		return []models.Review{}, total, nil
+	}
+	items = items[skip:]
+	if limit > 0 && len(items) > limit {
+		items = items[:limit]
+	}
+
+	reviews := make([]models.Review, 0, len(items))
+	for _, it := range items {
+		r := models.Review{ProductID: productID, UserID: it.UserID, Rating: it.Rating, Comment: it.Comment}
+		if t, ok := ParseTimestamp(it.CreatedAt); ok {
+			r.CreatedAt = t
+		}
+		reviews = append(reviews, r)
+	}
+	return reviews, total, nil
+}
+
+func (d *DynamoReviewAdapter) RatingStats(ctx context.Context, productID uuid.UUID) (float64, int, error) {
+	items, err := d.queryAll(ctx, productID)
+	if err != nil {
+		return 0, 0, err
+	}
+	if len(items) == 0 {
+		return 0, 0, nil
+	}
+	sum := 0
+	for _, it := range items {
+		sum += it.Rating
+	}
+	return float64(sum) / float64(len(items)), len(items), nil
+}
@@ -13,11 +13,14 @@ import (
 type ProductRepo interface {
 	FindByID(ctx context.Context, id uuid.UUID) (*models.Product, error)
 	Find(ctx context.Context, filter map[string]interface{}, limit, skip int) ([]*models.Product, error)
-	Count(ctx context.Context, filter map[string]interface{}) (int64, error)
+	// FindPage returns a page of the products matching filter and the total
+	// number of matches.
+	FindPage(ctx context.Context, filter map[string]interface{}, limit, skip int) ([]*models.Product, int64, error)
 	Create(ctx context.Context, product *models.Product) error
 	CreateMany(ctx context.Context, products []models.Product) error
 	Update(ctx context.Context, id uuid.UUID, updates map[string]interface{}) error
 	Delete(ctx context.Context, id uuid.UUID) error
+	DeleteMany(ctx context.Context, ids []uuid.UUID) error
 	FindBySKUs(ctx context.Context, skus []string) ([]models.Product, error)
 	EnsureIndexes(ctx context.Context) error
 }
@@ -31,5 +34,25 @@ type CategoryRepo interface {
 	Create(ctx context.Context, category *models.Category) error
 	Update(ctx context.Context, id uuid.UUID, updates map[string]interface{}) error
 	Delete(ctx context.Context, id uuid.UUID) error
+	FindDeletedByID(ctx context.Context, id uuid.UUID) (*models.Category, error)
+	Restore(ctx context.Context, id uuid.UUID) error
 	HasProducts(ctx context.Context, categoryID uuid.UUID) (bool, error)
 }
+
+// ReviewRepo defines the operations used for product reviews.
+type ReviewRepo interface {
+	// Create stores a review, failing with an "already exists" error if the
+	// user has already reviewed the product.
+	Create(ctx context.Context, review *models.Review) error
+	// ListByProduct returns a page of reviews, newest first, and the total count.
+	ListByProduct(ctx context.Context, productID uuid.UUID, limit, skip int) ([]models.Review, int64, error)
+	// RatingStats returns the average rating and number of reviews for a product.
+	RatingStats(ctx context.Context, productID uuid.UUID) (float64, int, error)
+}
+
+// PriceHistoryRepo stores the audit trail of product price edits.
+type PriceHistoryRepo interface {
+	Create(ctx context.Context, change *models.PriceChange) error
+	// ListByProduct returns a product's price changes, newest first.
+	ListByProduct(ctx context.Context, productID uuid.UUID) ([]models.PriceChange, error)
+}
@@ -0,0 +1,86 @@
+package repository
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"product-service/models"
+
+	"github.com/google/uuid"
+)
+
+// SortKey is the filter key naming the result order for Find. It is not a
+// condition; see sortProducts for the supported values.
+const SortKey = "sort"
+
+// matchesFilter reports whether p satisfies every condition in filter at now.
+// All conditions must hold; unknown keys are ignored. Prices are compared by
+// what a customer pays at now, so a product on sale matches its sale price.
+// Supported keys:
+//
+//	is_featured  bool       exact match
+//	category_ids []uuid.UUID product is in at least one of the categories
+//	min_price    float64    effective price >= min_price
+//	max_price    float64    effective price <= max_price
+//	brand        string     case-insensitive exact match
+//	in_stock     bool       quantity > 0 when true, == 0 when false
+func matchesFilter(p *models.Product, filter map[string]interface{}, now time.Time) bool {
+	if v, ok := filter["is_featured"].(bool); ok && p.IsFeatured != v {
+		return false
+	}
+	if ids, ok := filter["category_ids"].([]uuid.UUID); ok && len(ids) > 0 && !inAnyCategory(p, ids) {
+		return false
+	}
+	if v, ok := filter["min_price"].(float64); ok && p.EffectivePrice(now) < v {
+		return false
+	}
+	if v, ok := filter["max_price"].(float64); ok && p.EffectivePrice(now) > v {
+		return false
+	}
+	if v, ok := filter["brand"].(string); ok && v != "" && !strings.EqualFold(p.Brand, v) {
+		return false
+	}
+	if v, ok := filter["in_stock"].(bool); ok && (p.Quantity > 0) != v {
+		return false
+	}
+	return true
+}
+
+func inAnyCategory(p *models.Product, ids []uuid.UUID) bool {
+	for _, have := range p.CategoryIDs {
+		for _, want := range ids {
+			if have == want {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// productLess orders products at now for each supported sort key. Price keys
+// use the effective price, matching the filter.
+var productLess = map[string]func(a, b *models.Product, now time.Time) bool{
+	"price_asc": func(a, b *models.Product, now time.Time) bool {
+		return a.EffectivePrice(now) < b.EffectivePrice(now)
+	},
+	"price_desc": func(a, b *models.Product, now time.Time) bool {
+		return a.EffectivePrice(now) > b.EffectivePrice(now)
+	},
+	"created_at_asc":  func(a, b *models.Product, _ time.Time) bool { return a.CreatedAt.Before(b.CreatedAt) },
+	"created_at_desc": func(a, b *models.Product, _ time.Time) bool { return a.CreatedAt.After(b.CreatedAt) },
+	"name_asc":        func(a, b *models.Product, _ time.Time) bool { return strings.ToLower(a.Name) < strings.ToLower(b.Name) },
+	"name_desc":       func(a, b *models.Product, _ time.Time) bool { return strings.ToLower(a.Name) > strings.ToLower(b.Name) },
+}
+
+// sortProducts orders products in place by key at now. Ties keep their scan
+// order.
+func sortProducts(products []*models.Product, key string, now time.Time) error {
+	less, ok := productLess[key]
+	if !ok {
+		return fmt.Errorf("unsupported sort %q", key)
+	}
+	sort.SliceStable(products, func(i, j int) bool { return less(products[i], products[j], now) })
+	return nil
+}
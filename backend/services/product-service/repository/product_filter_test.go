@@ -0,0 +1,170 @@
+package repository
+
+import (
+	"testing"
+	"time"
+
+	"product-service/models"
+
+	"github.com/google/uuid"
+)
+
+func TestMatchesFilter_CombinedFiltersNarrowResults(t *testing.T) {
+	shoes, shirts := uuid.New(), uuid.New()
+	products := []*models.Product{
+		{Name: "runner", Brand: "Acme", Price: 80, Quantity: 3, CategoryIDs: []uuid.UUID{shoes}},
+		{Name: "runner-oos", Brand: "Acme", Price: 80, Quantity: 0, CategoryIDs: []uuid.UUID{shoes}},
+		{Name: "premium", Brand: "acme", Price: 250, Quantity: 1, CategoryIDs: []uuid.UUID{shoes}},
+		{Name: "tee", Brand: "Acme", Price: 20, Quantity: 9, CategoryIDs: []uuid.UUID{shirts}},
+		{Name: "other-brand", Brand: "Zeta", Price: 90, Quantity: 4, CategoryIDs: []uuid.UUID{shoes}},
+	}
+
+	cases := []struct {
+		name   string
+		filter map[string]interface{}
+		want   []string
+	}{
+		{
+			name:   "no filter",
+			filter: map[string]interface{}{},
+			want:   []string{"runner", "runner-oos", "premium", "tee", "other-brand"},
+		},
+		{
+			name:   "brand is case-insensitive",
+			filter: map[string]interface{}{"brand": "ACME"},
+			want:   []string{"runner", "runner-oos", "premium", "tee"},
+		},
+		{
+			name:   "brand and category",
+			filter: map[string]interface{}{"brand": "Acme", "category_ids": []uuid.UUID{shoes}},
+			want:   []string{"runner", "runner-oos", "premium"},
+		},
+		{
+			name: "brand, category and price",
+			filter: map[string]interface{}{
+				"brand": "Acme", "category_ids": []uuid.UUID{shoes},
+				"min_price": 50.0, "max_price": 100.0,
+			},
+			want: []string{"runner", "runner-oos"},
+		},
+		{
+			name: "brand, category, price and in stock",
+			filter: map[string]interface{}{
+				"brand": "Acme", "category_ids": []uuid.UUID{shoes},
+				"min_price": 50.0, "max_price": 100.0, "in_stock": true,
+			},
+			want: []string{"runner"},
+		},
+		{
+			name:   "out of stock only",
+			filter: map[string]interface{}{"in_stock": false},
+			want:   []string{"runner-oos"},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var got []string
+			for _, p := range products {
+				if matchesFilter(p, tc.filter, time.Now()) {
+					got = append(got, p.Name)
+				}
+			}
+			if len(got) != len(tc.want) {
+				t.Fatalf("expected %v, got %v", tc.want, got)
+			}
+			for i := range got {
+				if got[i] != tc.want[i] {
+					t.Fatalf("expected %v, got %v", tc.want, got)
+				}
+			}
+		})
+	}
+}
+
+func TestSortProducts_SupportedKeys(t *testing.T) {
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	newProducts := func() []*models.Product {
+		return []*models.Product{
+			{Name: "banana", Price: 20, CreatedAt: base.Add(2 * time.Hour)},
+			{Name: "Apple", Price: 30, CreatedAt: base},
+			{Name: "cherry", Price: 10, CreatedAt: base.Add(time.Hour)},
+		}
+	}
+
+	cases := map[string][]string{
+		"price_asc":       {"cherry", "banana", "Apple"},
+		"price_desc":      {"Apple", "banana", "cherry"},
+		"created_at_asc":  {"Apple", "cherry", "banana"},
+		"created_at_desc": {"banana", "cherry", "Apple"},
+		"name_asc":        {"Apple", "banana", "cherry"},
+		"name_desc":       {"cherry", "banana", "Apple"},
+	}
+	for key, want := range cases {
+		t.Run(key, func(t *testing.T) {
+			products := newProducts()
+			if err := sortProducts(products, key, base); err != nil {
+				t.Fatalf("sort %s: %v", key, err)
+			}
+			for i, p := range products {
+				if p.Name != want[i] {
+					t.Fatalf("expected order %v, got %s at %d", want, p.Name, i)
+				}
+			}
+		})
+	}
+}
+
+func TestFilterAndSort_UseSalePriceWhileSaleIsActive(t *testing.T) {
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	salePrice := 40.0
+	start, end := now.Add(-time.Hour), now.Add(time.Hour)
+	newProducts := func() []*models.Product {
+		return []*models.Product{
+			{Name: "regular", Price: 60},
+			{Name: "on-sale", Price: 100, SalePrice: &salePrice, SaleStartsAt: &start, SaleEndsAt: &end},
+		}
+	}
+
+	var got []string
+	for _, p := range newProducts() {
+		if matchesFilter(p, map[string]interface{}{"max_price": 50.0}, now) {
+			got = append(got, p.Name)
+		}
+	}
+	if len(got) != 1 || got[0] != "on-sale" {
+		t.Fatalf("expected only the sale product under max_price, got %v", got)
+	}
+
+	products := newProducts()
+	if err := sortProducts(products, "price_asc", now); err != nil {
+		t.Fatalf("sort: %v", err)
+	}
+	if products[0].Name != "on-sale" {
+		t.Fatalf("expected the sale product first, got %s", products[0].Name)
+	}
+
+	// Once the sale ends the list price applies again
+	products = newProducts()
+	if err := sortProducts(products, "price_asc", end); err != nil {
+		t.Fatalf("sort: %v", err)
+	}
+	if products[0].Name != "regular" {
+		t.Fatalf("expected the regular product first after the sale, got %s", products[0].Name)
+	}
+}
+
+func TestSortProducts_RejectsUnsupportedKey(t *testing.T) {
+	if err := sortProducts(nil, "rating_desc", time.Now()); err == nil {
+		t.Fatal("expected error for unsupported sort key")
+	}
+}
+
+func TestPageOf(t *testing.T) {
+	products := []*models.Product{{Name: "a"}, {Name: "b"}, {Name: "c"}}
+	if got := pageOf(products, 2, 1); len(got) != 2 || got[0].Name != "b" {
+		t.Fatalf("unexpected page %v", got)
+	}
+	if got := pageOf(products, 2, 5); len(got) != 0 {
+		t.Fatalf("expected empty page, got %v", got)
+	}
+}
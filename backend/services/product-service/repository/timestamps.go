@@ -0,0 +1,27 @@
+package repository
+
+import "time"
+
+// Timestamps are stored as UTC RFC3339 strings, which sort lexically and
+// carry whole seconds only.
+
+// Now returns the current time in UTC truncated to the second, so a value
+// written through FormatTimestamp reads back identical.
+func Now() time.Time {
+	return time.Now().UTC().Truncate(time.Second)
+}
+
+// FormatTimestamp renders t in the stored form.
+func FormatTimestamp(t time.Time) string {
+	return t.UTC().Format(time.RFC3339)
+}
+
+// ParseTimestamp reads a stored timestamp, normalizing any offset to UTC.
+// ok is false for empty or malformed values.
+func ParseTimestamp(s string) (t time.Time, ok bool) {
+	t, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return t.UTC(), true
+}
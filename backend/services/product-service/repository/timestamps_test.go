@@ -0,0 +1,48 @@
+package repository
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTimestampRoundTrip(t *testing.T) {
+	now := Now()
+	if now.Location() != time.UTC {
+		t.Fatalf("expected UTC, got %s", now.Location())
+	}
+
+	parsed, ok := ParseTimestamp(FormatTimestamp(now))
+	if !ok {
+		t.Fatal("failed to parse formatted timestamp")
+	}
+	if !parsed.Equal(now) || parsed != now {
+		t.Fatalf("round trip changed value: %v -> %v", now, parsed)
+	}
+}
+
+func TestFormatTimestamp_NormalizesToUTC(t *testing.T) {
+	ist := time.FixedZone("IST", 5*3600+1800)
+	local := time.Date(2024, 6, 1, 5, 30, 0, 0, ist)
+
+	if got := FormatTimestamp(local); got != "2024-06-01T00:00:00Z" {
+		t.Fatalf("expected UTC form, got %s", got)
+	}
+}
+
+func TestParseTimestamp_NormalizesOffsets(t *testing.T) {
+	parsed, ok := ParseTimestamp("2024-06-01T05:30:00+05:30")
+	if !ok {
+		t.Fatal("expected offset timestamp to parse")
+	}
+	if parsed.Location() != time.UTC || parsed.Hour() != 0 {
+		t.Fatalf("expected 00:00 UTC, got %v", parsed)
+	}
+}
+
+func TestParseTimestamp_Invalid(t *testing.T) {
+	for _, s := range []string{"", "yesterday", "2024-06-01"} {
+		if _, ok := ParseTimestamp(s); ok {
+			t.Fatalf("expected %q to be rejected", s)
+		}
+	}
+}
@@ -6,11 +6,13 @@ import (
 	"github.com/gin-gonic/gin"
 )
 
-func RegisterRoutes(r *gin.Engine, productController *controllers.ProductController, categoryController *controllers.CategoryController) {
+func RegisterRoutes(r *gin.Engine, productController *controllers.ProductController, categoryController *controllers.CategoryController, reviewController *controllers.ReviewController) {
 	productRoutes := r.Group("/products")
 	{
 		// List products with filtering, pagination, and sorting
 		productRoutes.GET("/", productController.GetProducts)
+		// The caller's recently viewed products
+		productRoutes.GET("/recently-viewed", productController.GetRecentlyViewed)
 		// Get a specific product
 		productRoutes.GET("/:id", productController.GetProductByID)
 		// Create a new product
@@ -23,14 +25,31 @@ func RegisterRoutes(r *gin.Engine, productController *controllers.ProductControl
 		productRoutes.POST("/bulk/validate", productController.ValidateBulkImport)
 
 		productRoutes.POST("/bulk", productController.CreateBulkProducts)
+		// Bulk delete products
+		productRoutes.POST("/bulk/delete", productController.BulkDeleteProducts)
 		// Update a product
 		productRoutes.PUT("/:id", productController.UpdateProduct)
 		// Delete a product
 		productRoutes.DELETE("/:id", productController.DeleteProduct)
+		// Price change audit trail
+		productRoutes.GET("/:id/price-history", productController.GetPriceHistory)
+		// Products sharing categories with this one
+		productRoutes.GET("/:id/related", productController.GetRelatedProducts)
+		// Product reviews
+		productRoutes.GET("/:id/reviews", reviewController.GetReviews)
+		productRoutes.POST("/:id/reviews", reviewController.CreateReview)
 		// Get products by category
 		//Get product by id for order service
 		productRoutes.GET("/internal/:id", productController.GetProductByIDInternal)
 	}
+	// Service-to-service routes. The gateway only forwards /products and
+	// /categories, so these are not reachable from outside.
+	internalRoutes := r.Group("/internal")
+	{
+		// Stock threshold events. Dormant: inventory-service does not send
+		// them yet, so the handler only runs when called directly.
+		internalRoutes.POST("/inventory-events", productController.HandleInventoryEvent)
+	}
 	categoryRoutes := r.Group("/categories")
 	{
 		// List all categories
@@ -39,14 +58,18 @@ func RegisterRoutes(r *gin.Engine, productController *controllers.ProductControl
 		// categoryRoutes.GET("/:id", categoryController.GetCategoryByID)
 		// Create a new category
 		categoryRoutes.POST("/", categoryController.CreateCategory)
-		// POST /categories/bulk
-		// categoryRoutes.POST("/bulk", categoryController.BulkCreateCategories)
+		// Validate a bulk category import without creating anything
+		categoryRoutes.POST("/bulk/validate", categoryController.ValidateCategoryImport)
+		// Create categories in bulk, parents before children
+		categoryRoutes.POST("/bulk", categoryController.BulkCreateCategories)
 
 		// Update a category
 		categoryRoutes.PUT("/:id", categoryController.UpdateCategory)
 		// Delete a category
 		categoryRoutes.DELETE("/:id", categoryController.DeleteCategory)
+		// Restore a soft-deleted category
+		categoryRoutes.POST("/:id/restore", categoryController.RestoreCategory)
 		// Get all products in a category
-		// categoryRoutes.GET("/:id/products", categoryController.GetCategoryProducts)
+		categoryRoutes.GET("/:id/products", categoryController.GetCategoryProducts)
 	}
 }
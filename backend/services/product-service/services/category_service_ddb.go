@@ -2,16 +2,28 @@ package services
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"sort"
 	"strings"
-	"time"
 
 	"product-service/models"
 	"product-service/repository"
 
 	"github.com/google/uuid"
+	apperrors "github.com/yashrajoria/common/errors"
 )
 
+// ErrCategoryHasProducts is returned when deleting a category that products still reference.
+var ErrCategoryHasProducts = errors.New("cannot delete category with associated products")
+
+// ErrParentCategoryNotFound is returned when a category names a parent that
+// does not exist.
+var ErrParentCategoryNotFound = errors.New("one or more parent categories not found")
+
+// ErrInvalidCategoryImport is returned when a bulk category import fails validation.
+var ErrInvalidCategoryImport = errors.New("category import failed validation")
+
 // CategoryServiceDDB is a DynamoDB-backed category service
 type CategoryServiceDDB struct {
 	repo        repository.CategoryRepo
@@ -30,7 +42,7 @@ func (s *CategoryServiceDDB) CreateCategory(ctx context.Context, req CategoryCre
 		return nil, fmt.Errorf("category with name '%s' already exists", req.Name)
 	}
 	// Continue only if error is "not found", otherwise return error
-	if !strings.Contains(err.Error(), "not found") {
+	if !errors.Is(err, apperrors.ErrNotFound) {
 		return nil, err
 	}
 
@@ -40,7 +52,7 @@ func (s *CategoryServiceDDB) CreateCategory(ctx context.Context, req CategoryCre
 		return nil, err
 	}
 
-	now := time.Now().UTC()
+	now := repository.Now()
 	slug := strings.ToLower(strings.ReplaceAll(req.Name, " ", "-"))
 
 	newCategory := &models.Category{
@@ -73,7 +85,7 @@ func (s *CategoryServiceDDB) resolveAncestry(ctx context.Context, parentNames []
 		return nil, nil, fmt.Errorf("failed to find parent categories: %w", err)
 	}
 	if len(parents) != len(parentNames) {
-		return nil, nil, fmt.Errorf("one or more parent categories not found")
+		return nil, nil, ErrParentCategoryNotFound
 	}
 
 	ancestorSet := make(map[uuid.UUID]bool)
@@ -92,31 +104,58 @@ func (s *CategoryServiceDDB) resolveAncestry(ctx context.Context, parentNames []
 	return parentIDs, ancestorIDs, nil
 }
 
+// MaxCategoryTreeDepth caps how many levels GetCategoryTree returns.
+const MaxCategoryTreeDepth = 8
+
 func (s *CategoryServiceDDB) GetCategoryTree(ctx context.Context) ([]*models.Category, error) {
 	categories, err := s.repo.FindAll(ctx)
 	if err != nil {
 		return nil, err
 	}
+	return buildCategoryTree(categories, MaxCategoryTreeDepth), nil
+}
 
-	categoryMap := make(map[uuid.UUID]*models.Category)
+// buildCategoryTree nests categories under their parents, at most maxDepth
+// levels deep. A category with several parents appears under each of them as
+// a separate copy, and a category is never nested under itself, so cyclic
+// parent data cannot produce an infinite tree.
+func buildCategoryTree(categories []models.Category, maxDepth int) []*models.Category {
+	children := make(map[uuid.UUID][]int)
+	var roots []int
 	for i := range categories {
-		categoryMap[categories[i].ID] = &categories[i]
+		if len(categories[i].ParentIDs) == 0 {
+			roots = append(roots, i)
+			continue
+		}
+		for _, parentID := range categories[i].ParentIDs {
+			children[parentID] = append(children[parentID], i)
+		}
 	}
 
-	var rootCategories []*models.Category
-	for i := range categories {
-		cat := &categories[i]
-		if len(cat.ParentIDs) == 0 {
-			rootCategories = append(rootCategories, cat)
-		} else {
-			for _, parentID := range cat.ParentIDs {
-				if parent, ok := categoryMap[parentID]; ok {
-					parent.Children = append(parent.Children, cat)
-				}
+	inBranch := make(map[uuid.UUID]bool)
+	var build func(i, depth int) *models.Category
+	build = func(i, depth int) *models.Category {
+		node := categories[i]
+		node.Children = nil
+		if depth >= maxDepth {
+			return &node
+		}
+		inBranch[node.ID] = true
+		for _, c := range children[node.ID] {
+			if inBranch[categories[c].ID] {
+				continue
 			}
+			node.Children = append(node.Children, build(c, depth+1))
 		}
+		delete(inBranch, node.ID)
+		return &node
 	}
-	return rootCategories, nil
+
+	rootCategories := make([]*models.Category, 0, len(roots))
+	for _, i := range roots {
+		rootCategories = append(rootCategories, build(i, 1))
+	}
+	return rootCategories
 }
 
 func (s *CategoryServiceDDB) UpdateCategory(ctx context.Context, id uuid.UUID, req CategoryCreateRequest) (int64, error) {
@@ -132,7 +171,7 @@ func (s *CategoryServiceDDB) UpdateCategory(ctx context.Context, id uuid.UUID, r
 		"parent_ids": parentIDs,
 		"ancestors":  ancestorIDs,
 		"slug":       strings.ToLower(strings.ReplaceAll(req.Name, " ", "-")),
-		"updated_at": time.Now().UTC().Format(time.RFC3339),
+		"updated_at": repository.FormatTimestamp(repository.Now()),
 	}
 
 	err = s.repo.Update(ctx, id, updates)
@@ -143,13 +182,18 @@ func (s *CategoryServiceDDB) UpdateCategory(ctx context.Context, id uuid.UUID, r
 }
 
 func (s *CategoryServiceDDB) DeleteCategory(ctx context.Context, id uuid.UUID) error {
+	// Dynamo UpdateItem upserts, so confirm the category exists before soft-deleting.
+	if _, err := s.repo.FindByID(ctx, id); err != nil {
+		return err
+	}
+
 	// Business rule: check for associated products before deleting.
 	hasProducts, err := s.repo.HasProducts(ctx, id)
 	if err != nil {
 		return err
 	}
 	if hasProducts {
-		return fmt.Errorf("cannot delete category with associated products")
+		return ErrCategoryHasProducts
 	}
 
 	err = s.repo.Delete(ctx, id)
@@ -159,12 +203,223 @@ func (s *CategoryServiceDDB) DeleteCategory(ctx context.Context, id uuid.UUID) e
 	return nil
 }
 
+// RestoreCategory reactivates a soft-deleted category. Restore is rejected if
+// an active category with the same name has been created in the meantime.
+func (s *CategoryServiceDDB) RestoreCategory(ctx context.Context, id uuid.UUID) (*models.Category, error) {
+	category, err := s.repo.FindDeletedByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = s.repo.FindByName(ctx, category.Name)
+	if err == nil {
+		return nil, fmt.Errorf("category with name '%s' already exists", category.Name)
+	}
+	if !errors.Is(err, apperrors.ErrNotFound) {
+		return nil, err
+	}
+
+	if err := s.repo.Restore(ctx, id); err != nil {
+		return nil, err
+	}
+	return s.repo.FindByID(ctx, id)
+}
+
 // GetCategory returns a single category by ID
 func (s *CategoryServiceDDB) GetCategory(ctx context.Context, id uuid.UUID) (*models.Category, error) {
 	return s.repo.FindByID(ctx, id)
 }
 
+// ListCategoryProducts returns one page of the products in a category along
+// with the category's total product count.
+func (s *CategoryServiceDDB) ListCategoryProducts(ctx context.Context, id uuid.UUID, page, perPage int) ([]*models.Product, int64, error) {
+	if _, err := s.repo.FindByID(ctx, id); err != nil {
+		return nil, 0, err
+	}
+
+	filter := map[string]interface{}{"category_ids": []uuid.UUID{id}}
+	return s.productRepo.FindPage(ctx, filter, perPage, (page-1)*perPage)
+}
+
 // FindByNames returns categories by their names
 func (s *CategoryServiceDDB) FindByNames(ctx context.Context, names []string) ([]models.Category, error) {
 	return s.repo.FindByNames(ctx, names)
 }
+
+// ValidateCategoryImport checks a category import before anything is written.
+// It reports names duplicated in the file or already stored, parents that are
+// neither in the file nor stored, and parent cycles within the file.
+func (s *CategoryServiceDDB) ValidateCategoryImport(ctx context.Context, reqs []CategoryCreateRequest) (*models.CategoryImportValidation, error) {
+	v := &models.CategoryImportValidation{
+		TotalCategories: len(reqs),
+		MissingParents:  []string{},
+		DuplicateNames:  []string{},
+		Cycles:          [][]string{},
+		Errors:          []map[string]interface{}{},
+	}
+
+	existing, err := s.repo.FindAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load categories: %w", err)
+	}
+	stored := make(map[string]bool, len(existing))
+	for _, cat := range existing {
+		stored[cat.Name] = true
+	}
+
+	inFile := make(map[string]bool, len(reqs))
+	for i, req := range reqs {
+		if inFile[req.Name] || stored[req.Name] {
+			v.DuplicateNames = append(v.DuplicateNames, req.Name)
+			v.Errors = append(v.Errors, map[string]interface{}{
+				"index": i,
+				"name":  req.Name,
+				"error": fmt.Sprintf("category with name '%s' already exists", req.Name),
+			})
+		}
+		inFile[req.Name] = true
+	}
+
+	missing := make(map[string]bool)
+	for i, req := range reqs {
+		for _, parent := range req.ParentNames {
+			if inFile[parent] || stored[parent] {
+				continue
+			}
+			if !missing[parent] {
+				missing[parent] = true
+				v.MissingParents = append(v.MissingParents, parent)
+			}
+			v.Errors = append(v.Errors, map[string]interface{}{
+				"index":  i,
+				"name":   req.Name,
+				"parent": parent,
+				"error":  fmt.Sprintf("parent category '%s' not found", parent),
+			})
+		}
+	}
+
+	_, cycles := orderCategoryImport(reqs)
+	for _, cycle := range cycles {
+		v.Cycles = append(v.Cycles, cycle)
+		v.Errors = append(v.Errors, map[string]interface{}{
+			"names": cycle,
+			"error": fmt.Sprintf("parent cycle: %s", strings.Join(cycle, " -> ")),
+		})
+	}
+
+	return v, nil
+}
+
+// BulkCreateCategories validates reqs and, if the import is clean, creates the
+// categories parents-first so children in the same file can resolve them.
+// The validation report is returned alongside ErrInvalidCategoryImport when
+// the import is rejected.
+func (s *CategoryServiceDDB) BulkCreateCategories(ctx context.Context, reqs []CategoryCreateRequest) ([]*models.Category, *models.CategoryImportValidation, error) {
+	v, err := s.ValidateCategoryImport(ctx, reqs)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !v.Valid() {
+		return nil, v, ErrInvalidCategoryImport
+	}
+
+	ordered, _ := orderCategoryImport(reqs)
+	created := make([]*models.Category, 0, len(ordered))
+	for _, req := range ordered {
+		category, err := s.CreateCategory(ctx, req)
+		if err != nil {
+			return created, v, fmt.Errorf("failed to create category '%s': %w", req.Name, err)
+		}
+		created = append(created, category)
+	}
+	return created, v, nil
+}
+
+// orderCategoryImport sorts reqs so every category follows its in-file
+// parents, keeping file order otherwise. Categories that cannot be placed are
+// left out and the parent cycles responsible are returned, each listed from
+// child to parent. Only the first request with a given name is considered.
+func orderCategoryImport(reqs []CategoryCreateRequest) ([]CategoryCreateRequest, [][]string) {
+	index := make(map[string]int, len(reqs))
+	for i, req := range reqs {
+		if _, ok := index[req.Name]; !ok {
+			index[req.Name] = i
+		}
+	}
+
+	pending := make(map[int]int, len(index))
+	children := make(map[int][]int)
+	for _, i := range index {
+		seen := make(map[string]bool)
+		for _, parent := range reqs[i].ParentNames {
+			p, ok := index[parent]
+			if !ok || seen[parent] {
+				continue
+			}
+			seen[parent] = true
+			pending[i]++
+			children[p] = append(children[p], i)
+		}
+	}
+	for p := range children {
+		sort.Ints(children[p])
+	}
+
+	var queue []int
+	for i, req := range reqs {
+		if index[req.Name] == i && pending[i] == 0 {
+			queue = append(queue, i)
+		}
+	}
+
+	ordered := make([]CategoryCreateRequest, 0, len(index))
+	placed := make(map[int]bool, len(index))
+	for len(queue) > 0 {
+		i := queue[0]
+		queue = queue[1:]
+		ordered = append(ordered, reqs[i])
+		placed[i] = true
+		for _, c := range children[i] {
+			pending[c]--
+			if pending[c] == 0 {
+				queue = append(queue, c)
+			}
+		}
+	}
+	if len(ordered) == len(index) {
+		return ordered, nil
+	}
+
+	// Every unplaced category has an unplaced parent, so following those
+	// parents from any of them must eventually loop.
+	var cycles [][]string
+	visited := make(map[int]bool)
+	for i, req := range reqs {
+		if index[req.Name] != i || placed[i] || visited[i] {
+			continue
+		}
+		pos := make(map[int]int)
+		var path []int
+		for cur := i; !visited[cur]; {
+			visited[cur] = true
+			pos[cur] = len(path)
+			path = append(path, cur)
+			for _, parent := range reqs[cur].ParentNames {
+				if p, ok := index[parent]; ok && !placed[p] {
+					cur = p
+					break
+				}
+			}
+			if start, onPath := pos[cur]; onPath {
+				cycle := make([]string, 0, len(path)-start+1)
+				for _, n := range path[start:] {
+					cycle = append(cycle, reqs[n].Name)
+				}
+				cycles = append(cycles, append(cycle, reqs[cur].Name))
+				break
+			}
+		}
+	}
+	return ordered, cycles
+}
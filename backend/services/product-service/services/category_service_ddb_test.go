@@ -0,0 +1,406 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"product-service/models"
+
+	"github.com/google/uuid"
+	apperrors "github.com/yashrajoria/common/errors"
+)
+
+// fakeCategoryRepo is an in-memory CategoryRepo that mimics the soft-delete
+// semantics of the Dynamo adapter.
+type fakeCategoryRepo struct {
+	categories  map[uuid.UUID]*models.Category
+	hasProducts map[uuid.UUID]bool
+}
+
+func newFakeCategoryRepo() *fakeCategoryRepo {
+	return &fakeCategoryRepo{
+		categories:  make(map[uuid.UUID]*models.Category),
+		hasProducts: make(map[uuid.UUID]bool),
+	}
+}
+
+func (f *fakeCategoryRepo) add(name string, deleted bool) *models.Category {
+	cat := &models.Category{ID: uuid.New(), Name: name, CreatedAt: time.Now().UTC()}
+	if deleted {
+		now := time.Now().UTC()
+		cat.DeletedAt = &now
+	}
+	f.categories[cat.ID] = cat
+	return cat
+}
+
+func (f *fakeCategoryRepo) FindByID(ctx context.Context, id uuid.UUID) (*models.Category, error) {
+	cat, ok := f.categories[id]
+	if !ok || cat.DeletedAt != nil {
+		return nil, fmt.Errorf("category %s: %w", id, apperrors.ErrNotFound)
+	}
+	return cat, nil
+}
+
+func (f *fakeCategoryRepo) FindByName(ctx context.Context, name string) (*models.Category, error) {
+	for _, cat := range f.categories {
+		if cat.Name == name && cat.DeletedAt == nil {
+			return cat, nil
+		}
+	}
+	return nil, fmt.Errorf("category %q: %w", name, apperrors.ErrNotFound)
+}
+
+func (f *fakeCategoryRepo) FindByNames(ctx context.Context, names []string) ([]models.Category, error) {
+	var out []models.Category
+	for _, name := range names {
+		if cat, err := f.FindByName(ctx, name); err == nil {
+			out = append(out, *cat)
+		}
+	}
+	return out, nil
+}
+
+func (f *fakeCategoryRepo) FindAll(ctx context.Context) ([]models.Category, error) {
+	var out []models.Category
+	for _, cat := range f.categories {
+		if cat.DeletedAt == nil {
+			out = append(out, *cat)
+		}
+	}
+	return out, nil
+}
+
+func (f *fakeCategoryRepo) Create(ctx context.Context, category *models.Category) error {
+	f.categories[category.ID] = category
+	return nil
+}
+
+func (f *fakeCategoryRepo) Update(ctx context.Context, id uuid.UUID, updates map[string]interface{}) error {
+	return nil
+}
+
+func (f *fakeCategoryRepo) Delete(ctx context.Context, id uuid.UUID) error {
+	cat, ok := f.categories[id]
+	if !ok || cat.DeletedAt != nil {
+		return apperrors.ErrNotFound
+	}
+	now := time.Now().UTC()
+	cat.DeletedAt = &now
+	return nil
+}
+
+func (f *fakeCategoryRepo) FindDeletedByID(ctx context.Context, id uuid.UUID) (*models.Category, error) {
+	cat, ok := f.categories[id]
+	if !ok || cat.DeletedAt == nil {
+		return nil, apperrors.ErrNotFound
+	}
+	return cat, nil
+}
+
+func (f *fakeCategoryRepo) Restore(ctx context.Context, id uuid.UUID) error {
+	cat, ok := f.categories[id]
+	if !ok || cat.DeletedAt == nil {
+		return apperrors.ErrNotFound
+	}
+	cat.DeletedAt = nil
+	cat.UpdatedAt = time.Now().UTC()
+	return nil
+}
+
+func (f *fakeCategoryRepo) HasProducts(ctx context.Context, categoryID uuid.UUID) (bool, error) {
+	return f.hasProducts[categoryID], nil
+}
+
+func TestRestoreCategory_Success(t *testing.T) {
+	repo := newFakeCategoryRepo()
+	deleted := repo.add("Shoes", true)
+	svc := NewCategoryServiceDDB(repo, nil)
+
+	restored, err := svc.RestoreCategory(context.Background(), deleted.ID)
+	if err != nil {
+		t.Fatalf("expected restore to succeed, got %v", err)
+	}
+	if restored.DeletedAt != nil {
+		t.Fatalf("expected deleted_at to be cleared")
+	}
+	if restored.UpdatedAt.IsZero() {
+		t.Fatalf("expected updated_at to be set")
+	}
+	if _, err := repo.FindByID(context.Background(), deleted.ID); err != nil {
+		t.Fatalf("expected restored category to be active, got %v", err)
+	}
+}
+
+func TestRestoreCategory_NameConflict(t *testing.T) {
+	repo := newFakeCategoryRepo()
+	deleted := repo.add("Shoes", true)
+	repo.add("Shoes", false)
+	svc := NewCategoryServiceDDB(repo, nil)
+
+	_, err := svc.RestoreCategory(context.Background(), deleted.ID)
+	if err == nil || !strings.Contains(err.Error(), "already exists") {
+		t.Fatalf("expected name conflict error, got %v", err)
+	}
+	if repo.categories[deleted.ID].DeletedAt == nil {
+		t.Fatalf("expected category to remain deleted after rejected restore")
+	}
+}
+
+func TestRestoreCategory_NotDeleted(t *testing.T) {
+	repo := newFakeCategoryRepo()
+	active := repo.add("Shoes", false)
+	svc := NewCategoryServiceDDB(repo, nil)
+
+	_, err := svc.RestoreCategory(context.Background(), active.ID)
+	if !errors.Is(err, apperrors.ErrNotFound) {
+		t.Fatalf("expected not found error, got %v", err)
+	}
+}
+
+func TestDeleteCategory_BlockedWithProducts(t *testing.T) {
+	repo := newFakeCategoryRepo()
+	cat := repo.add("Shoes", false)
+	repo.hasProducts[cat.ID] = true
+	svc := NewCategoryServiceDDB(repo, nil)
+
+	err := svc.DeleteCategory(context.Background(), cat.ID)
+	if !errors.Is(err, ErrCategoryHasProducts) {
+		t.Fatalf("expected ErrCategoryHasProducts, got %v", err)
+	}
+	if repo.categories[cat.ID].DeletedAt != nil {
+		t.Fatalf("expected category to remain active")
+	}
+}
+
+func TestDeleteCategory_AllowedWhenEmpty(t *testing.T) {
+	repo := newFakeCategoryRepo()
+	cat := repo.add("Shoes", false)
+	svc := NewCategoryServiceDDB(repo, nil)
+
+	if err := svc.DeleteCategory(context.Background(), cat.ID); err != nil {
+		t.Fatalf("expected delete to succeed, got %v", err)
+	}
+	if repo.categories[cat.ID].DeletedAt == nil {
+		t.Fatalf("expected category to be soft-deleted")
+	}
+}
+
+func TestDeleteCategory_NotFound(t *testing.T) {
+	repo := newFakeCategoryRepo()
+	svc := NewCategoryServiceDDB(repo, nil)
+
+	err := svc.DeleteCategory(context.Background(), uuid.New())
+	if !errors.Is(err, apperrors.ErrNotFound) {
+		t.Fatalf("expected not found error, got %v", err)
+	}
+}
+
+func TestBulkCreateCategories_CreatesParentsFirst(t *testing.T) {
+	repo := newFakeCategoryRepo()
+	repo.add("Home", false)
+	svc := NewCategoryServiceDDB(repo, nil)
+
+	// Children appear before their parents in the file
+	reqs := []CategoryCreateRequest{
+		{Name: "Running Shoes", ParentNames: []string{"Shoes"}},
+		{Name: "Shoes", ParentNames: []string{"Clothing"}},
+		{Name: "Clothing"},
+		{Name: "Kitchen", ParentNames: []string{"Home"}},
+	}
+
+	created, v, err := svc.BulkCreateCategories(context.Background(), reqs)
+	if err != nil {
+		t.Fatalf("expected bulk create to succeed, got %v (report %+v)", err, v)
+	}
+	if len(created) != 4 {
+		t.Fatalf("expected 4 categories created, got %d", len(created))
+	}
+
+	pos := make(map[string]int)
+	for i, cat := range created {
+		pos[cat.Name] = i
+	}
+	if !(pos["Clothing"] < pos["Shoes"] && pos["Shoes"] < pos["Running Shoes"]) {
+		t.Fatalf("expected parents created before children, got order %v", pos)
+	}
+
+	shoes, _ := repo.FindByName(context.Background(), "Shoes")
+	running, _ := repo.FindByName(context.Background(), "Running Shoes")
+	if len(running.ParentIDs) != 1 || running.ParentIDs[0] != shoes.ID {
+		t.Fatalf("expected Running Shoes to reference Shoes, got %v", running.ParentIDs)
+	}
+	if len(running.Ancestors) != 2 {
+		t.Fatalf("expected 2 ancestors for Running Shoes, got %v", running.Ancestors)
+	}
+}
+
+func TestValidateCategoryImport_ReportsMissingParents(t *testing.T) {
+	repo := newFakeCategoryRepo()
+	repo.add("Home", false)
+	svc := NewCategoryServiceDDB(repo, nil)
+
+	reqs := []CategoryCreateRequest{
+		{Name: "Kitchen", ParentNames: []string{"Home"}},
+		{Name: "Cookware", ParentNames: []string{"Kitchenware"}},
+		{Name: "Bakeware", ParentNames: []string{"Kitchenware"}},
+		{Name: "Home"},
+	}
+
+	v, err := svc.ValidateCategoryImport(context.Background(), reqs)
+	if err != nil {
+		t.Fatalf("ValidateCategoryImport returned error: %v", err)
+	}
+	if v.Valid() {
+		t.Fatalf("expected import to be invalid")
+	}
+	if len(v.MissingParents) != 1 || v.MissingParents[0] != "Kitchenware" {
+		t.Fatalf("expected missing parent Kitchenware, got %v", v.MissingParents)
+	}
+	if len(v.DuplicateNames) != 1 || v.DuplicateNames[0] != "Home" {
+		t.Fatalf("expected duplicate Home, got %v", v.DuplicateNames)
+	}
+	// One error per child with a missing parent, plus the duplicate
+	if len(v.Errors) != 3 {
+		t.Fatalf("expected 3 errors, got %v", v.Errors)
+	}
+
+	created, _, err := svc.BulkCreateCategories(context.Background(), reqs)
+	if !errors.Is(err, ErrInvalidCategoryImport) {
+		t.Fatalf("expected ErrInvalidCategoryImport, got %v", err)
+	}
+	if len(created) != 0 || len(repo.categories) != 1 {
+		t.Fatalf("expected nothing created for an invalid import")
+	}
+}
+
+func TestValidateCategoryImport_ReportsCycles(t *testing.T) {
+	svc := NewCategoryServiceDDB(newFakeCategoryRepo(), nil)
+
+	reqs := []CategoryCreateRequest{
+		{Name: "A", ParentNames: []string{"B"}},
+		{Name: "B", ParentNames: []string{"C"}},
+		{Name: "C", ParentNames: []string{"A"}},
+		{Name: "D", ParentNames: []string{"A"}},
+		{Name: "E", ParentNames: []string{"E"}},
+	}
+
+	v, err := svc.ValidateCategoryImport(context.Background(), reqs)
+	if err != nil {
+		t.Fatalf("ValidateCategoryImport returned error: %v", err)
+	}
+	if len(v.Cycles) != 2 {
+		t.Fatalf("expected 2 cycles, got %v", v.Cycles)
+	}
+	if got := strings.Join(v.Cycles[0], ","); got != "A,B,C,A" {
+		t.Fatalf("expected cycle A,B,C,A, got %s", got)
+	}
+	if got := strings.Join(v.Cycles[1], ","); got != "E,E" {
+		t.Fatalf("expected self-cycle E,E, got %s", got)
+	}
+}
+
+func TestBuildCategoryTree_MultiParentCategory(t *testing.T) {
+	men := models.Category{ID: uuid.New(), Name: "Men"}
+	women := models.Category{ID: uuid.New(), Name: "Women"}
+	shoes := models.Category{ID: uuid.New(), Name: "Shoes", ParentIDs: []uuid.UUID{men.ID, women.ID}}
+
+	roots := buildCategoryTree([]models.Category{men, women, shoes}, MaxCategoryTreeDepth)
+
+	if len(roots) != 2 {
+		t.Fatalf("expected 2 roots, got %d", len(roots))
+	}
+	for _, root := range roots {
+		if len(root.Children) != 1 || root.Children[0].Name != "Shoes" {
+			t.Fatalf("expected Shoes under %s, got %v", root.Name, root.Children)
+		}
+	}
+	if roots[0].Children[0] == roots[1].Children[0] {
+		t.Fatalf("expected each parent to get its own copy of a shared child")
+	}
+}
+
+func TestBuildCategoryTree_CapsDepthAndBreaksCycles(t *testing.T) {
+	// Root -> A -> B -> A ... with A and B naming each other as parents
+	root := models.Category{ID: uuid.New(), Name: "Root"}
+	a := models.Category{ID: uuid.New(), Name: "A"}
+	b := models.Category{ID: uuid.New(), Name: "B", ParentIDs: []uuid.UUID{a.ID}}
+	a.ParentIDs = []uuid.UUID{root.ID, b.ID}
+	c := models.Category{ID: uuid.New(), Name: "C", ParentIDs: []uuid.UUID{b.ID}}
+	d := models.Category{ID: uuid.New(), Name: "D", ParentIDs: []uuid.UUID{c.ID}}
+	cats := []models.Category{root, a, b, c, d}
+
+	roots := buildCategoryTree(cats, 10)
+	if len(roots) != 1 {
+		t.Fatalf("expected 1 root, got %d", len(roots))
+	}
+	// The cycle is cut where A would reappear under B
+	bNode := roots[0].Children[0].Children[0]
+	if bNode.Name != "B" || len(bNode.Children) != 1 || bNode.Children[0].Name != "C" {
+		t.Fatalf("expected B to contain only C, got %+v", bNode.Children)
+	}
+
+	capped := buildCategoryTree(cats, 2)
+	aNode := capped[0].Children[0]
+	if aNode.Name != "A" || len(aNode.Children) != 0 {
+		t.Fatalf("expected tree capped at depth 2, got %+v", aNode.Children)
+	}
+	// The input is left untouched
+	if cats[1].Children != nil {
+		t.Fatalf("expected source categories to be unmodified")
+	}
+}
+
+func TestCreateCategory_DuplicateCheckUsesTypedNotFound(t *testing.T) {
+	repo := newFakeCategoryRepo()
+	svc := NewCategoryServiceDDB(repo, nil)
+
+	if _, err := svc.CreateCategory(context.Background(), CategoryCreateRequest{Name: "Shoes"}); err != nil {
+		t.Fatalf("expected create to succeed when lookup returns ErrNotFound, got %v", err)
+	}
+	_, err := svc.CreateCategory(context.Background(), CategoryCreateRequest{Name: "Shoes"})
+	if err == nil || !strings.Contains(err.Error(), "already exists") {
+		t.Fatalf("expected duplicate error, got %v", err)
+	}
+}
+
+func TestListCategoryProducts_Paginates(t *testing.T) {
+	_, pr, cr := newTestProductService()
+	books := cr.add("Books", false)
+	toys := cr.add("Toys", false)
+	for i := 0; i < 25; i++ {
+		pr.products[uuid.New()] = &models.Product{SKU: fmt.Sprintf("BK-%02d", i), CategoryIDs: []uuid.UUID{books.ID}}
+	}
+	pr.products[uuid.New()] = &models.Product{SKU: "TOY-1", CategoryIDs: []uuid.UUID{toys.ID}}
+	svc := NewCategoryServiceDDB(cr, pr)
+
+	first, total, err := svc.ListCategoryProducts(context.Background(), books.ID, 1, 10)
+	if err != nil {
+		t.Fatalf("ListCategoryProducts returned error: %v", err)
+	}
+	if total != 25 || len(first) != 10 {
+		t.Fatalf("expected 10 of 25 products on page 1, got %d of %d", len(first), total)
+	}
+
+	last, _, err := svc.ListCategoryProducts(context.Background(), books.ID, 3, 10)
+	if err != nil {
+		t.Fatalf("ListCategoryProducts returned error: %v", err)
+	}
+	if len(last) != 5 || last[0].SKU != "BK-20" {
+		t.Fatalf("expected last page to hold BK-20..BK-24, got %d starting %s", len(last), last[0].SKU)
+	}
+}
+
+func TestListCategoryProducts_UnknownCategory(t *testing.T) {
+	_, pr, cr := newTestProductService()
+	svc := NewCategoryServiceDDB(cr, pr)
+
+	_, _, err := svc.ListCategoryProducts(context.Background(), uuid.New(), 1, 10)
+	if !errors.Is(err, apperrors.ErrNotFound) {
+		t.Fatalf("expected ErrNotFound, got %v", err)
+	}
+}
@@ -0,0 +1,95 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"product-service/repository"
+
+	"github.com/google/uuid"
+)
+
+// Inventory event types sent by inventory-service.
+const (
+	InventoryLowStock   = "low_stock"
+	InventoryOutOfStock = "out_of_stock"
+)
+
+// ErrUnknownInventoryEvent is returned for an event type other than
+// InventoryLowStock or InventoryOutOfStock.
+var ErrUnknownInventoryEvent = errors.New("unknown inventory event")
+
+// InventoryEvent reports a product's stock crossing a threshold.
+type InventoryEvent struct {
+	Event     string    `json:"event"`
+	ProductID uuid.UUID `json:"product_id"`
+	Available *int      `json:"available,omitempty"` // units left; implied 0 for out_of_stock
+}
+
+// InventoryEventResult describes what handling an event changed.
+type InventoryEventResult struct {
+	QuantityUpdated bool `json:"quantity_updated"`
+	Unfeatured      bool `json:"unfeatured"`
+}
+
+// Changed reports whether the product was modified.
+func (r InventoryEventResult) Changed() bool {
+	return r.QuantityUpdated || r.Unfeatured
+}
+
+// SetUnfeatureOn sets which inventory event types clear is_featured. By
+// default none do and events only sync the product's quantity.
+func (s *ProductServiceDDB) SetUnfeatureOn(events []string) error {
+	unfeatureOn := make(map[string]bool, len(events))
+	for _, e := range events {
+		if e != InventoryLowStock && e != InventoryOutOfStock {
+			return fmt.Errorf("%w %q", ErrUnknownInventoryEvent, e)
+		}
+		unfeatureOn[e] = true
+	}
+	s.unfeatureOn = unfeatureOn
+	return nil
+}
+
+// HandleInventoryEvent brings the product in line with an inventory event.
+// The product's quantity is set to the units left, so an out_of_stock product
+// no longer matches in_stock=true, and the product is unfeatured when the
+// event type is enabled through SetUnfeatureOn.
+func (s *ProductServiceDDB) HandleInventoryEvent(ctx context.Context, ev InventoryEvent) (InventoryEventResult, error) {
+	var result InventoryEventResult
+
+	available := ev.Available
+	switch ev.Event {
+	case InventoryOutOfStock:
+		zero := 0
+		available = &zero
+	case InventoryLowStock:
+	default:
+		return result, fmt.Errorf("%w %q", ErrUnknownInventoryEvent, ev.Event)
+	}
+
+	product, err := s.productRepo.FindByID(ctx, ev.ProductID)
+	if err != nil {
+		return result, err
+	}
+
+	updates := make(map[string]interface{})
+	if available != nil && *available >= 0 && product.Quantity != *available {
+		updates["quantity"] = *available
+		result.QuantityUpdated = true
+	}
+	if s.unfeatureOn[ev.Event] && product.IsFeatured {
+		updates["is_featured"] = false
+		result.Unfeatured = true
+	}
+	if !result.Changed() {
+		return result, nil
+	}
+
+	updates["updated_at"] = repository.FormatTimestamp(repository.Now())
+	if err := s.productRepo.Update(ctx, ev.ProductID, updates); err != nil {
+		return InventoryEventResult{}, err
+	}
+	return result, nil
+}
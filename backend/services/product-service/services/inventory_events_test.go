@@ -0,0 +1,100 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"product-service/models"
+
+	"github.com/google/uuid"
+	apperrors "github.com/yashrajoria/common/errors"
+)
+
+func newFeaturedProduct(pr *fakeProductRepo, quantity int) *models.Product {
+	p := &models.Product{ID: uuid.New(), Name: "Runner", SKU: "RUN-1", Quantity: quantity, IsFeatured: true}
+	pr.products[p.ID] = p
+	return p
+}
+
+func TestHandleInventoryEvent_OutOfStockUnfeaturesWhenEnabled(t *testing.T) {
+	svc, pr, _ := newTestProductService()
+	if err := svc.SetUnfeatureOn([]string{InventoryOutOfStock}); err != nil {
+		t.Fatalf("SetUnfeatureOn: %v", err)
+	}
+	p := newFeaturedProduct(pr, 4)
+
+	result, err := svc.HandleInventoryEvent(context.Background(), InventoryEvent{Event: InventoryOutOfStock, ProductID: p.ID})
+	if err != nil {
+		t.Fatalf("HandleInventoryEvent: %v", err)
+	}
+	if !result.Unfeatured || !result.QuantityUpdated {
+		t.Fatalf("expected product unfeatured and quantity updated, got %+v", result)
+	}
+	if p.IsFeatured {
+		t.Fatal("expected is_featured to be cleared")
+	}
+	if p.Quantity != 0 {
+		t.Fatalf("expected quantity 0, got %d", p.Quantity)
+	}
+}
+
+func TestHandleInventoryEvent_OutOfStockKeepsFeaturedWhenDisabled(t *testing.T) {
+	svc, pr, _ := newTestProductService()
+	p := newFeaturedProduct(pr, 4)
+
+	result, err := svc.HandleInventoryEvent(context.Background(), InventoryEvent{Event: InventoryOutOfStock, ProductID: p.ID})
+	if err != nil {
+		t.Fatalf("HandleInventoryEvent: %v", err)
+	}
+	if result.Unfeatured || !p.IsFeatured {
+		t.Fatal("expected product to stay featured when unfeaturing is disabled")
+	}
+	if p.Quantity != 0 {
+		t.Fatalf("expected quantity 0, got %d", p.Quantity)
+	}
+}
+
+func TestHandleInventoryEvent_LowStock(t *testing.T) {
+	svc, pr, _ := newTestProductService()
+	if err := svc.SetUnfeatureOn([]string{InventoryOutOfStock}); err != nil {
+		t.Fatalf("SetUnfeatureOn: %v", err)
+	}
+	p := newFeaturedProduct(pr, 40)
+	available := 3
+
+	result, err := svc.HandleInventoryEvent(context.Background(), InventoryEvent{Event: InventoryLowStock, ProductID: p.ID, Available: &available})
+	if err != nil {
+		t.Fatalf("HandleInventoryEvent: %v", err)
+	}
+	if result.Unfeatured || !p.IsFeatured {
+		t.Fatal("low_stock must not unfeature unless enabled")
+	}
+	if p.Quantity != 3 {
+		t.Fatalf("expected quantity synced to 3, got %d", p.Quantity)
+	}
+
+	// Repeating the event changes nothing
+	result, err = svc.HandleInventoryEvent(context.Background(), InventoryEvent{Event: InventoryLowStock, ProductID: p.ID, Available: &available})
+	if err != nil {
+		t.Fatalf("HandleInventoryEvent: %v", err)
+	}
+	if result.Changed() {
+		t.Fatalf("expected no change on a repeated event, got %+v", result)
+	}
+}
+
+func TestHandleInventoryEvent_Errors(t *testing.T) {
+	svc, pr, _ := newTestProductService()
+	p := newFeaturedProduct(pr, 1)
+
+	if _, err := svc.HandleInventoryEvent(context.Background(), InventoryEvent{Event: "restocked", ProductID: p.ID}); !errors.Is(err, ErrUnknownInventoryEvent) {
+		t.Fatalf("expected ErrUnknownInventoryEvent, got %v", err)
+	}
+	if _, err := svc.HandleInventoryEvent(context.Background(), InventoryEvent{Event: InventoryOutOfStock, ProductID: uuid.New()}); !errors.Is(err, apperrors.ErrNotFound) {
+		t.Fatalf("expected ErrNotFound, got %v", err)
+	}
+	if err := svc.SetUnfeatureOn([]string{"sold_out"}); !errors.Is(err, ErrUnknownInventoryEvent) {
+		t.Fatalf("expected SetUnfeatureOn to reject unknown events, got %v", err)
+	}
+}
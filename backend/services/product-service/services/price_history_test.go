@@ -0,0 +1,85 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"product-service/models"
+
+	"github.com/google/uuid"
+	apperrors "github.com/yashrajoria/common/errors"
+)
+
+// fakePriceHistory is an in-memory PriceHistoryRepo.
+type fakePriceHistory struct {
+	changes []models.PriceChange
+}
+
+func (f *fakePriceHistory) Create(ctx context.Context, change *models.PriceChange) error {
+	f.changes = append(f.changes, *change)
+	return nil
+}
+
+func (f *fakePriceHistory) ListByProduct(ctx context.Context, productID uuid.UUID) ([]models.PriceChange, error) {
+	var out []models.PriceChange
+	for i := len(f.changes) - 1; i >= 0; i-- {
+		if f.changes[i].ProductID == productID {
+			out = append(out, f.changes[i])
+		}
+	}
+	return out, nil
+}
+
+func TestUpdateProduct_RecordsPriceChange(t *testing.T) {
+	pr := newFakeProductRepo()
+	history := &fakePriceHistory{}
+	svc := NewProductServiceDDB(pr, newFakeCategoryRepo(), history, nil, nil, "", "", "", "")
+	product := &models.Product{ID: uuid.New(), Name: "Runner", Price: 80}
+	pr.products[product.ID] = product
+
+	if _, err := svc.UpdateProduct(context.Background(), product.ID, map[string]interface{}{"price": 95.5}, "admin-1"); err != nil {
+		t.Fatalf("update: %v", err)
+	}
+
+	if len(history.changes) != 1 {
+		t.Fatalf("expected one price change, got %d", len(history.changes))
+	}
+	got := history.changes[0]
+	if got.ProductID != product.ID || got.OldPrice != 80 || got.NewPrice != 95.5 || got.ChangedBy != "admin-1" || got.ChangedAt.IsZero() {
+		t.Fatalf("unexpected price change %+v", got)
+	}
+
+	list, err := svc.PriceHistory(context.Background(), product.ID)
+	if err != nil || len(list) != 1 {
+		t.Fatalf("expected history with one entry, got %v (err=%v)", list, err)
+	}
+}
+
+func TestUpdateProduct_NoPriceChangeNotRecorded(t *testing.T) {
+	pr := newFakeProductRepo()
+	history := &fakePriceHistory{}
+	svc := NewProductServiceDDB(pr, newFakeCategoryRepo(), history, nil, nil, "", "", "", "")
+	product := &models.Product{ID: uuid.New(), Name: "Runner", Price: 80}
+	pr.products[product.ID] = product
+
+	updates := []map[string]interface{}{
+		{"name": "Runner 2"},
+		{"price": 80.0, "name": "Runner 3"},
+	}
+	for _, u := range updates {
+		if _, err := svc.UpdateProduct(context.Background(), product.ID, u, "admin-1"); err != nil {
+			t.Fatalf("update %v: %v", u, err)
+		}
+	}
+	if len(history.changes) != 0 {
+		t.Fatalf("expected no price changes, got %+v", history.changes)
+	}
+}
+
+func TestPriceHistory_UnknownProduct(t *testing.T) {
+	svc, _, _ := newTestProductService()
+	if _, err := svc.PriceHistory(context.Background(), uuid.New()); !errors.Is(err, apperrors.ErrNotFound) {
+		t.Fatalf("expected ErrNotFound, got %v", err)
+	}
+}
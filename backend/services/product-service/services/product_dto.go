@@ -0,0 +1,138 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"product-service/models"
+	"product-service/repository"
+
+	"github.com/google/uuid"
+	apperrors "github.com/yashrajoria/common/errors"
+)
+
+// ProductDTO is the public representation of a product returned by the read
+// endpoints. It leaves out soft-delete state and the raw category IDs and
+// paths, exposing category names instead. Service-to-service callers use
+// ProductInternalDTO.
+type ProductDTO struct {
+	ID            uuid.UUID `json:"_id"`
+	Name          string    `json:"name"`
+	Price         float64   `json:"price"`
+	Quantity      int       `json:"quantity"`
+	Description   string    `json:"description,omitempty"`
+	Images        []string  `json:"images,omitempty"`
+	Brand         string    `json:"brand,omitempty"`
+	SKU           string    `json:"sku"`
+	Categories    []string  `json:"categories"`
+	IsFeatured    bool      `json:"is_featured"`
+	AverageRating float64   `json:"average_rating"`
+	ReviewCount   int       `json:"review_count"`
+	CreatedAt     time.Time `json:"created_at"`
+	UpdatedAt     time.Time `json:"updated_at"`
+
+	// EffectivePrice is what the customer pays now: SalePrice while the sale
+	// window is open, otherwise Price.
+	EffectivePrice float64    `json:"effective_price"`
+	OnSale         bool       `json:"on_sale"`
+	SalePrice      *float64   `json:"sale_price,omitempty"`
+	SaleStartsAt   *time.Time `json:"sale_starts_at,omitempty"`
+	SaleEndsAt     *time.Time `json:"sale_ends_at,omitempty"`
+
+	// CategoryDetails is only set when categories are expanded.
+	CategoryDetails []CategoryRef `json:"category_details,omitempty"`
+}
+
+// CategoryRef identifies a category in an expanded product response.
+type CategoryRef struct {
+	ID   uuid.UUID `json:"id"`
+	Name string    `json:"name"`
+	Slug string    `json:"slug"`
+}
+
+// ProductDTOOptions controls optional parts of the public product shape.
+type ProductDTOOptions struct {
+	ExpandCategories bool // include CategoryDetails
+}
+
+// NewProductDTO maps p to its public shape with the given category names,
+// pricing it as of now.
+func NewProductDTO(p *models.Product, categories []string) ProductDTO {
+	return newProductDTO(p, categories, time.Now())
+}
+
+func newProductDTO(p *models.Product, categories []string, now time.Time) ProductDTO {
+	if categories == nil {
+		categories = []string{}
+	}
+	return ProductDTO{
+		ID:            p.ID,
+		Name:          p.Name,
+		Price:         p.Price,
+		Quantity:      p.Quantity,
+		Description:   p.Description,
+		Images:        p.Images,
+		Brand:         p.Brand,
+		SKU:           p.SKU,
+		Categories:    categories,
+		IsFeatured:    p.IsFeatured,
+		AverageRating: p.AverageRating,
+		ReviewCount:   p.ReviewCount,
+		CreatedAt:     p.CreatedAt,
+		UpdatedAt:     p.UpdatedAt,
+
+		EffectivePrice: p.EffectivePrice(now),
+		OnSale:         p.OnSale(now),
+		SalePrice:      p.SalePrice,
+		SaleStartsAt:   p.SaleStartsAt,
+		SaleEndsAt:     p.SaleEndsAt,
+	}
+}
+
+// publicProducts converts products to DTOs. Each distinct category ID is
+// looked up once per call, so a page of products sharing categories costs one
+// lookup per category. Categories that no longer exist are left out.
+func publicProducts(ctx context.Context, categories repository.CategoryRepo, products []*models.Product, opts ProductDTOOptions) ([]ProductDTO, error) {
+	found := make(map[uuid.UUID]*models.Category)
+	resolved := make(map[uuid.UUID]bool)
+	dtos := make([]ProductDTO, 0, len(products))
+	for _, p := range products {
+		var names []string
+		var details []CategoryRef
+		for _, id := range p.CategoryIDs {
+			if !resolved[id] {
+				cat, err := categories.FindByID(ctx, id)
+				if err != nil && !errors.Is(err, apperrors.ErrNotFound) {
+					return nil, err
+				}
+				if cat != nil {
+					found[id] = cat
+				}
+				resolved[id] = true
+			}
+			cat, ok := found[id]
+			if !ok {
+				continue
+			}
+			names = append(names, cat.Name)
+			if opts.ExpandCategories {
+				details = append(details, CategoryRef{ID: cat.ID, Name: cat.Name, Slug: cat.Slug})
+			}
+		}
+		dto := NewProductDTO(p, names)
+		dto.CategoryDetails = details
+		dtos = append(dtos, dto)
+	}
+	return dtos, nil
+}
+
+// PublicProducts converts products to their public DTOs.
+func (s *ProductServiceDDB) PublicProducts(ctx context.Context, products []*models.Product, opts ProductDTOOptions) ([]ProductDTO, error) {
+	return publicProducts(ctx, s.categoryRepo, products, opts)
+}
+
+// PublicProducts converts products to their public DTOs.
+func (s *CategoryServiceDDB) PublicProducts(ctx context.Context, products []*models.Product, opts ProductDTOOptions) ([]ProductDTO, error) {
+	return publicProducts(ctx, s.repo, products, opts)
+}
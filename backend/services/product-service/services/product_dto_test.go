@@ -0,0 +1,111 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"product-service/models"
+
+	"github.com/google/uuid"
+)
+
+// countingCategoryRepo counts FindByID lookups.
+type countingCategoryRepo struct {
+	*fakeCategoryRepo
+	lookups int
+}
+
+func (c *countingCategoryRepo) FindByID(ctx context.Context, id uuid.UUID) (*models.Category, error) {
+	c.lookups++
+	return c.fakeCategoryRepo.FindByID(ctx, id)
+}
+
+func TestPublicProducts_ResolvesCategoryNamesAndHidesInternalFields(t *testing.T) {
+	repo := &countingCategoryRepo{fakeCategoryRepo: newFakeCategoryRepo()}
+	shoes := repo.add("Shoes", false)
+	running := repo.add("Running", false)
+	retired := repo.add("Retired", true)
+
+	deletedAt := time.Now().UTC()
+	products := []*models.Product{
+		{ID: uuid.New(), Name: "Runner", CategoryIDs: []uuid.UUID{shoes.ID, running.ID, retired.ID}, CategoryPath: []string{"Shoes", "Running"}, DeletedAt: &deletedAt},
+		{ID: uuid.New(), Name: "Trail", CategoryIDs: []uuid.UUID{shoes.ID, running.ID}},
+	}
+
+	dtos, err := publicProducts(context.Background(), repo, products, ProductDTOOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(dtos) != 2 {
+		t.Fatalf("expected 2 DTOs, got %d", len(dtos))
+	}
+	if got := dtos[0].Categories; len(got) != 2 || got[0] != "Shoes" || got[1] != "Running" {
+		t.Fatalf("expected category names [Shoes Running], got %v", got)
+	}
+	if repo.lookups != 3 {
+		t.Fatalf("expected each category to be looked up once, got %d lookups", repo.lookups)
+	}
+
+	body, err := json.Marshal(dtos[0])
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	var fields map[string]interface{}
+	if err := json.Unmarshal(body, &fields); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	for _, hidden := range []string{"deleted_at", "category_ids", "category_path", "image_uploads"} {
+		if _, ok := fields[hidden]; ok {
+			t.Errorf("public DTO must not expose %s: %s", hidden, body)
+		}
+	}
+	for _, shown := range []string{"_id", "name", "price", "sku", "categories"} {
+		if _, ok := fields[shown]; !ok {
+			t.Errorf("public DTO is missing %s: %s", shown, body)
+		}
+	}
+}
+
+func TestNewProductDTO_EmptyCategoriesSerializeAsArray(t *testing.T) {
+	body, _ := json.Marshal(NewProductDTO(&models.Product{Name: "x"}, nil))
+	var fields map[string]interface{}
+	_ = json.Unmarshal(body, &fields)
+	if cats, ok := fields["categories"].([]interface{}); !ok || len(cats) != 0 {
+		t.Fatalf("expected empty categories array, got %s", body)
+	}
+}
+
+func TestPublicProducts_ExpandCategories(t *testing.T) {
+	repo := newFakeCategoryRepo()
+	shoes := repo.add("Shoes", false)
+	shoes.Slug = "shoes"
+	product := &models.Product{ID: uuid.New(), Name: "Runner", CategoryIDs: []uuid.UUID{shoes.ID}}
+
+	plain, err := publicProducts(context.Background(), repo, []*models.Product{product}, ProductDTOOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if plain[0].CategoryDetails != nil {
+		t.Fatalf("expected no category details without expand, got %+v", plain[0].CategoryDetails)
+	}
+	body, _ := json.Marshal(plain[0])
+	var fields map[string]interface{}
+	_ = json.Unmarshal(body, &fields)
+	if _, ok := fields["category_details"]; ok {
+		t.Fatalf("category_details must be omitted without expand: %s", body)
+	}
+
+	expanded, err := publicProducts(context.Background(), repo, []*models.Product{product}, ProductDTOOptions{ExpandCategories: true})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	got := expanded[0].CategoryDetails
+	if len(got) != 1 || got[0].ID != shoes.ID || got[0].Name != "Shoes" || got[0].Slug != "shoes" {
+		t.Fatalf("unexpected category details %+v", got)
+	}
+	if names := expanded[0].Categories; len(names) != 1 || names[0] != "Shoes" {
+		t.Fatalf("expected names alongside details, got %v", names)
+	}
+}
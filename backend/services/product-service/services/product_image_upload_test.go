@@ -0,0 +1,177 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"mime/multipart"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/feature/s3/manager"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+)
+
+// fakeObjectPutter fails uploads whose key contains any of failKeys.
+type fakeObjectPutter struct {
+	failKeys []string
+	keys     []string
+}
+
+func (f *fakeObjectPutter) PutObject(ctx context.Context, in *s3.PutObjectInput, optFns ...func(*s3.Options)) (*s3.PutObjectOutput, error) {
+	for _, k := range f.failKeys {
+		if strings.Contains(*in.Key, k) {
+			return nil, errors.New("access denied")
+		}
+	}
+	f.keys = append(f.keys, *in.Key)
+	return &s3.PutObjectOutput{}, nil
+}
+
+// fakeObjectUploader records multipart uploads and the bytes streamed.
+type fakeObjectUploader struct {
+	keys  []string
+	bytes []int
+}
+
+func (f *fakeObjectUploader) Upload(ctx context.Context, in *s3.PutObjectInput, opts ...func(*manager.Uploader)) (*manager.UploadOutput, error) {
+	n, err := io.Copy(io.Discard, in.Body)
+	if err != nil {
+		return nil, err
+	}
+	f.keys = append(f.keys, *in.Key)
+	f.bytes = append(f.bytes, int(n))
+	return &manager.UploadOutput{}, nil
+}
+
+func imageHeaders(t *testing.T, names ...string) []*multipart.FileHeader {
+	t.Helper()
+	contents := make(map[string][]byte, len(names))
+	for _, name := range names {
+		contents[name] = []byte("image-bytes")
+	}
+	return sizedImageHeaders(t, names, contents)
+}
+
+// sizedImageHeaders builds one file header per name, in order, holding
+// contents[name].
+func sizedImageHeaders(t *testing.T, names []string, contents map[string][]byte) []*multipart.FileHeader {
+	t.Helper()
+	var buf bytes.Buffer
+	mw := multipart.NewWriter(&buf)
+	for _, name := range names {
+		part, _ := mw.CreateFormFile("images", name)
+		_, _ = part.Write(contents[name])
+	}
+	_ = mw.Close()
+
+	form, err := multipart.NewReader(&buf, mw.Boundary()).ReadForm(1 << 20)
+	if err != nil {
+		t.Fatalf("failed to build multipart form: %v", err)
+	}
+	return form.File["images"]
+}
+
+func TestCreateProduct_ReportsPartialImageFailures(t *testing.T) {
+	svc, pr, cr := newTestProductService()
+	cr.add("Books", false)
+	putter := &fakeObjectPutter{failKeys: []string{"_1"}}
+	svc.s3Client = putter
+	svc.bucket = "products"
+
+	req := ProductCreateRequest{Name: "Widget", SKU: "SKU-1", Price: 5, Categories: []string{"Books"}}
+	product, err := svc.CreateProduct(context.Background(), req, imageHeaders(t, "a.png", "b.png", "c.png"))
+	if err != nil {
+		t.Fatalf("expected create to succeed with partial uploads, got %v", err)
+	}
+
+	if len(product.Images) != 2 {
+		t.Fatalf("expected 2 stored images, got %v", product.Images)
+	}
+	if len(product.ImageUploads) != 3 {
+		t.Fatalf("expected a result for each of 3 images, got %v", product.ImageUploads)
+	}
+	for i, r := range product.ImageUploads {
+		failed := i == 1
+		if failed != (r.Error != "") || failed != (r.URL == "") {
+			t.Fatalf("image %d (%s): unexpected result %+v", i, r.Filename, r)
+		}
+	}
+	if product.ImageUploads[1].Filename != "b.png" {
+		t.Fatalf("expected failure reported for b.png, got %+v", product.ImageUploads[1])
+	}
+	if _, ok := pr.products[product.ID]; !ok {
+		t.Fatalf("expected product to be stored")
+	}
+}
+
+func TestCreateProduct_FailsWhenNoImageUploads(t *testing.T) {
+	svc, pr, cr := newTestProductService()
+	cr.add("Books", false)
+	svc.s3Client = &fakeObjectPutter{failKeys: []string{"product_img_"}}
+
+	req := ProductCreateRequest{Name: "Widget", SKU: "SKU-1", Price: 5, Categories: []string{"Books"}}
+	_, err := svc.CreateProduct(context.Background(), req, imageHeaders(t, "a.png", "b.png"))
+	if !errors.Is(err, ErrNoImagesUploaded) {
+		t.Fatalf("expected ErrNoImagesUploaded, got %v", err)
+	}
+	if len(pr.products) != 0 {
+		t.Fatalf("expected no product stored when every upload fails")
+	}
+}
+
+func TestCreateProduct_LargeImagesUseMultipartUpload(t *testing.T) {
+	svc, _, cr := newTestProductService()
+	cr.add("Books", false)
+	putter := &fakeObjectPutter{}
+	uploader := &fakeObjectUploader{}
+	svc.s3Client = putter
+	svc.uploader = uploader
+	svc.multipartThreshold = 1024
+
+	large := bytes.Repeat([]byte("x"), 4096)
+	headers := sizedImageHeaders(t, []string{"small.png", "large.png"}, map[string][]byte{
+		"small.png": []byte("image-bytes"),
+		"large.png": large,
+	})
+
+	req := ProductCreateRequest{Name: "Widget", SKU: "SKU-1", Price: 5, Categories: []string{"Books"}}
+	product, err := svc.CreateProduct(context.Background(), req, headers)
+	if err != nil {
+		t.Fatalf("CreateProduct: %v", err)
+	}
+	if len(product.Images) != 2 {
+		t.Fatalf("expected 2 stored images, got %v", product.Images)
+	}
+
+	if len(putter.keys) != 1 || !strings.HasSuffix(putter.keys[0], "_0") {
+		t.Fatalf("expected only the small image sent with PutObject, got %v", putter.keys)
+	}
+	if len(uploader.keys) != 1 || !strings.HasSuffix(uploader.keys[0], "_1") {
+		t.Fatalf("expected only the large image sent with the uploader, got %v", uploader.keys)
+	}
+	if uploader.bytes[0] != len(large) {
+		t.Fatalf("expected %d bytes streamed, got %d", len(large), uploader.bytes[0])
+	}
+}
+
+func TestCreateProduct_SmallImagesUsePutObject(t *testing.T) {
+	svc, _, cr := newTestProductService()
+	cr.add("Books", false)
+	putter := &fakeObjectPutter{}
+	uploader := &fakeObjectUploader{}
+	svc.s3Client = putter
+	svc.uploader = uploader
+
+	req := ProductCreateRequest{Name: "Widget", SKU: "SKU-1", Price: 5, Categories: []string{"Books"}}
+	if _, err := svc.CreateProduct(context.Background(), req, imageHeaders(t, "a.png", "b.png")); err != nil {
+		t.Fatalf("CreateProduct: %v", err)
+	}
+	if len(putter.keys) != 2 {
+		t.Fatalf("expected 2 PutObject calls, got %v", putter.keys)
+	}
+	if len(uploader.keys) != 0 {
+		t.Fatalf("expected no multipart uploads below the threshold, got %v", uploader.keys)
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"encoding/csv"
+	"errors"
 	"fmt"
 	"io"
 	"mime/multipart"
@@ -18,39 +19,77 @@ import (
 	"product-service/repository"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/feature/s3/manager"
 	"github.com/aws/aws-sdk-go-v2/service/s3"
 	"github.com/google/uuid"
+	"go.uber.org/zap"
 )
 
+// ErrNoImagesUploaded is returned by CreateProduct when every image upload fails.
+var ErrNoImagesUploaded = errors.New("no product images could be uploaded")
+
+// ErrInvalidSale is returned by UpdateProduct for a malformed sale price or window.
+var ErrInvalidSale = errors.New("invalid sale")
+
+// objectPutter is the subset of the S3 client used for image uploads.
+type objectPutter interface {
+	PutObject(ctx context.Context, params *s3.PutObjectInput, optFns ...func(*s3.Options)) (*s3.PutObjectOutput, error)
+}
+
+// objectUploader streams an object to S3 in parts. It is implemented by
+// manager.Uploader.
+type objectUploader interface {
+	Upload(ctx context.Context, input *s3.PutObjectInput, opts ...func(*manager.Uploader)) (*manager.UploadOutput, error)
+}
+
+// defaultMultipartThreshold is the image size above which uploads are
+// streamed as a multipart upload rather than buffered for a single PutObject.
+const defaultMultipartThreshold = 8 * 1024 * 1024
+
 // ProductServiceDDB is a DynamoDB-backed product service
 type ProductServiceDDB struct {
 	productRepo   repository.ProductRepo
 	categoryRepo  repository.CategoryRepo
-	s3Client      *s3.Client
+	priceHistory  repository.PriceHistoryRepo
+	s3Client      objectPutter
+	uploader      objectUploader // nil without an S3 client
 	presignClient *s3.PresignClient
 	bucket        string
 	prefix        string
 	endpoint      string
 	cdnDomain     string
+
+	// multipartThreshold is the image size in bytes above which uploader is used.
+	multipartThreshold int64
+
+	// unfeatureOn holds the inventory event types that clear is_featured.
+	unfeatureOn map[string]bool
 }
 
 func NewProductServiceDDB(
 	pr repository.ProductRepo,
 	cr repository.CategoryRepo,
+	ph repository.PriceHistoryRepo,
 	s3Client *s3.Client,
 	presignClient *s3.PresignClient,
 	bucket, prefix, endpoint, cdnDomain string,
 ) *ProductServiceDDB {
-	return &ProductServiceDDB{
-		productRepo:   pr,
-		categoryRepo:  cr,
-		s3Client:      s3Client,
-		presignClient: presignClient,
-		bucket:        bucket,
-		prefix:        prefix,
-		endpoint:      endpoint,
-		cdnDomain:     cdnDomain,
-	}
+	s := &ProductServiceDDB{
+		productRepo:        pr,
+		categoryRepo:       cr,
+		priceHistory:       ph,
+		s3Client:           s3Client,
+		presignClient:      presignClient,
+		bucket:             bucket,
+		prefix:             prefix,
+		endpoint:           endpoint,
+		cdnDomain:          cdnDomain,
+		multipartThreshold: defaultMultipartThreshold,
+	}
+	if s3Client != nil {
+		s.uploader = manager.NewUploader(s3Client)
+	}
+	return s
 }
 
 // GeneratePresignedUpload returns a presigned PUT URL, the object key, and the public URL
@@ -71,16 +110,7 @@ func (s *ProductServiceDDB) GeneratePresignedUpload(ctx context.Context, sku, fi
 		return "", "", "", fmt.Errorf("failed to presign put object: %w", err)
 	}
 
-	var publicURL string
-	if s.cdnDomain != "" {
-		publicURL = fmt.Sprintf("https://%s/%s", strings.TrimRight(s.cdnDomain, "/"), key)
-	} else if s.endpoint != "" {
-		publicURL = fmt.Sprintf("%s/%s/%s", strings.TrimRight(s.endpoint, "/"), s.bucket, key)
-	} else {
-		publicURL = fmt.Sprintf("https://%s.s3.amazonaws.com/%s", s.bucket, key)
-	}
-
-	return presignedReq.URL, key, publicURL, nil
+	return presignedReq.URL, key, s.buildPublicURL(s.bucket, key), nil
 }
 
 func (s *ProductServiceDDB) GetProduct(ctx context.Context, id uuid.UUID) (*models.Product, error) {
@@ -103,21 +133,20 @@ func (s *ProductServiceDDB) ListProducts(ctx context.Context, params ListProduct
 	if params.MaxPrice != nil {
 		filter["max_price"] = *params.MaxPrice
 	}
+	if params.Brand != "" {
+		filter["brand"] = params.Brand
+	}
+	if params.InStock != nil {
+		filter["in_stock"] = *params.InStock
+	}
+	if params.Sort != "" {
+		filter[repository.SortKey] = params.Sort
+	}
 
 	limit := params.PerPage
 	skip := (params.Page - 1) * params.PerPage
 
-	products, err := s.productRepo.Find(ctx, filter, limit, skip)
-	if err != nil {
-		return nil, 0, err
-	}
-
-	total, err := s.productRepo.Count(ctx, filter)
-	if err != nil {
-		return nil, 0, err
-	}
-
-	return products, total, nil
+	return s.productRepo.FindPage(ctx, filter, limit, skip)
 }
 
 func (s *ProductServiceDDB) CreateProduct(ctx context.Context, req ProductCreateRequest, images []*multipart.FileHeader) (*models.Product, error) {
@@ -145,39 +174,27 @@ func (s *ProductServiceDDB) CreateProduct(ctx context.Context, req ProductCreate
 		}
 	}
 
-	// Step 2: Upload images to S3
+	// Step 2: Upload images to S3, keeping a result per image so partial
+	// failures are reported rather than silently dropped
 	var imageURLs []string
+	uploads := make([]models.ImageUploadResult, 0, len(images))
 	for i, fileHeader := range images {
-		file, err := fileHeader.Open()
-		if err != nil {
-			continue
-		}
-		defer file.Close()
-		data, err := io.ReadAll(file)
-		if err != nil {
-			continue
-		}
-		key := fmt.Sprintf("%sproduct_img_%s_%d", s.prefix, req.SKU, i)
-		_, err = s.s3Client.PutObject(ctx, &s3.PutObjectInput{
-			Bucket:      aws.String(s.bucket),
-			Key:         aws.String(key),
-			Body:        bytes.NewReader(data),
-			ContentType: aws.String(fileHeader.Header.Get("Content-Type")),
-		})
+		result := models.ImageUploadResult{Filename: fileHeader.Filename}
+		urlStr, err := s.uploadImage(ctx, fileHeader, req.SKU, i)
 		if err != nil {
-			continue
-		}
-		var urlStr string
-		if s.endpoint != "" {
-			urlStr = fmt.Sprintf("%s/%s/%s", strings.TrimRight(s.endpoint, "/"), s.bucket, key)
+			result.Error = err.Error()
 		} else {
-			urlStr = fmt.Sprintf("https://%s.s3.amazonaws.com/%s", s.bucket, key)
+			result.URL = urlStr
+			imageURLs = append(imageURLs, urlStr)
 		}
-		imageURLs = append(imageURLs, urlStr)
+		uploads = append(uploads, result)
+	}
+	if len(images) > 0 && len(imageURLs) == 0 {
+		return nil, fmt.Errorf("%w: %s", ErrNoImagesUploaded, uploads[0].Error)
 	}
 
 	// Step 3: Create the product model
-	now := time.Now().UTC()
+	now := repository.Now()
 	product := &models.Product{
 		ID:          uuid.New(),
 		Name:        req.Name,
@@ -199,26 +216,132 @@ func (s *ProductServiceDDB) CreateProduct(ctx context.Context, req ProductCreate
 		return nil, err
 	}
 
+	product.ImageUploads = uploads
 	return product, nil
 }
 
-func (s *ProductServiceDDB) UpdateProduct(ctx context.Context, id uuid.UUID, updates map[string]interface{}) (int64, error) {
+// uploadImage stores one multipart image in S3 and returns its public URL.
+// Images larger than multipartThreshold are streamed to S3 in parts; smaller
+// ones are read into memory and sent with a single PutObject.
+func (s *ProductServiceDDB) uploadImage(ctx context.Context, fileHeader *multipart.FileHeader, sku string, index int) (string, error) {
+	file, err := fileHeader.Open()
+	if err != nil {
+		return "", fmt.Errorf("failed to open image: %w", err)
+	}
+	defer file.Close()
+	key := fmt.Sprintf("%sproduct_img_%s_%d", s.prefix, sku, index)
+	input := &s3.PutObjectInput{
+		Bucket:      aws.String(s.bucket),
+		Key:         aws.String(key),
+		ContentType: aws.String(fileHeader.Header.Get("Content-Type")),
+	}
+
+	if s.uploader != nil && fileHeader.Size > s.multipartThreshold {
+		input.Body = file
+		if _, err := s.uploader.Upload(ctx, input); err != nil {
+			return "", fmt.Errorf("failed to upload to s3: %w", err)
+		}
+		return s.buildPublicURL(s.bucket, key), nil
+	}
+
+	data, err := io.ReadAll(file)
+	if err != nil {
+		return "", fmt.Errorf("failed to read image: %w", err)
+	}
+	input.Body = bytes.NewReader(data)
+	if _, err := s.s3Client.PutObject(ctx, input); err != nil {
+		return "", fmt.Errorf("failed to upload to s3: %w", err)
+	}
+	return s.buildPublicURL(s.bucket, key), nil
+}
+
+// UpdateProduct applies updates to a product. When the price changes, the
+// old and new prices are recorded in the price history against changedBy.
+func (s *ProductServiceDDB) UpdateProduct(ctx context.Context, id uuid.UUID, updates map[string]interface{}, changedBy string) (int64, error) {
 	if len(updates) == 0 {
 		return 0, fmt.Errorf("no update fields provided")
 	}
 	delete(updates, "_id")
 	delete(updates, "product_id")
+	// Rating aggregates are owned by the review flow
+	delete(updates, "average_rating")
+	delete(updates, "review_count")
+
+	if err := normalizeSaleUpdates(updates); err != nil {
+		return 0, err
+	}
+
+	var change *models.PriceChange
+	if newPrice, ok := updates["price"].(float64); ok {
+		current, err := s.productRepo.FindByID(ctx, id)
+		if err != nil {
+			return 0, err
+		}
+		if current.Price != newPrice {
+			change = &models.PriceChange{ProductID: id, OldPrice: current.Price, NewPrice: newPrice, ChangedBy: changedBy}
+		}
+	}
 
-	updates["updated_at"] = time.Now().UTC().Format(time.RFC3339)
+	now := repository.Now()
+	updates["updated_at"] = repository.FormatTimestamp(now)
 
 	err := s.productRepo.Update(ctx, id, updates)
 	if err != nil {
 		return 0, err
 	}
 
+	if change != nil {
+		change.ChangedAt = now
+		// The price is already saved; a lost audit entry is logged rather
+		// than reported as a failed update
+		if err := s.priceHistory.Create(ctx, change); err != nil {
+			zap.L().Error("failed to record price change", zap.Error(err), zap.String("product_id", id.String()),
+				zap.Float64("old_price", change.OldPrice), zap.Float64("new_price", change.NewPrice))
+		}
+	}
+
 	return 1, nil
 }
 
+// normalizeSaleUpdates validates sale_price, sale_starts_at and sale_ends_at in
+// an update and rewrites the timestamps in their stored form. A null value
+// clears the field.
+func normalizeSaleUpdates(updates map[string]interface{}) error {
+	if v, ok := updates["sale_price"]; ok && v != nil {
+		price, isNum := v.(float64)
+		if !isNum || price < 0 {
+			return fmt.Errorf("%w: sale_price must be a non-negative number", ErrInvalidSale)
+		}
+	}
+
+	var bounds [2]*time.Time
+	for i, field := range []string{"sale_starts_at", "sale_ends_at"} {
+		v, ok := updates[field]
+		if !ok || v == nil {
+			continue
+		}
+		raw, isString := v.(string)
+		t, err := time.Parse(time.RFC3339, raw)
+		if !isString || err != nil {
+			return fmt.Errorf("%w: %s must be an RFC 3339 timestamp", ErrInvalidSale, field)
+		}
+		updates[field] = repository.FormatTimestamp(t)
+		bounds[i] = &t
+	}
+	if bounds[0] != nil && bounds[1] != nil && !bounds[1].After(*bounds[0]) {
+		return fmt.Errorf("%w: sale_ends_at must be after sale_starts_at", ErrInvalidSale)
+	}
+	return nil
+}
+
+// PriceHistory returns a product's price changes, newest first.
+func (s *ProductServiceDDB) PriceHistory(ctx context.Context, id uuid.UUID) ([]models.PriceChange, error) {
+	if _, err := s.productRepo.FindByID(ctx, id); err != nil {
+		return nil, err
+	}
+	return s.priceHistory.ListByProduct(ctx, id)
+}
+
 func (s *ProductServiceDDB) DeleteProduct(ctx context.Context, id uuid.UUID) (int64, error) {
 	err := s.productRepo.Delete(ctx, id)
 	if err != nil {
@@ -227,16 +350,29 @@ func (s *ProductServiceDDB) DeleteProduct(ctx context.Context, id uuid.UUID) (in
 	return 1, nil
 }
 
+// BulkDeleteProducts removes the given products in batches. It fails rather
+// than reporting success if any delete could not be applied.
+func (s *ProductServiceDDB) BulkDeleteProducts(ctx context.Context, ids []uuid.UUID) (int64, error) {
+	if len(ids) == 0 {
+		return 0, nil
+	}
+	if err := s.productRepo.DeleteMany(ctx, ids); err != nil {
+		return 0, err
+	}
+	return int64(len(ids)), nil
+}
+
 func (s *ProductServiceDDB) GetProductInternal(ctx context.Context, id uuid.UUID) (*ProductInternalDTO, error) {
 	product, err := s.productRepo.FindByID(ctx, id)
 	if err != nil {
 		return nil, err
 	}
 
+	// Checkout prices orders from here, so a running sale applies
 	dto := &ProductInternalDTO{
 		ID:    product.ID,
 		Name:  product.Name,
-		Price: product.Price,
+		Price: product.EffectivePrice(time.Now()),
 		Stock: product.Quantity,
 	}
 
@@ -267,7 +403,11 @@ func (s *ProductServiceDDB) ValidateBulkImport(ctx context.Context, file multipa
 	skuSet := make(map[string]int)
 	var errorsList []map[string]interface{}
 	var warningsList []map[string]interface{}
-	rowNum := 2
+	// Rows are reported by their line in the file; csv.Reader drops empty
+	// lines, so a running counter would drift.
+	rowNum := 1
+	invalidRows := 0
+	skippedRows := 0
 
 	for {
 		row, err := r.Read()
@@ -275,11 +415,22 @@ func (s *ProductServiceDDB) ValidateBulkImport(ctx context.Context, file multipa
 			break
 		}
 		if err != nil {
+			var perr *csv.ParseError
+			if errors.As(err, &perr) {
+				rowNum = perr.StartLine
+			} else {
+				rowNum++
+			}
 			errorsList = append(errorsList, map[string]interface{}{
 				"row":   rowNum,
 				"error": "Failed to parse CSV row",
 			})
-			rowNum++
+			skippedRows++
+			continue
+		}
+		rowNum, _ = r.FieldPos(0)
+		if isBlankCSVRow(row) {
+			skippedRows++
 			continue
 		}
 
@@ -292,32 +443,35 @@ func (s *ProductServiceDDB) ValidateBulkImport(ctx context.Context, file multipa
 		hasError := false
 
 		if name == "" {
-			errorsList = append(errorsList, map[string]interface{}{"row": rowNum, "error": "Product name is required"})
+			errorsList = append(errorsList, map[string]interface{}{"row": rowNum, "field": "name", "error": "Product name is required"})
 			hasError = true
 		}
 
 		if sku == "" {
-			errorsList = append(errorsList, map[string]interface{}{"row": rowNum, "error": "SKU is required"})
+			errorsList = append(errorsList, map[string]interface{}{"row": rowNum, "field": "sku", "error": "SKU is required"})
 			hasError = true
 		} else if existingRow, exists := skuSet[sku]; exists {
-			errorsList = append(errorsList, map[string]interface{}{"row": rowNum, "error": fmt.Sprintf("Duplicate SKU '%s' found (also in row %d)", sku, existingRow)})
+			errorsList = append(errorsList, map[string]interface{}{"row": rowNum, "field": "sku", "error": fmt.Sprintf("Duplicate SKU '%s' found (also in row %d)", sku, existingRow)})
 			hasError = true
 		} else {
 			skuSet[sku] = rowNum
 		}
 
-		if _, err := strconv.ParseFloat(priceStr, 64); err != nil {
-			errorsList = append(errorsList, map[string]interface{}{"row": rowNum, "error": "Invalid price format"})
+		if price, err := strconv.ParseFloat(priceStr, 64); err != nil {
+			errorsList = append(errorsList, map[string]interface{}{"row": rowNum, "field": "price", "error": "Invalid price format"})
+			hasError = true
+		} else if price <= 0 {
+			errorsList = append(errorsList, map[string]interface{}{"row": rowNum, "field": "price", "error": "Price must be greater than 0"})
 			hasError = true
 		}
 
 		if _, err := strconv.Atoi(quantityStr); err != nil {
-			errorsList = append(errorsList, map[string]interface{}{"row": rowNum, "error": "Invalid quantity format"})
+			errorsList = append(errorsList, map[string]interface{}{"row": rowNum, "field": "quantity", "error": "Invalid quantity format"})
 			hasError = true
 		}
 
 		if _, err := strconv.ParseBool(isFeaturedStr); err != nil {
-			errorsList = append(errorsList, map[string]interface{}{"row": rowNum, "error": "Invalid is_featured format (must be TRUE or FALSE)"})
+			errorsList = append(errorsList, map[string]interface{}{"row": rowNum, "field": "is_featured", "error": "Invalid is_featured format (must be TRUE or FALSE)"})
 			hasError = true
 		}
 
@@ -338,10 +492,11 @@ func (s *ProductServiceDDB) ValidateBulkImport(ctx context.Context, file multipa
 			}
 		}
 
-		if !hasError {
+		if hasError {
+			invalidRows++
+		} else {
 			pendingProducts = append(pendingProducts, pendingProduct{Row: row, RowNum: rowNum, CategoryNames: currentCatNames, SKU: sku})
 		}
-		rowNum++
 	}
 
 	var catNames []string
@@ -384,9 +539,10 @@ func (s *ProductServiceDDB) ValidateBulkImport(ctx context.Context, file multipa
 	}
 
 	return &models.BulkImportValidation{
-		TotalProducts:     rowNum - 2,
+		TotalProducts:     len(pendingProducts) + invalidRows,
 		ValidProducts:     len(pendingProducts),
-		InvalidProducts:   len(errorsList),
+		InvalidProducts:   invalidRows,
+		SkippedRows:       skippedRows,
 		Errors:            errorsList,
 		Warnings:          warningsList,
 		MissingCategories: missingCategories,
@@ -491,7 +647,7 @@ func (s *ProductServiceDDB) ProcessBulkImport(ctx context.Context, file multipar
 			}
 		}
 
-		now := time.Now().UTC()
+		now := repository.Now()
 		product := models.Product{
 			ID:          uuid.New(),
 			Name:        name,
@@ -549,9 +705,16 @@ func (s *ProductServiceDDB) uploadImageFromURL(ctx context.Context, imageURL, sk
 	if err != nil {
 		return "", fmt.Errorf("failed to upload to s3: %w", err)
 	}
+	return s.buildPublicURL(s.bucket, key), nil
+}
 
-	if s.endpoint != "" {
-		return fmt.Sprintf("%s/%s/%s", strings.TrimRight(s.endpoint, "/"), s.bucket, key), nil
+// isBlankCSVRow reports whether every field in row is empty, as produced by
+// trailing lines of bare delimiters in spreadsheet exports.
+func isBlankCSVRow(row []string) bool {
+	for _, v := range row {
+		if strings.TrimSpace(v) != "" {
+			return false
+		}
 	}
-	return fmt.Sprintf("https://%s.s3.amazonaws.com/%s", s.bucket, key), nil
+	return true
 }
@@ -0,0 +1,232 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"testing"
+
+	"product-service/models"
+
+	"github.com/google/uuid"
+	apperrors "github.com/yashrajoria/common/errors"
+)
+
+// fakeProductRepo is an in-memory ProductRepo keyed by product ID.
+type fakeProductRepo struct {
+	products map[uuid.UUID]*models.Product
+}
+
+func newFakeProductRepo() *fakeProductRepo {
+	return &fakeProductRepo{products: make(map[uuid.UUID]*models.Product)}
+}
+
+func (f *fakeProductRepo) FindByID(ctx context.Context, id uuid.UUID) (*models.Product, error) {
+	p, ok := f.products[id]
+	if !ok {
+		return nil, fmt.Errorf("product %s: %w", id, apperrors.ErrNotFound)
+	}
+	return p, nil
+}
+
+// matching returns the products that satisfy filter's category_ids, ordered
+// by SKU so paging is deterministic.
+func (f *fakeProductRepo) matching(filter map[string]interface{}) []*models.Product {
+	want, _ := filter["category_ids"].([]uuid.UUID)
+	var out []*models.Product
+	for _, p := range f.products {
+		if len(want) == 0 || hasAnyCategory(p, want) {
+			out = append(out, p)
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].SKU < out[j].SKU })
+	return out
+}
+
+func hasAnyCategory(p *models.Product, ids []uuid.UUID) bool {
+	for _, have := range p.CategoryIDs {
+		for _, id := range ids {
+			if have == id {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (f *fakeProductRepo) Find(ctx context.Context, filter map[string]interface{}, limit, skip int) ([]*models.Product, error) {
+	out := f.matching(filter)
+	if skip >= len(out) {
+		return []*models.Product{}, nil
+	}
+	out = out[skip:]
+	if limit > 0 && limit < len(out) {
+		out = out[:limit]
+	}
+	return out, nil
+}
+
+func (f *fakeProductRepo) FindPage(ctx context.Context, filter map[string]interface{}, limit, skip int) ([]*models.Product, int64, error) {
+	page, _ := f.Find(ctx, filter, limit, skip)
+	return page, int64(len(f.matching(filter))), nil
+}
+
+func (f *fakeProductRepo) Create(ctx context.Context, product *models.Product) error {
+	f.products[product.ID] = product
+	return nil
+}
+
+func (f *fakeProductRepo) CreateMany(ctx context.Context, products []models.Product) error {
+	for i := range products {
+		p := products[i]
+		f.products[p.ID] = &p
+	}
+	return nil
+}
+
+func (f *fakeProductRepo) Update(ctx context.Context, id uuid.UUID, updates map[string]interface{}) error {
+	p, ok := f.products[id]
+	if !ok {
+		return fmt.Errorf("product %s: %w", id, apperrors.ErrNotFound)
+	}
+	if v, ok := updates["average_rating"].(float64); ok {
+		p.AverageRating = v
+	}
+	if v, ok := updates["review_count"].(int); ok {
+		p.ReviewCount = v
+	}
+	if v, ok := updates["price"].(float64); ok {
+		p.Price = v
+	}
+	if v, ok := updates["quantity"].(int); ok {
+		p.Quantity = v
+	}
+	if v, ok := updates["is_featured"].(bool); ok {
+		p.IsFeatured = v
+	}
+	return nil
+}
+
+func (f *fakeProductRepo) Delete(ctx context.Context, id uuid.UUID) error {
+	delete(f.products, id)
+	return nil
+}
+
+func (f *fakeProductRepo) DeleteMany(ctx context.Context, ids []uuid.UUID) error {
+	for _, id := range ids {
+		delete(f.products, id)
+	}
+	return nil
+}
+
+func (f *fakeProductRepo) FindBySKUs(ctx context.Context, skus []string) ([]models.Product, error) {
+	want := make(map[string]bool, len(skus))
+	for _, s := range skus {
+		want[s] = true
+	}
+	var out []models.Product
+	for _, p := range f.products {
+		if want[p.SKU] {
+			out = append(out, *p)
+		}
+	}
+	return out, nil
+}
+
+func (f *fakeProductRepo) EnsureIndexes(ctx context.Context) error {
+	return nil
+}
+
+func newTestProductService() (*ProductServiceDDB, *fakeProductRepo, *fakeCategoryRepo) {
+	pr := newFakeProductRepo()
+	cr := newFakeCategoryRepo()
+	return NewProductServiceDDB(pr, cr, &fakePriceHistory{}, nil, nil, "", "", "", ""), pr, cr
+}
+
+// csvFile adapts a string to multipart.File for the bulk import paths.
+type csvFile struct {
+	*strings.Reader
+}
+
+func (csvFile) Close() error { return nil }
+
+func newCSVFile(s string) csvFile {
+	return csvFile{strings.NewReader(s)}
+}
+
+const bulkCSVHeader = "name,description,brand,sku,price,quantity,is_featured,categories,imageurl\n"
+
+func TestValidateBulkImport_ReportsFieldErrors(t *testing.T) {
+	svc, _, cr := newTestProductService()
+	cr.add("Books", false)
+
+	csv := bulkCSVHeader +
+		",desc,brand,SKU-1,10,1,false,Books,\n" +
+		"Widget,desc,brand,SKU-2,-3,1,false,Books,\n"
+
+	v, err := svc.ValidateBulkImport(context.Background(), newCSVFile(csv))
+	if err != nil {
+		t.Fatalf("ValidateBulkImport returned error: %v", err)
+	}
+
+	want := map[int]string{2: "name", 3: "price"}
+	if len(v.Errors) != len(want) {
+		t.Fatalf("expected %d errors, got %v", len(want), v.Errors)
+	}
+	for _, e := range v.Errors {
+		row, _ := e["row"].(int)
+		if e["field"] != want[row] {
+			t.Fatalf("row %d: expected field %q, got %v", row, want[row], e["field"])
+		}
+	}
+}
+
+func TestValidateBulkImport_CountsOnlyDataRows(t *testing.T) {
+	svc, _, cr := newTestProductService()
+	cr.add("Books", false)
+
+	csv := bulkCSVHeader +
+		"Widget,desc,brand,SKU-1,10,1,false,Books,\n" +
+		"Broken,desc,brand,SKU-2\n" + // wrong field count
+		"Gadget,desc,brand,SKU-3,abc,1,false,Books,\n" +
+		",,,,,,,,\n" +
+		"\n"
+
+	v, err := svc.ValidateBulkImport(context.Background(), newCSVFile(csv))
+	if err != nil {
+		t.Fatalf("ValidateBulkImport returned error: %v", err)
+	}
+
+	if v.ValidProducts != 1 || v.InvalidProducts != 1 {
+		t.Fatalf("expected 1 valid and 1 invalid, got %d valid %d invalid", v.ValidProducts, v.InvalidProducts)
+	}
+	if v.TotalProducts != 2 {
+		t.Fatalf("expected total 2 (valid + invalid), got %d", v.TotalProducts)
+	}
+	if v.SkippedRows != 2 {
+		t.Fatalf("expected 2 skipped rows (malformed + blank), got %d", v.SkippedRows)
+	}
+
+	rows := map[int]bool{}
+	for _, e := range v.Errors {
+		row, _ := e["row"].(int)
+		rows[row] = true
+	}
+	if !rows[3] || !rows[4] {
+		t.Fatalf("expected errors reported on lines 3 and 4, got %v", v.Errors)
+	}
+}
+
+func TestGetProduct_NotFoundIsTyped(t *testing.T) {
+	svc, _, _ := newTestProductService()
+
+	_, err := svc.GetProduct(context.Background(), uuid.New())
+	if !errors.Is(err, apperrors.ErrNotFound) {
+		t.Fatalf("expected errors.Is(err, ErrNotFound) through wrapping, got %v", err)
+	}
+	if _, err := svc.GetProductInternal(context.Background(), uuid.New()); !errors.Is(err, apperrors.ErrNotFound) {
+		t.Fatalf("expected internal lookup to surface ErrNotFound, got %v", err)
+	}
+}
@@ -0,0 +1,47 @@
+package services
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// buildPublicURL returns the URL an uploaded object is served from:
+//
+//   - through the CDN when a CDN domain is configured;
+//   - path-style under the custom endpoint (LocalStack, MinIO) when one is set;
+//   - otherwise virtual-hosted style on AWS S3, falling back to path-style
+//     for bucket names containing dots, which break the wildcard certificate.
+//
+// Each key segment is escaped; the "/" separators are kept.
+func (s *ProductServiceDDB) buildPublicURL(bucket, key string) string {
+	path := escapeKey(key)
+	switch {
+	case s.cdnDomain != "":
+		return fmt.Sprintf("%s/%s", withScheme(s.cdnDomain), path)
+	case s.endpoint != "":
+		return fmt.Sprintf("%s/%s/%s", withScheme(s.endpoint), bucket, path)
+	case strings.Contains(bucket, "."):
+		return fmt.Sprintf("https://s3.amazonaws.com/%s/%s", bucket, path)
+	default:
+		return fmt.Sprintf("https://%s.s3.amazonaws.com/%s", bucket, path)
+	}
+}
+
+// withScheme trims trailing slashes from base and defaults it to https when
+// it has no scheme.
+func withScheme(base string) string {
+	base = strings.TrimRight(base, "/")
+	if !strings.Contains(base, "://") {
+		base = "https://" + base
+	}
+	return base
+}
+
+func escapeKey(key string) string {
+	segments := strings.Split(key, "/")
+	for i, seg := range segments {
+		segments[i] = url.PathEscape(seg)
+	}
+	return strings.Join(segments, "/")
+}
@@ -0,0 +1,70 @@
+package services
+
+import "testing"
+
+func TestBuildPublicURL(t *testing.T) {
+	cases := []struct {
+		name      string
+		endpoint  string
+		cdnDomain string
+		bucket    string
+		key       string
+		want      string
+	}{
+		{
+			name:      "cdn",
+			cdnDomain: "d123.cloudfront.net/",
+			endpoint:  "http://localstack:4566",
+			bucket:    "shop-images",
+			key:       "products/product_img_SKU1_0",
+			want:      "https://d123.cloudfront.net/products/product_img_SKU1_0",
+		},
+		{
+			name:      "cdn with scheme",
+			cdnDomain: "https://cdn.example.com",
+			bucket:    "shop-images",
+			key:       "product_img_SKU1_0",
+			want:      "https://cdn.example.com/product_img_SKU1_0",
+		},
+		{
+			name:     "custom endpoint is path-style",
+			endpoint: "http://localstack:4566/",
+			bucket:   "shop-images",
+			key:      "products/product_img_SKU1_0",
+			want:     "http://localstack:4566/shop-images/products/product_img_SKU1_0",
+		},
+		{
+			name:     "custom endpoint without scheme",
+			endpoint: "minio.internal:9000",
+			bucket:   "shop-images",
+			key:      "product_img_SKU1_0",
+			want:     "https://minio.internal:9000/shop-images/product_img_SKU1_0",
+		},
+		{
+			name:   "aws virtual-host",
+			bucket: "shop-images",
+			key:    "products/product_img_SKU1_0.png",
+			want:   "https://shop-images.s3.amazonaws.com/products/product_img_SKU1_0.png",
+		},
+		{
+			name:   "aws dotted bucket falls back to path-style",
+			bucket: "images.shop.example",
+			key:    "product_img_SKU1_0",
+			want:   "https://s3.amazonaws.com/images.shop.example/product_img_SKU1_0",
+		},
+		{
+			name:   "key segments are escaped",
+			bucket: "shop-images",
+			key:    "products/product_img_SKU 1#a_0",
+			want:   "https://shop-images.s3.amazonaws.com/products/product_img_SKU%201%23a_0",
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			svc := NewProductServiceDDB(nil, nil, nil, nil, nil, tc.bucket, "", tc.endpoint, tc.cdnDomain)
+			if got := svc.buildPublicURL(tc.bucket, tc.key); got != tc.want {
+				t.Fatalf("expected %q, got %q", tc.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,70 @@
+package services
+
+import (
+	"context"
+	"sort"
+
+	"product-service/models"
+
+	"github.com/google/uuid"
+)
+
+// RelatedProducts returns up to limit other products that share categories
+// with the product id, those sharing the most first. Ties go to the higher
+// rated product, then by name. A product with no categories has no related
+// products.
+func (s *ProductServiceDDB) RelatedProducts(ctx context.Context, id uuid.UUID, limit int) ([]*models.Product, error) {
+	product, err := s.productRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if len(product.CategoryIDs) == 0 {
+		return []*models.Product{}, nil
+	}
+
+	// Any-category match; ranking by overlap happens below
+	candidates, err := s.productRepo.Find(ctx, map[string]interface{}{
+		"category_ids": product.CategoryIDs,
+	}, 0, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	mine := make(map[uuid.UUID]bool, len(product.CategoryIDs))
+	for _, c := range product.CategoryIDs {
+		mine[c] = true
+	}
+	shared := make(map[uuid.UUID]int, len(candidates))
+	related := make([]*models.Product, 0, len(candidates))
+	for _, p := range candidates {
+		if p.ID == product.ID {
+			continue
+		}
+		n := 0
+		for _, c := range p.CategoryIDs {
+			if mine[c] {
+				n++
+			}
+		}
+		if n == 0 {
+			continue
+		}
+		shared[p.ID] = n
+		related = append(related, p)
+	}
+
+	sort.SliceStable(related, func(i, j int) bool {
+		a, b := related[i], related[j]
+		if shared[a.ID] != shared[b.ID] {
+			return shared[a.ID] > shared[b.ID]
+		}
+		if a.AverageRating != b.AverageRating {
+			return a.AverageRating > b.AverageRating
+		}
+		return a.Name < b.Name
+	})
+	if limit > 0 && len(related) > limit {
+		related = related[:limit]
+	}
+	return related, nil
+}
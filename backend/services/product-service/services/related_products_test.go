@@ -0,0 +1,78 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"product-service/models"
+
+	"github.com/google/uuid"
+	apperrors "github.com/yashrajoria/common/errors"
+)
+
+func TestRelatedProducts_RanksBySharedCategories(t *testing.T) {
+	svc, pr, _ := newTestProductService()
+	shoes, running, trail, hats := uuid.New(), uuid.New(), uuid.New(), uuid.New()
+
+	add := func(name string, rating float64, cats ...uuid.UUID) *models.Product {
+		p := &models.Product{ID: uuid.New(), Name: name, SKU: name, AverageRating: rating, CategoryIDs: cats}
+		pr.products[p.ID] = p
+		return p
+	}
+	target := add("target", 0, shoes, running, trail)
+	two := add("road runner", 3, shoes, running)
+	three := add("trail runner", 2, shoes, running, trail)
+	oneLow := add("sandal", 1, shoes)
+	oneHigh := add("boot", 4, shoes)
+	add("cap", 5, hats)
+
+	related, err := svc.RelatedProducts(context.Background(), target.ID, 0)
+	if err != nil {
+		t.Fatalf("RelatedProducts: %v", err)
+	}
+	want := []*models.Product{three, two, oneHigh, oneLow}
+	if len(related) != len(want) {
+		t.Fatalf("expected %d related products, got %d", len(want), len(related))
+	}
+	for i, p := range want {
+		if related[i].ID != p.ID {
+			t.Fatalf("position %d: expected %q, got %q", i, p.Name, related[i].Name)
+		}
+	}
+
+	limited, err := svc.RelatedProducts(context.Background(), target.ID, 2)
+	if err != nil {
+		t.Fatalf("RelatedProducts: %v", err)
+	}
+	if len(limited) != 2 || limited[0].ID != three.ID || limited[1].ID != two.ID {
+		t.Fatalf("expected the top two related products, got %v", limited)
+	}
+}
+
+func TestRelatedProducts_NoNeighbors(t *testing.T) {
+	svc, pr, _ := newTestProductService()
+	lonely := &models.Product{ID: uuid.New(), Name: "lonely", SKU: "lonely", CategoryIDs: []uuid.UUID{uuid.New()}}
+	uncategorized := &models.Product{ID: uuid.New(), Name: "bare", SKU: "bare"}
+	other := &models.Product{ID: uuid.New(), Name: "other", SKU: "other", CategoryIDs: []uuid.UUID{uuid.New()}}
+	for _, p := range []*models.Product{lonely, uncategorized, other} {
+		pr.products[p.ID] = p
+	}
+
+	for _, p := range []*models.Product{lonely, uncategorized} {
+		related, err := svc.RelatedProducts(context.Background(), p.ID, 10)
+		if err != nil {
+			t.Fatalf("%s: RelatedProducts: %v", p.Name, err)
+		}
+		if related == nil || len(related) != 0 {
+			t.Fatalf("%s: expected an empty list, got %v", p.Name, related)
+		}
+	}
+}
+
+func TestRelatedProducts_UnknownProduct(t *testing.T) {
+	svc, _, _ := newTestProductService()
+	if _, err := svc.RelatedProducts(context.Background(), uuid.New(), 10); !errors.Is(err, apperrors.ErrNotFound) {
+		t.Fatalf("expected ErrNotFound, got %v", err)
+	}
+}
@@ -0,0 +1,80 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strings"
+
+	"product-service/models"
+	"product-service/repository"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// ReviewServiceDDB is a DynamoDB-backed product review service
+type ReviewServiceDDB struct {
+	reviewRepo  repository.ReviewRepo
+	productRepo repository.ProductRepo
+}
+
+func NewReviewServiceDDB(reviewRepo repository.ReviewRepo, productRepo repository.ProductRepo) *ReviewServiceDDB {
+	return &ReviewServiceDDB{reviewRepo: reviewRepo, productRepo: productRepo}
+}
+
+// CreateReview stores a user's review of a product and refreshes the
+// product's denormalized rating aggregate. The review is the source of truth,
+// so a failed refresh is logged rather than failing the request; the
+// aggregate is recomputed in full on the next write, including a retried
+// duplicate, which repairs it.
+func (s *ReviewServiceDDB) CreateReview(ctx context.Context, productID uuid.UUID, userID string, req ReviewCreateRequest) (*models.Review, error) {
+	if _, err := s.productRepo.FindByID(ctx, productID); err != nil {
+		return nil, err
+	}
+
+	review := &models.Review{
+		ProductID: productID,
+		UserID:    userID,
+		Rating:    req.Rating,
+		Comment:   req.Comment,
+		CreatedAt: repository.Now(),
+	}
+	if err := s.reviewRepo.Create(ctx, review); err != nil {
+		if strings.Contains(err.Error(), "already exists") {
+			// A client retrying after an earlier refresh failed lands here
+			s.refreshRatingBestEffort(ctx, productID)
+		}
+		return nil, err
+	}
+
+	s.refreshRatingBestEffort(ctx, productID)
+	return review, nil
+}
+
+// refreshRating recomputes the aggregate from the stored reviews rather than
+// adjusting it incrementally, so concurrent writes can't make it drift.
+func (s *ReviewServiceDDB) refreshRating(ctx context.Context, productID uuid.UUID) error {
+	avg, count, err := s.reviewRepo.RatingStats(ctx, productID)
+	if err != nil {
+		return fmt.Errorf("failed to compute rating: %w", err)
+	}
+	return s.productRepo.Update(ctx, productID, map[string]interface{}{
+		"average_rating": math.Round(avg*100) / 100,
+		"review_count":   count,
+	})
+}
+
+func (s *ReviewServiceDDB) refreshRatingBestEffort(ctx context.Context, productID uuid.UUID) {
+	if err := s.refreshRating(ctx, productID); err != nil {
+		zap.L().Error("failed to refresh product rating", zap.Error(err), zap.String("product_id", productID.String()))
+	}
+}
+
+// ListReviews returns a page of reviews for a product, newest first.
+func (s *ReviewServiceDDB) ListReviews(ctx context.Context, productID uuid.UUID, page, perPage int) ([]models.Review, int64, error) {
+	if _, err := s.productRepo.FindByID(ctx, productID); err != nil {
+		return nil, 0, err
+	}
+	return s.reviewRepo.ListByProduct(ctx, productID, perPage, (page-1)*perPage)
+}
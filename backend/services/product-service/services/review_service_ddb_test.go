@@ -0,0 +1,148 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"product-service/models"
+
+	"github.com/google/uuid"
+	apperrors "github.com/yashrajoria/common/errors"
+)
+
+// fakeReviewRepo is an in-memory ReviewRepo keyed by product and user.
+type fakeReviewRepo struct {
+	reviews  map[uuid.UUID]map[string]models.Review
+	statsErr error
+}
+
+func newFakeReviewRepo() *fakeReviewRepo {
+	return &fakeReviewRepo{reviews: make(map[uuid.UUID]map[string]models.Review)}
+}
+
+func (f *fakeReviewRepo) Create(ctx context.Context, review *models.Review) error {
+	byUser := f.reviews[review.ProductID]
+	if byUser == nil {
+		byUser = make(map[string]models.Review)
+		f.reviews[review.ProductID] = byUser
+	}
+	if _, exists := byUser[review.UserID]; exists {
+		return errors.New("review already exists")
+	}
+	byUser[review.UserID] = *review
+	return nil
+}
+
+func (f *fakeReviewRepo) ListByProduct(ctx context.Context, productID uuid.UUID, limit, skip int) ([]models.Review, int64, error) {
+	var out []models.Review
+	for _, r := range f.reviews[productID] {
+		out = append(out, r)
+	}
+	return out, int64(len(out)), nil
+}
+
+func (f *fakeReviewRepo) RatingStats(ctx context.Context, productID uuid.UUID) (float64, int, error) {
+	if f.statsErr != nil {
+		return 0, 0, f.statsErr
+	}
+	byUser := f.reviews[productID]
+	if len(byUser) == 0 {
+		return 0, 0, nil
+	}
+	sum := 0
+	for _, r := range byUser {
+		sum += r.Rating
+	}
+	return float64(sum) / float64(len(byUser)), len(byUser), nil
+}
+
+func newTestReviewService() (*ReviewServiceDDB, *fakeProductRepo, *models.Product) {
+	pr := newFakeProductRepo()
+	product := &models.Product{ID: uuid.New(), Name: "Widget", SKU: "SKU-1"}
+	pr.products[product.ID] = product
+	return NewReviewServiceDDB(newFakeReviewRepo(), pr), pr, product
+}
+
+func TestCreateReview_StoresReview(t *testing.T) {
+	svc, _, product := newTestReviewService()
+
+	review, err := svc.CreateReview(context.Background(), product.ID, "user-1", ReviewCreateRequest{Rating: 4, Comment: "Nice"})
+	if err != nil {
+		t.Fatalf("CreateReview returned error: %v", err)
+	}
+	if review.ProductID != product.ID || review.UserID != "user-1" || review.Rating != 4 {
+		t.Fatalf("unexpected review %+v", review)
+	}
+}
+
+func TestCreateReview_RejectsDuplicate(t *testing.T) {
+	svc, _, product := newTestReviewService()
+
+	if _, err := svc.CreateReview(context.Background(), product.ID, "user-1", ReviewCreateRequest{Rating: 4}); err != nil {
+		t.Fatalf("first review failed: %v", err)
+	}
+	_, err := svc.CreateReview(context.Background(), product.ID, "user-1", ReviewCreateRequest{Rating: 1})
+	if err == nil || !strings.Contains(err.Error(), "already exists") {
+		t.Fatalf("expected duplicate review to be rejected, got %v", err)
+	}
+	if product.ReviewCount != 1 || product.AverageRating != 4 {
+		t.Fatalf("duplicate must not change aggregate, got avg=%v count=%d", product.AverageRating, product.ReviewCount)
+	}
+}
+
+func TestCreateReview_RecomputesAggregate(t *testing.T) {
+	svc, _, product := newTestReviewService()
+
+	for i, rating := range []int{5, 4, 4} {
+		userID := "user-" + string(rune('a'+i))
+		if _, err := svc.CreateReview(context.Background(), product.ID, userID, ReviewCreateRequest{Rating: rating}); err != nil {
+			t.Fatalf("CreateReview returned error: %v", err)
+		}
+	}
+
+	if product.ReviewCount != 3 {
+		t.Fatalf("expected review_count 3, got %d", product.ReviewCount)
+	}
+	if product.AverageRating != 4.33 {
+		t.Fatalf("expected average_rating 4.33, got %v", product.AverageRating)
+	}
+}
+
+func TestCreateReview_UnknownProduct(t *testing.T) {
+	svc, _, _ := newTestReviewService()
+
+	_, err := svc.CreateReview(context.Background(), uuid.New(), "user-1", ReviewCreateRequest{Rating: 3})
+	if !errors.Is(err, apperrors.ErrNotFound) {
+		t.Fatalf("expected not found error, got %v", err)
+	}
+}
+
+func TestCreateReview_RefreshFailureStillCreates(t *testing.T) {
+	pr := newFakeProductRepo()
+	product := &models.Product{ID: uuid.New(), Name: "Widget", SKU: "SKU-1"}
+	pr.products[product.ID] = product
+	reviews := newFakeReviewRepo()
+	svc := NewReviewServiceDDB(reviews, pr)
+
+	reviews.statsErr = errors.New("dynamodb throttled")
+	review, err := svc.CreateReview(context.Background(), product.ID, "user-1", ReviewCreateRequest{Rating: 4})
+	if err != nil || review == nil {
+		t.Fatalf("expected the review to be created despite the refresh failure, got %v", err)
+	}
+	if product.ReviewCount != 0 {
+		t.Fatalf("expected the aggregate to be stale after the failed refresh, got count=%d", product.ReviewCount)
+	}
+
+	// The client retries; the duplicate is still rejected but the aggregate
+	// is repaired
+	reviews.statsErr = nil
+	_, err = svc.CreateReview(context.Background(), product.ID, "user-1", ReviewCreateRequest{Rating: 4})
+	if err == nil || !strings.Contains(err.Error(), "already exists") {
+		t.Fatalf("expected duplicate review to be rejected, got %v", err)
+	}
+	if product.ReviewCount != 1 || product.AverageRating != 4 {
+		t.Fatalf("expected the retry to repair the aggregate, got avg=%v count=%d", product.AverageRating, product.ReviewCount)
+	}
+}
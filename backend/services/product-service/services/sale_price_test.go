@@ -0,0 +1,89 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"product-service/models"
+
+	"github.com/google/uuid"
+)
+
+func TestProductDTO_EffectivePriceAcrossSaleWindow(t *testing.T) {
+	start := time.Date(2024, 11, 29, 0, 0, 0, 0, time.UTC)
+	end := start.Add(72 * time.Hour)
+	sale := 60.0
+	p := &models.Product{ID: uuid.New(), Price: 80, SalePrice: &sale, SaleStartsAt: &start, SaleEndsAt: &end}
+
+	cases := []struct {
+		name   string
+		now    time.Time
+		want   float64
+		onSale bool
+	}{
+		{name: "before", now: start.Add(-time.Second), want: 80},
+		{name: "at start", now: start, want: 60, onSale: true},
+		{name: "within", now: start.Add(24 * time.Hour), want: 60, onSale: true},
+		{name: "at end", now: end, want: 80},
+		{name: "after", now: end.Add(time.Hour), want: 80},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			dto := newProductDTO(p, nil, tc.now)
+			if dto.EffectivePrice != tc.want || dto.OnSale != tc.onSale {
+				t.Fatalf("expected effective price %v (on sale %v), got %v (%v)", tc.want, tc.onSale, dto.EffectivePrice, dto.OnSale)
+			}
+			if dto.Price != 80 {
+				t.Fatalf("regular price must be unchanged, got %v", dto.Price)
+			}
+		})
+	}
+}
+
+func TestGetProductInternal_UsesEffectivePrice(t *testing.T) {
+	svc, pr, _ := newTestProductService()
+	start := time.Now().Add(-time.Hour)
+	end := time.Now().Add(time.Hour)
+	sale := 15.0
+	onSale := &models.Product{ID: uuid.New(), Price: 20, Quantity: 3, SalePrice: &sale, SaleStartsAt: &start, SaleEndsAt: &end}
+	expired := &models.Product{ID: uuid.New(), Price: 20, Quantity: 3, SalePrice: &sale, SaleEndsAt: &start}
+	pr.products[onSale.ID] = onSale
+	pr.products[expired.ID] = expired
+
+	dto, err := svc.GetProductInternal(context.Background(), onSale.ID)
+	if err != nil || dto.Price != 15 {
+		t.Fatalf("expected checkout price 15 during the sale, got %+v (err=%v)", dto, err)
+	}
+	dto, err = svc.GetProductInternal(context.Background(), expired.ID)
+	if err != nil || dto.Price != 20 {
+		t.Fatalf("expected checkout price 20 after the sale, got %+v (err=%v)", dto, err)
+	}
+}
+
+func TestUpdateProduct_SaleValidation(t *testing.T) {
+	svc, pr, _ := newTestProductService()
+	product := &models.Product{ID: uuid.New(), Price: 80}
+	pr.products[product.ID] = product
+
+	invalid := []map[string]interface{}{
+		{"sale_price": -1.0},
+		{"sale_price": "cheap"},
+		{"sale_starts_at": "tomorrow"},
+		{"sale_starts_at": "2024-12-02T00:00:00Z", "sale_ends_at": "2024-12-01T00:00:00Z"},
+	}
+	for _, u := range invalid {
+		if _, err := svc.UpdateProduct(context.Background(), product.ID, u, ""); !errors.Is(err, ErrInvalidSale) {
+			t.Errorf("expected ErrInvalidSale for %v, got %v", u, err)
+		}
+	}
+
+	valid := map[string]interface{}{"sale_price": 60.0, "sale_starts_at": "2024-11-29T05:30:00+05:30", "sale_ends_at": nil}
+	if _, err := svc.UpdateProduct(context.Background(), product.ID, valid, ""); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if valid["sale_starts_at"] != "2024-11-29T00:00:00Z" {
+		t.Fatalf("expected sale_starts_at normalized to UTC, got %v", valid["sale_starts_at"])
+	}
+}
@@ -11,6 +11,8 @@ type ListProductsParams struct {
 	CategoryID []uuid.UUID
 	MinPrice   *float64
 	MaxPrice   *float64
+	Brand      string // case-insensitive exact match; empty matches any brand
+	InStock    *bool
 }
 
 // ProductCreateRequest is the request payload for creating a product
@@ -40,3 +42,9 @@ type CategoryCreateRequest struct {
 	Image       string   `json:"image"`
 	IsActive    bool     `json:"is_active"`
 }
+
+// ReviewCreateRequest is the request payload for reviewing a product
+type ReviewCreateRequest struct {
+	Rating  int    `json:"rating" validate:"required,min=1,max=5"`
+	Comment string `json:"comment" validate:"max=2000"`
+}
@@ -22,6 +22,8 @@ type Config struct {
 	SMTPEmail        string // SMTP email for sending mail
 	SMTPPassword     string // SMTP password for sending mail
 	Port             string // Service port (default: 8081)
+	OrderServiceURL  string // Base URL of order-service, used by data exports
+	UserSNSTopicARN  string // SNS topic for user events such as role changes
 }
 
 // LoadConfig loads environment variables into Config struct and validates them.
@@ -38,11 +40,16 @@ func LoadConfig() (*Config, error) {
 		SMTPEmail:        os.Getenv("SMTP_EMAIL"),
 		SMTPPassword:     os.Getenv("SMTP_PASSWORD"),
 		Port:             os.Getenv("PORT"),
+		OrderServiceURL:  os.Getenv("ORDER_SERVICE_URL"),
+		UserSNSTopicARN:  os.Getenv("USER_SNS_TOPIC_ARN"),
 	}
 
 	if cfg.Port == "" {
 		cfg.Port = "8085"
 	}
+	if cfg.OrderServiceURL == "" {
+		cfg.OrderServiceURL = "http://order-service:8083"
+	}
 
 	if os.Getenv("AWS_USE_SECRETS") == "true" {
 		if awsCfg, err := aws_pkg.LoadAWSConfig(context.Background()); err == nil {
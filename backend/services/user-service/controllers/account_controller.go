@@ -0,0 +1,38 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"user-service/repository"
+	"user-service/services"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yashrajoria/common/httputil"
+)
+
+// AccountController serves DELETE /users/me.
+type AccountController struct {
+	Service *services.AccountService
+}
+
+// DeactivateAccount soft-deletes the caller's account. The row is kept (with
+// deleted_at set) so orders and other records referencing the user survive,
+// and auth-service refuses to log the account in again.
+func (ac *AccountController) DeactivateAccount(c *gin.Context) {
+	userID, ok := requestUserID(c)
+	if !ok {
+		return
+	}
+
+	err := ac.Service.Deactivate(c.Request.Context(), userID)
+	if errors.Is(err, repository.ErrUserNotFound) {
+		httputil.RespondError(c, http.StatusNotFound, "User not found")
+		return
+	}
+	if err != nil {
+		httputil.RespondError(c, http.StatusInternalServerError, "Failed to deactivate account")
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
@@ -0,0 +1,115 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"user-service/middleware"
+	"user-service/repository"
+	"user-service/services"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/yashrajoria/common/httputil"
+)
+
+// AddressController serves the caller's address book under /users/addresses.
+type AddressController struct {
+	Service *services.AddressService
+}
+
+func (ac *AddressController) ListAddresses(c *gin.Context) {
+	userID, ok := requestUserID(c)
+	if !ok {
+		return
+	}
+	addrs, err := ac.Service.List(c.Request.Context(), userID)
+	if err != nil {
+		httputil.RespondError(c, http.StatusInternalServerError, "Failed to fetch addresses")
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"addresses": addrs})
+}
+
+func (ac *AddressController) CreateAddress(c *gin.Context) {
+	userID, ok := requestUserID(c)
+	if !ok {
+		return
+	}
+	var req services.AddressRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		httputil.RespondErrorDetails(c, http.StatusBadRequest, "Invalid payload", err.Error())
+		return
+	}
+	addr, err := ac.Service.Create(c.Request.Context(), userID, req)
+	if err != nil {
+		respondAddressError(c, err)
+		return
+	}
+	c.JSON(http.StatusCreated, addr)
+}
+
+func (ac *AddressController) UpdateAddress(c *gin.Context) {
+	userID, ok := requestUserID(c)
+	if !ok {
+		return
+	}
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		httputil.RespondError(c, http.StatusBadRequest, "Invalid address ID")
+		return
+	}
+	var req services.AddressRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		httputil.RespondErrorDetails(c, http.StatusBadRequest, "Invalid payload", err.Error())
+		return
+	}
+	addr, err := ac.Service.Update(c.Request.Context(), userID, id, req)
+	if err != nil {
+		respondAddressError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, addr)
+}
+
+func (ac *AddressController) DeleteAddress(c *gin.Context) {
+	userID, ok := requestUserID(c)
+	if !ok {
+		return
+	}
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		httputil.RespondError(c, http.StatusBadRequest, "Invalid address ID")
+		return
+	}
+	if err := ac.Service.Delete(c.Request.Context(), userID, id); err != nil {
+		respondAddressError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+func requestUserID(c *gin.Context) (uuid.UUID, bool) {
+	raw, err := middleware.GetUserID(c)
+	if err != nil {
+		httputil.RespondError(c, http.StatusUnauthorized, "Unauthorized")
+		return uuid.Nil, false
+	}
+	userID, err := uuid.Parse(raw)
+	if err != nil {
+		httputil.RespondError(c, http.StatusBadRequest, "Invalid user ID")
+		return uuid.Nil, false
+	}
+	return userID, true
+}
+
+func respondAddressError(c *gin.Context, err error) {
+	var verr *services.ValidationError
+	switch {
+	case errors.As(err, &verr):
+		httputil.RespondErrorDetails(c, http.StatusBadRequest, "Validation failed", verr.Fields)
+	case errors.Is(err, repository.ErrAddressNotFound):
+		httputil.RespondError(c, http.StatusNotFound, "Address not found")
+	default:
+		httputil.RespondError(c, http.StatusInternalServerError, "Failed to save address")
+	}
+}
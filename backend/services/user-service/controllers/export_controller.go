@@ -0,0 +1,38 @@
+package controllers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"user-service/repository"
+	"user-service/services"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yashrajoria/common/httputil"
+)
+
+// ExportController serves GET /users/me/export.
+type ExportController struct {
+	Service *services.ExportService
+}
+
+// ExportUserData returns the caller's data as a downloadable JSON document.
+func (ec *ExportController) ExportUserData(c *gin.Context) {
+	userID, ok := requestUserID(c)
+	if !ok {
+		return
+	}
+
+	export, err := ec.Service.Export(c.Request.Context(), userID)
+	if errors.Is(err, repository.ErrUserNotFound) {
+		httputil.RespondError(c, http.StatusNotFound, "User not found")
+		return
+	}
+	if err != nil {
+		httputil.RespondError(c, http.StatusInternalServerError, "Failed to export user data")
+		return
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="user-data-%s.json"`, userID))
+	c.JSON(http.StatusOK, export)
+}
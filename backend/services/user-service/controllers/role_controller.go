@@ -0,0 +1,55 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"user-service/middleware"
+	"user-service/repository"
+	"user-service/services"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/yashrajoria/common/httputil"
+)
+
+// RoleController serves admin role management.
+type RoleController struct {
+	Service *services.RoleService
+}
+
+// UpdateRole handles PUT /users/:id/role.
+func (rc *RoleController) UpdateRole(c *gin.Context) {
+	actorID, err := middleware.GetUserID(c)
+	if err != nil {
+		httputil.RespondError(c, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+	userID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		httputil.RespondError(c, http.StatusBadRequest, "Invalid user ID")
+		return
+	}
+
+	var req struct {
+		Role string `json:"role" binding:"required"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		httputil.RespondErrorDetails(c, http.StatusBadRequest, "Invalid payload", err.Error())
+		return
+	}
+
+	event, err := rc.Service.ChangeRole(c.Request.Context(), actorID, userID, req.Role)
+	switch {
+	case errors.Is(err, services.ErrInvalidRole):
+		httputil.RespondError(c, http.StatusBadRequest, "Invalid role")
+		return
+	case errors.Is(err, repository.ErrUserNotFound):
+		httputil.RespondError(c, http.StatusNotFound, "User not found")
+		return
+	case err != nil:
+		httputil.RespondError(c, http.StatusInternalServerError, "Failed to update role")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"id": userID, "role": event.NewRole, "previous_role": event.OldRole})
+}
@@ -9,6 +9,7 @@ import (
     "user-service/services"
 
     "github.com/gin-gonic/gin"
+    "github.com/yashrajoria/common/httputil"
     "golang.org/x/crypto/bcrypt"
     "gorm.io/gorm"
 )
@@ -17,7 +18,7 @@ import (
 func GetProfile(c *gin.Context) {
     userID, err := middleware.GetUserID(c)
     if err != nil {
-        c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+        httputil.RespondError(c, http.StatusUnauthorized, "Unauthorized")
         return
     }
 
@@ -27,7 +28,7 @@ func GetProfile(c *gin.Context) {
         First(&user).Error
 
     if err != nil {
-        c.JSON(http.StatusNotFound, gin.H{"error": "User not found"})
+        httputil.RespondError(c, http.StatusNotFound, "User not found")
         return
     }
 
@@ -41,20 +42,24 @@ func GetProfile(c *gin.Context) {
     })
 }
 
+// UpdateProfile updates the caller's name, phone number and default
+// (shipping) address. Immutable fields such as email and role are rejected.
 func UpdateProfile(c *gin.Context) {
     userID, err := middleware.GetUserID(c)
     if err != nil {
-        c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+        httputil.RespondError(c, http.StatusUnauthorized, "Unauthorized")
         return
     }
 
-    var req struct {
-        Name        *string `json:"name"`
-        PhoneNumber *string `json:"phone_number"`
+    body, err := c.GetRawData()
+    if err != nil {
+        httputil.RespondErrorDetails(c, http.StatusBadRequest, "Invalid payload", err.Error())
+        return
     }
 
-    if err := c.ShouldBindJSON(&req); err != nil {
-        c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid payload", "details": err.Error()})
+    update, fieldErrs := services.ValidateProfileUpdate(body)
+    if fieldErrs != nil {
+        httputil.RespondErrorDetails(c, http.StatusBadRequest, "Validation failed", fieldErrs)
         return
     }
 
@@ -64,40 +69,83 @@ func UpdateProfile(c *gin.Context) {
         First(&user).Error
 
     if errors.Is(err, gorm.ErrRecordNotFound) {
-        c.JSON(http.StatusNotFound, gin.H{"error": "User not found"})
+        httputil.RespondError(c, http.StatusNotFound, "User not found")
         return
     } else if err != nil {
-        c.JSON(http.StatusInternalServerError, gin.H{"error": "Database error"})
+        httputil.RespondError(c, http.StatusInternalServerError, "Database error")
         return
     }
 
-    if req.Name != nil {
-        user.Name = *req.Name
+    if update.Name != nil {
+        user.Name = *update.Name
     }
-    if req.PhoneNumber != nil {
-        user.PhoneNumber = req.PhoneNumber
+    if update.PhoneNumber != nil {
+        user.PhoneNumber = update.PhoneNumber
     }
 
-    err = database.DB.WithContext(c.Request.Context()).Save(&user).Error
+    err = database.DB.WithContext(c.Request.Context()).Transaction(func(tx *gorm.DB) error {
+        if update.DefaultAddress != nil {
+            if err := saveDefaultAddress(tx, &user, update.DefaultAddress); err != nil {
+                return err
+            }
+        }
+        return tx.Omit("BillingAddress", "ShippingAddress").Save(&user).Error
+    })
     if err != nil {
-        c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update user"})
+        httputil.RespondError(c, http.StatusInternalServerError, "Failed to update user")
         return
     }
 
     c.JSON(http.StatusOK, gin.H{
         "message": "Profile updated",
         "user": gin.H{
-            "id":           user.ID,
-            "name":         user.Name,
-            "phone_number": user.PhoneNumber,
+            "id":                  user.ID,
+            "name":                user.Name,
+            "phone_number":        user.PhoneNumber,
+            "shipping_address_id": user.ShippingAddressID,
         },
     })
 }
 
+// saveDefaultAddress overwrites the user's shipping address, creating it on
+// first use.
+func saveDefaultAddress(tx *gorm.DB, user *models.User, in *services.AddressInput) error {
+    addr := models.Address{
+        UserID:     user.ID,
+        Type:       "shipping",
+        Street:     in.Street,
+        City:       in.City,
+        State:      in.State,
+        PostalCode: in.PostalCode,
+        Country:    in.Country,
+    }
+    if user.ShippingAddressID != nil {
+        addr.ID = *user.ShippingAddressID
+        return tx.Model(&models.Address{}).
+            Where("id = ? AND user_id = ?", addr.ID, user.ID).
+            Updates(map[string]interface{}{
+                "street":      addr.Street,
+                "city":        addr.City,
+                "state":       addr.State,
+                "postal_code": addr.PostalCode,
+                "country":     addr.Country,
+            }).Error
+    }
+    addr.IsDefault = true
+    if err := tx.Model(&models.Address{}).Where("user_id = ?", user.ID).Update("is_default", false).Error; err != nil {
+        return err
+    }
+    if err := tx.Create(&addr).Error; err != nil {
+        return err
+    }
+    user.ShippingAddressID = &addr.ID
+    return nil
+}
+
 func ChangePassword(c *gin.Context) {
     userID, err := middleware.GetUserID(c)
     if err != nil {
-        c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+        httputil.RespondError(c, http.StatusUnauthorized, "Unauthorized")
         return
     }
 
@@ -106,7 +154,7 @@ func ChangePassword(c *gin.Context) {
         NewPassword string `json:"new_password" binding:"required,min=8"`
     }
     if err := c.ShouldBindJSON(&req); err != nil {
-        c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request", "details": err.Error()})
+        httputil.RespondErrorDetails(c, http.StatusBadRequest, "Invalid request", err.Error())
         return
     }
 
@@ -116,31 +164,31 @@ func ChangePassword(c *gin.Context) {
         First(&user).Error
 
     if err != nil {
-        c.JSON(http.StatusNotFound, gin.H{"error": "User not found"})
+        httputil.RespondError(c, http.StatusNotFound, "User not found")
         return
     }
 
     if err := bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(req.OldPassword)); err != nil {
-        c.JSON(http.StatusUnauthorized, gin.H{"error": "Old password incorrect"})
+        httputil.RespondError(c, http.StatusUnauthorized, "Old password incorrect")
         return
     }
 
     validator := services.NewPasswordValidator()
     if err := validator.ValidatePassword(req.NewPassword); err != nil {
-        c.JSON(http.StatusBadRequest, gin.H{"error": "Weak password", "details": err.Error()})
+        httputil.RespondErrorDetails(c, http.StatusBadRequest, "Weak password", err.Error())
         return
     }
 
     hashedPassword, err := bcrypt.GenerateFromPassword([]byte(req.NewPassword), bcrypt.DefaultCost)
     if err != nil {
-        c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to hash new password"})
+        httputil.RespondError(c, http.StatusInternalServerError, "Failed to hash new password")
         return
     }
 
     user.Password = string(hashedPassword)
     err = database.DB.WithContext(c.Request.Context()).Save(&user).Error
     if err != nil {
-        c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update password"})
+        httputil.RespondError(c, http.StatusInternalServerError, "Failed to update password")
         return
     }
 
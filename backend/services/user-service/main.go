@@ -9,12 +9,17 @@ import (
 	"syscall"
 	"time"
 
+	"user-service/controllers"
 	"user-service/database"
 	"user-service/middleware"
 	"user-service/models"
+	"user-service/repository"
 	"user-service/routes"
+	"user-service/services"
 
 	"github.com/gin-gonic/gin"
+	aws_pkg "github.com/yashrajoria/E-Commerce-backend/backend/pkg/aws"
+	commonmw "github.com/yashrajoria/common/middleware"
 	"go.uber.org/zap"
 )
 
@@ -39,8 +44,16 @@ func main() {
 		}
 	}
 
+	// SNS client for publishing user events
+	awsCfg, err := aws_pkg.LoadAWSConfig(context.Background())
+	if err != nil {
+		logger.Fatal("Failed to load AWS config", zap.Error(err))
+	}
+	snsClient := aws_pkg.NewSNSClient(awsCfg)
+
 	r := gin.New()
-	r.Use(gin.Recovery())
+	r.Use(commonmw.RequestID())
+	r.Use(commonmw.Recovery(logger, "user-service", os.Stdout))
 
 	// Add request timeout middleware
 	r.Use(func(c *gin.Context) {
@@ -61,6 +74,33 @@ func main() {
 	userRoutes := r.Group("/users")
 	userRoutes.Use(middleware.AuthMiddleware())
 	routes.RegisterUserRoutes(userRoutes)
+	userRepo := repository.NewGormUserRepo(database.DB)
+	addressRepo := repository.NewGormAddressRepo(database.DB)
+	addressController := &controllers.AddressController{
+		Service: services.NewAddressService(addressRepo),
+	}
+	routes.RegisterAddressRoutes(userRoutes, addressController)
+	exportController := &controllers.ExportController{
+		Service: services.NewExportService(
+			userRepo,
+			addressRepo,
+			services.NewOrderClient(cfg.OrderServiceURL),
+		),
+	}
+	routes.RegisterExportRoutes(userRoutes, exportController)
+	accountController := &controllers.AccountController{
+		Service: services.NewAccountService(userRepo),
+	}
+	routes.RegisterAccountRoutes(userRoutes, accountController)
+
+	roleController := &controllers.RoleController{
+		Service: services.NewRoleService(
+			userRepo,
+			snsClient,
+			cfg.UserSNSTopicARN,
+		),
+	}
+	routes.RegisterAdminRoutes(userRoutes, roleController)
 
 	port := cfg.Port
 	if port == "" {
@@ -8,6 +8,7 @@ import (
 )
 
 const UserContextKey = "userID"
+const RoleContextKey = "role"
 
 func AuthMiddleware() gin.HandlerFunc {
 	return func(c *gin.Context) {
@@ -18,6 +19,18 @@ func AuthMiddleware() gin.HandlerFunc {
 		}
 
 		c.Set(UserContextKey, userID)
+		c.Set(RoleContextKey, c.GetHeader("X-User-Role"))
+		c.Next()
+	}
+}
+
+// AdminOnly rejects callers whose gateway-supplied role is not admin.
+func AdminOnly() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if c.GetString(RoleContextKey) != "admin" {
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "Admin role required"})
+			return
+		}
 		c.Next()
 	}
 }
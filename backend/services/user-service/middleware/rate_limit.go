@@ -0,0 +1,64 @@
+package middleware
+
+import (
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"golang.org/x/time/rate"
+)
+
+// limiterSweepInterval bounds how often UserRateLimit scans for idle limiters.
+const limiterSweepInterval = time.Minute
+
+type userLimiter struct {
+	limiter  *rate.Limiter
+	lastSeen time.Time
+}
+
+// UserRateLimit limits how often each authenticated user may call the
+// wrapped routes. It must run after AuthMiddleware. A user's limiter is
+// dropped once it has been idle long enough to refill its whole burst, since
+// a fresh limiter would behave the same.
+func UserRateLimit(r rate.Limit, burst int) gin.HandlerFunc {
+	var mu sync.Mutex
+	limiters := make(map[string]*userLimiter)
+	var lastSweep time.Time
+	var refill time.Duration
+	if r > 0 && r != rate.Inf {
+		refill = time.Duration(float64(burst) / float64(r) * float64(time.Second))
+	}
+
+	return func(c *gin.Context) {
+		userID, err := GetUserID(c)
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+			return
+		}
+
+		now := time.Now()
+		mu.Lock()
+		if r > 0 && now.Sub(lastSweep) >= limiterSweepInterval {
+			lastSweep = now
+			for id, l := range limiters {
+				if now.Sub(l.lastSeen) > refill {
+					delete(limiters, id)
+				}
+			}
+		}
+		l, ok := limiters[userID]
+		if !ok {
+			l = &userLimiter{limiter: rate.NewLimiter(r, burst)}
+			limiters[userID] = l
+		}
+		l.lastSeen = now
+		mu.Unlock()
+
+		if !l.limiter.Allow() {
+			c.AbortWithStatusJSON(http.StatusTooManyRequests, gin.H{"error": "Rate limit exceeded"})
+			return
+		}
+		c.Next()
+	}
+}
@@ -24,18 +24,21 @@ type User struct {
 	DeletedAt         gorm.DeletedAt `gorm:"index"`
 }
 
+// Address is an entry in a user's address book. At most one address per user
+// is the default; it is also referenced by User.ShippingAddressID.
 type Address struct {
-	ID         uuid.UUID      `gorm:"type:uuid;default:gen_random_uuid();primaryKey"`
-	UserID     uuid.UUID      `gorm:"type:uuid;not null;index"`
-	Type       string         `gorm:"type:varchar(20);check:type IN ('billing', 'shipping')"`
-	Street     string         `gorm:"not null"`
-	City       string         `gorm:"not null"`
-	State      string         `gorm:"not null"`
-	PostalCode string         `gorm:"not null"`
-	Country    string         `gorm:"not null"`
-	CreatedAt  time.Time      `gorm:"autoCreateTime"`
-	UpdatedAt  time.Time      `gorm:"autoUpdateTime"`
-	DeletedAt  gorm.DeletedAt `gorm:"index"`
+	ID         uuid.UUID      `gorm:"type:uuid;default:gen_random_uuid();primaryKey" json:"id"`
+	UserID     uuid.UUID      `gorm:"type:uuid;not null;index" json:"-"`
+	Type       string         `gorm:"type:varchar(20);check:type IN ('billing', 'shipping')" json:"type"`
+	Street     string         `gorm:"not null" json:"street"`
+	City       string         `gorm:"not null" json:"city"`
+	State      string         `gorm:"not null" json:"state"`
+	PostalCode string         `gorm:"not null" json:"postal_code"`
+	Country    string         `gorm:"not null" json:"country"`
+	IsDefault  bool           `gorm:"not null;default:false" json:"is_default"`
+	CreatedAt  time.Time      `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt  time.Time      `gorm:"autoUpdateTime" json:"updated_at"`
+	DeletedAt  gorm.DeletedAt `gorm:"index" json:"-"`
 }
 
 // Migrate function, now migrates soft deletes too
@@ -0,0 +1,14 @@
+package models
+
+import "time"
+
+// UserRoleChangedEvent is published after an admin changes a user's role so
+// anything caching roles (such as the gateway) can invalidate its entry.
+type UserRoleChangedEvent struct {
+	Type      string    `json:"type"` // "user_role_changed"
+	UserID    string    `json:"user_id"`
+	OldRole   string    `json:"old_role"`
+	NewRole   string    `json:"new_role"`
+	ChangedBy string    `json:"changed_by"`
+	Timestamp time.Time `json:"timestamp"`
+}
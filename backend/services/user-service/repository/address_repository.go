@@ -0,0 +1,111 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"user-service/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+var ErrAddressNotFound = errors.New("address not found")
+
+type AddressRepository interface {
+	ListByUser(ctx context.Context, userID uuid.UUID) ([]models.Address, error)
+	FindByID(ctx context.Context, userID, id uuid.UUID) (*models.Address, error)
+	Create(ctx context.Context, addr *models.Address) error
+	Update(ctx context.Context, addr *models.Address) error
+	Delete(ctx context.Context, userID, id uuid.UUID) error
+	// SetDefault makes id the user's only default address and points the
+	// user's shipping address at it.
+	SetDefault(ctx context.Context, userID, id uuid.UUID) error
+}
+
+type gormAddressRepo struct {
+	db *gorm.DB
+}
+
+func NewGormAddressRepo(db *gorm.DB) AddressRepository {
+	return &gormAddressRepo{db: db}
+}
+
+func (r *gormAddressRepo) ListByUser(ctx context.Context, userID uuid.UUID) ([]models.Address, error) {
+	var addrs []models.Address
+	err := r.db.WithContext(ctx).
+		Where("user_id = ?", userID).
+		Order("is_default DESC, created_at DESC").
+		Find(&addrs).Error
+	return addrs, err
+}
+
+func (r *gormAddressRepo) FindByID(ctx context.Context, userID, id uuid.UUID) (*models.Address, error) {
+	var addr models.Address
+	err := r.db.WithContext(ctx).Where("id = ? AND user_id = ?", id, userID).First(&addr).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrAddressNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &addr, nil
+}
+
+func (r *gormAddressRepo) Create(ctx context.Context, addr *models.Address) error {
+	return r.db.WithContext(ctx).Create(addr).Error
+}
+
+func (r *gormAddressRepo) Update(ctx context.Context, addr *models.Address) error {
+	res := r.db.WithContext(ctx).Model(&models.Address{}).
+		Where("id = ? AND user_id = ?", addr.ID, addr.UserID).
+		Updates(map[string]interface{}{
+			"type":        addr.Type,
+			"street":      addr.Street,
+			"city":        addr.City,
+			"state":       addr.State,
+			"postal_code": addr.PostalCode,
+			"country":     addr.Country,
+		})
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return ErrAddressNotFound
+	}
+	return nil
+}
+
+func (r *gormAddressRepo) Delete(ctx context.Context, userID, id uuid.UUID) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		res := tx.Where("id = ? AND user_id = ?", id, userID).Delete(&models.Address{})
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return ErrAddressNotFound
+		}
+		return tx.Model(&models.User{}).
+			Where("id = ? AND shipping_address_id = ?", userID, id).
+			Update("shipping_address_id", nil).Error
+	})
+}
+
+func (r *gormAddressRepo) SetDefault(ctx context.Context, userID, id uuid.UUID) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&models.Address{}).
+			Where("user_id = ? AND id <> ?", userID, id).
+			Update("is_default", false).Error; err != nil {
+			return err
+		}
+		res := tx.Model(&models.Address{}).
+			Where("id = ? AND user_id = ?", id, userID).
+			Update("is_default", true)
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return ErrAddressNotFound
+		}
+		return tx.Model(&models.User{}).Where("id = ?", userID).Update("shipping_address_id", id).Error
+	})
+}
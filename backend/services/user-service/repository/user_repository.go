@@ -0,0 +1,72 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"user-service/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+var ErrUserNotFound = errors.New("user not found")
+
+type UserRepository interface {
+	// FindByID returns an active (not deactivated) user.
+	FindByID(ctx context.Context, id uuid.UUID) (*models.User, error)
+	UpdateRole(ctx context.Context, id uuid.UUID, role string) error
+	// RevokeRefreshTokens revokes every refresh token auth-service has issued
+	// to the user, forcing a fresh login.
+	RevokeRefreshTokens(ctx context.Context, id uuid.UUID) error
+	// Deactivate soft-deletes the user by setting deleted_at. The row is kept
+	// so orders and other records referencing the user survive.
+	Deactivate(ctx context.Context, id uuid.UUID) error
+}
+
+type gormUserRepo struct {
+	db *gorm.DB
+}
+
+func NewGormUserRepo(db *gorm.DB) UserRepository {
+	return &gormUserRepo{db: db}
+}
+
+func (r *gormUserRepo) FindByID(ctx context.Context, id uuid.UUID) (*models.User, error) {
+	var user models.User
+	err := r.db.WithContext(ctx).Where("id = ?", id).First(&user).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrUserNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+func (r *gormUserRepo) UpdateRole(ctx context.Context, id uuid.UUID, role string) error {
+	res := r.db.WithContext(ctx).Model(&models.User{}).Where("id = ?", id).Update("role", role)
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return ErrUserNotFound
+	}
+	return nil
+}
+
+func (r *gormUserRepo) RevokeRefreshTokens(ctx context.Context, id uuid.UUID) error {
+	return r.db.WithContext(ctx).Table("refresh_tokens").
+		Where("user_id = ? AND revoked = ?", id, false).
+		Update("revoked", true).Error
+}
+
+func (r *gormUserRepo) Deactivate(ctx context.Context, id uuid.UUID) error {
+	res := r.db.WithContext(ctx).Where("id = ?", id).Delete(&models.User{})
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return ErrUserNotFound
+	}
+	return nil
+}
@@ -0,0 +1,33 @@
+package repository
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+// Deactivation must keep the row so records referencing the user survive.
+// The SQL is built without a database and checked to be a soft delete.
+func TestDeactivate_SoftDeletesTheUser(t *testing.T) {
+	db, err := gorm.Open(postgres.New(postgres.Config{DSN: "host=localhost dbname=users"}), &gorm.Config{DryRun: true, DisableAutomaticPing: true, SkipDefaultTransaction: true})
+	if err != nil {
+		t.Fatalf("open dry-run db: %v", err)
+	}
+	var statement string
+	db.Callback().Delete().After("gorm:delete").Register("test:record", func(tx *gorm.DB) {
+		statement = tx.Statement.SQL.String()
+	})
+
+	// A dry run affects no rows, which the repository reports as not found
+	if err := NewGormUserRepo(db).Deactivate(context.Background(), uuid.New()); err != ErrUserNotFound {
+		t.Fatalf("expected ErrUserNotFound from a dry run, got %v", err)
+	}
+	if !strings.HasPrefix(statement, `UPDATE "users" SET "deleted_at"=`) ||
+		!strings.Contains(statement, `"users"."deleted_at" IS NULL`) {
+		t.Fatalf("expected a soft delete of an active user, got %q", statement)
+	}
+}
@@ -2,8 +2,11 @@ package routes
 
 import (
     "log"
+    "time"
     "user-service/controllers"
+    "user-service/middleware"
     "github.com/gin-gonic/gin"
+    "golang.org/x/time/rate"
 )
 
 // Accepts a RouterGroup which already applies auth middleware
@@ -13,3 +16,26 @@ func RegisterUserRoutes(rg *gin.RouterGroup) {
     rg.PUT("/profile", controllers.UpdateProfile)
     rg.POST("/change-password", controllers.ChangePassword)
 }
+
+// RegisterAccountRoutes mounts account deactivation on the authenticated group
+func RegisterAccountRoutes(rg *gin.RouterGroup, ac *controllers.AccountController) {
+    rg.DELETE("/me", ac.DeactivateAccount)
+}
+
+// RegisterAddressRoutes mounts the address book on the authenticated group
+func RegisterAddressRoutes(rg *gin.RouterGroup, ac *controllers.AddressController) {
+    rg.GET("/addresses", ac.ListAddresses)
+    rg.POST("/addresses", ac.CreateAddress)
+    rg.PUT("/addresses/:id", ac.UpdateAddress)
+    rg.DELETE("/addresses/:id", ac.DeleteAddress)
+}
+
+// RegisterExportRoutes mounts the data export, limited to a few per user per hour
+func RegisterExportRoutes(rg *gin.RouterGroup, ec *controllers.ExportController) {
+    rg.GET("/me/export", middleware.UserRateLimit(rate.Every(20*time.Minute), 2), ec.ExportUserData)
+}
+
+// RegisterAdminRoutes mounts admin-only user management
+func RegisterAdminRoutes(rg *gin.RouterGroup, rc *controllers.RoleController) {
+    rg.PUT("/:id/role", middleware.AdminOnly(), rc.UpdateRole)
+}
@@ -0,0 +1,28 @@
+package services
+
+import (
+	"context"
+	"user-service/repository"
+
+	"github.com/google/uuid"
+)
+
+// AccountService manages a user's own account.
+type AccountService struct {
+	users repository.UserRepository
+}
+
+func NewAccountService(users repository.UserRepository) *AccountService {
+	return &AccountService{users: users}
+}
+
+// Deactivate soft-deletes userID's account. Its refresh tokens are revoked
+// first so no existing session outlives the account, and auth-service refuses
+// to log it in again. A user that is already deactivated is reported as
+// repository.ErrUserNotFound.
+func (s *AccountService) Deactivate(ctx context.Context, userID uuid.UUID) error {
+	if err := s.users.RevokeRefreshTokens(ctx, userID); err != nil {
+		return err
+	}
+	return s.users.Deactivate(ctx, userID)
+}
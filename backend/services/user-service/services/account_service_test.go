@@ -0,0 +1,57 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"user-service/repository"
+
+	"github.com/google/uuid"
+)
+
+// revokingUserRepo records whose refresh tokens were revoked.
+type revokingUserRepo struct {
+	memUserRepo
+	revoked []uuid.UUID
+}
+
+func (r *revokingUserRepo) RevokeRefreshTokens(ctx context.Context, id uuid.UUID) error {
+	r.revoked = append(r.revoked, id)
+	return nil
+}
+
+func TestAccountService_DeactivateKeepsTheUserRow(t *testing.T) {
+	userID := uuid.New()
+	users := &revokingUserRepo{memUserRepo: memUserRepo{userID: {ID: userID, Email: "a@example.com"}}}
+	svc := NewAccountService(users)
+
+	if err := svc.Deactivate(context.Background(), userID); err != nil {
+		t.Fatalf("deactivate: %v", err)
+	}
+	user, ok := users.memUserRepo[userID]
+	if !ok || !user.DeletedAt.Valid {
+		t.Fatalf("expected the row to be kept with deleted_at set, got %+v", user)
+	}
+	if _, err := users.FindByID(context.Background(), userID); !errors.Is(err, repository.ErrUserNotFound) {
+		t.Fatalf("expected a deactivated user to be hidden, got %v", err)
+	}
+	if len(users.revoked) != 1 || users.revoked[0] != userID {
+		t.Fatalf("expected the user's sessions to be revoked, got %v", users.revoked)
+	}
+}
+
+func TestAccountService_DeactivateUnknownOrDeactivatedUser(t *testing.T) {
+	userID := uuid.New()
+	users := &revokingUserRepo{memUserRepo: memUserRepo{userID: {ID: userID}}}
+	svc := NewAccountService(users)
+
+	if err := svc.Deactivate(context.Background(), uuid.New()); !errors.Is(err, repository.ErrUserNotFound) {
+		t.Fatalf("expected ErrUserNotFound for an unknown user, got %v", err)
+	}
+	if err := svc.Deactivate(context.Background(), userID); err != nil {
+		t.Fatalf("deactivate: %v", err)
+	}
+	if err := svc.Deactivate(context.Background(), userID); !errors.Is(err, repository.ErrUserNotFound) {
+		t.Fatalf("expected ErrUserNotFound when deactivating twice, got %v", err)
+	}
+}
@@ -0,0 +1,139 @@
+package services
+
+import (
+	"context"
+	"user-service/models"
+	"user-service/repository"
+
+	"github.com/google/uuid"
+)
+
+// ValidationError carries per-field messages for a rejected request.
+type ValidationError struct {
+	Fields map[string]string
+}
+
+func (e *ValidationError) Error() string {
+	return "validation failed"
+}
+
+// AddressRequest is a create or update of an address book entry.
+type AddressRequest struct {
+	AddressInput
+	Type      string `json:"type"` // billing or shipping; defaults to shipping
+	IsDefault *bool  `json:"is_default"`
+}
+
+// AddressService manages a user's address book. Exactly one address is the
+// default whenever the user has any: the first one saved becomes default, and
+// deleting the default promotes the most recent remaining address.
+type AddressService struct {
+	repo repository.AddressRepository
+}
+
+func NewAddressService(repo repository.AddressRepository) *AddressService {
+	return &AddressService{repo: repo}
+}
+
+func (s *AddressService) List(ctx context.Context, userID uuid.UUID) ([]models.Address, error) {
+	return s.repo.ListByUser(ctx, userID)
+}
+
+func (s *AddressService) Create(ctx context.Context, userID uuid.UUID, req AddressRequest) (*models.Address, error) {
+	addr, err := buildAddress(req)
+	if err != nil {
+		return nil, err
+	}
+	addr.UserID = userID
+
+	existing, err := s.repo.ListByUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.repo.Create(ctx, addr); err != nil {
+		return nil, err
+	}
+
+	if len(existing) == 0 || (req.IsDefault != nil && *req.IsDefault) {
+		if err := s.repo.SetDefault(ctx, userID, addr.ID); err != nil {
+			return nil, err
+		}
+		addr.IsDefault = true
+	}
+	return addr, nil
+}
+
+// Update replaces an address's fields. is_default=true makes it the default;
+// is_default=false is ignored, since another address must be chosen instead.
+func (s *AddressService) Update(ctx context.Context, userID, id uuid.UUID, req AddressRequest) (*models.Address, error) {
+	current, err := s.repo.FindByID(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+	addr, err := buildAddress(req)
+	if err != nil {
+		return nil, err
+	}
+	addr.ID = current.ID
+	addr.UserID = userID
+	addr.IsDefault = current.IsDefault
+	addr.CreatedAt = current.CreatedAt
+
+	if err := s.repo.Update(ctx, addr); err != nil {
+		return nil, err
+	}
+	if req.IsDefault != nil && *req.IsDefault && !addr.IsDefault {
+		if err := s.repo.SetDefault(ctx, userID, id); err != nil {
+			return nil, err
+		}
+		addr.IsDefault = true
+	}
+	return addr, nil
+}
+
+func (s *AddressService) Delete(ctx context.Context, userID, id uuid.UUID) error {
+	current, err := s.repo.FindByID(ctx, userID, id)
+	if err != nil {
+		return err
+	}
+	if err := s.repo.Delete(ctx, userID, id); err != nil {
+		return err
+	}
+	if !current.IsDefault {
+		return nil
+	}
+
+	remaining, err := s.repo.ListByUser(ctx, userID)
+	if err != nil || len(remaining) == 0 {
+		return err
+	}
+	newest := remaining[0]
+	for _, a := range remaining[1:] {
+		if a.CreatedAt.After(newest.CreatedAt) {
+			newest = a
+		}
+	}
+	return s.repo.SetDefault(ctx, userID, newest.ID)
+}
+
+func buildAddress(req AddressRequest) (*models.Address, error) {
+	in, fieldErrs := ValidateAddress(req.AddressInput)
+	addrType := req.Type
+	if addrType == "" {
+		addrType = "shipping"
+	}
+	if addrType != "shipping" && addrType != "billing" {
+		fieldErrs["type"] = "must be billing or shipping"
+	}
+	if len(fieldErrs) > 0 {
+		return nil, &ValidationError{Fields: fieldErrs}
+	}
+	return &models.Address{
+		Type:       addrType,
+		Street:     in.Street,
+		City:       in.City,
+		State:      in.State,
+		PostalCode: in.PostalCode,
+		Country:    in.Country,
+	}, nil
+}
@@ -0,0 +1,205 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+	"user-service/models"
+	"user-service/repository"
+
+	"github.com/google/uuid"
+)
+
+// memAddressRepo is an in-memory AddressRepository.
+type memAddressRepo struct {
+	addrs      map[uuid.UUID]models.Address
+	clock      time.Time
+	shippingID map[uuid.UUID]uuid.UUID // user -> shipping_address_id
+}
+
+func newMemAddressRepo() *memAddressRepo {
+	return &memAddressRepo{
+		addrs:      map[uuid.UUID]models.Address{},
+		clock:      time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		shippingID: map[uuid.UUID]uuid.UUID{},
+	}
+}
+
+func (r *memAddressRepo) ListByUser(ctx context.Context, userID uuid.UUID) ([]models.Address, error) {
+	var out []models.Address
+	for _, a := range r.addrs {
+		if a.UserID == userID {
+			out = append(out, a)
+		}
+	}
+	return out, nil
+}
+
+func (r *memAddressRepo) FindByID(ctx context.Context, userID, id uuid.UUID) (*models.Address, error) {
+	a, ok := r.addrs[id]
+	if !ok || a.UserID != userID {
+		return nil, repository.ErrAddressNotFound
+	}
+	return &a, nil
+}
+
+func (r *memAddressRepo) Create(ctx context.Context, addr *models.Address) error {
+	r.clock = r.clock.Add(time.Minute)
+	addr.ID = uuid.New()
+	addr.CreatedAt = r.clock
+	r.addrs[addr.ID] = *addr
+	return nil
+}
+
+func (r *memAddressRepo) Update(ctx context.Context, addr *models.Address) error {
+	cur, ok := r.addrs[addr.ID]
+	if !ok || cur.UserID != addr.UserID {
+		return repository.ErrAddressNotFound
+	}
+	r.addrs[addr.ID] = *addr
+	return nil
+}
+
+func (r *memAddressRepo) Delete(ctx context.Context, userID, id uuid.UUID) error {
+	if _, err := r.FindByID(ctx, userID, id); err != nil {
+		return err
+	}
+	delete(r.addrs, id)
+	if r.shippingID[userID] == id {
+		delete(r.shippingID, userID)
+	}
+	return nil
+}
+
+func (r *memAddressRepo) SetDefault(ctx context.Context, userID, id uuid.UUID) error {
+	if _, err := r.FindByID(ctx, userID, id); err != nil {
+		return err
+	}
+	for aid, a := range r.addrs {
+		if a.UserID == userID {
+			a.IsDefault = aid == id
+			r.addrs[aid] = a
+		}
+	}
+	r.shippingID[userID] = id
+	return nil
+}
+
+func (r *memAddressRepo) defaults(userID uuid.UUID) []uuid.UUID {
+	var ids []uuid.UUID
+	for id, a := range r.addrs {
+		if a.UserID == userID && a.IsDefault {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+func addressReq(street string, isDefault *bool) AddressRequest {
+	return AddressRequest{
+		AddressInput: AddressInput{Street: street, City: "Pune", State: "MH", PostalCode: "411001", Country: "IN"},
+		IsDefault:    isDefault,
+	}
+}
+
+func boolPtr(b bool) *bool { return &b }
+
+func TestAddressService_CRUD(t *testing.T) {
+	ctx := context.Background()
+	repo := newMemAddressRepo()
+	svc := NewAddressService(repo)
+	userID := uuid.New()
+
+	created, err := svc.Create(ctx, userID, addressReq("12 MG Road", nil))
+	if err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	if created.Type != "shipping" || !created.IsDefault {
+		t.Fatalf("expected first address to be a default shipping address, got %+v", created)
+	}
+
+	req := addressReq("14 MG Road", nil)
+	req.Type = "billing"
+	updated, err := svc.Update(ctx, userID, created.ID, req)
+	if err != nil {
+		t.Fatalf("update: %v", err)
+	}
+	if updated.Street != "14 MG Road" || updated.Type != "billing" || !updated.IsDefault {
+		t.Fatalf("unexpected updated address %+v", updated)
+	}
+
+	list, _ := svc.List(ctx, userID)
+	if len(list) != 1 || list[0].Street != "14 MG Road" {
+		t.Fatalf("unexpected list %+v", list)
+	}
+
+	if err := svc.Delete(ctx, userID, created.ID); err != nil {
+		t.Fatalf("delete: %v", err)
+	}
+	if list, _ := svc.List(ctx, userID); len(list) != 0 {
+		t.Fatalf("expected empty address book, got %+v", list)
+	}
+}
+
+func TestAddressService_OtherUsersAddressNotFound(t *testing.T) {
+	ctx := context.Background()
+	svc := NewAddressService(newMemAddressRepo())
+	owner := uuid.New()
+
+	addr, _ := svc.Create(ctx, owner, addressReq("12 MG Road", nil))
+
+	if _, err := svc.Update(ctx, uuid.New(), addr.ID, addressReq("x", nil)); !errors.Is(err, repository.ErrAddressNotFound) {
+		t.Fatalf("expected ErrAddressNotFound on update, got %v", err)
+	}
+	if err := svc.Delete(ctx, uuid.New(), addr.ID); !errors.Is(err, repository.ErrAddressNotFound) {
+		t.Fatalf("expected ErrAddressNotFound on delete, got %v", err)
+	}
+}
+
+func TestAddressService_ValidationError(t *testing.T) {
+	svc := NewAddressService(newMemAddressRepo())
+	req := addressReq("", nil)
+	req.Type = "office"
+
+	_, err := svc.Create(context.Background(), uuid.New(), req)
+	var verr *ValidationError
+	if !errors.As(err, &verr) || verr.Fields["street"] == "" || verr.Fields["type"] == "" {
+		t.Fatalf("expected street and type field errors, got %v", err)
+	}
+}
+
+func TestAddressService_DefaultSwitching(t *testing.T) {
+	ctx := context.Background()
+	repo := newMemAddressRepo()
+	svc := NewAddressService(repo)
+	userID := uuid.New()
+
+	home, _ := svc.Create(ctx, userID, addressReq("Home", nil))
+	work, _ := svc.Create(ctx, userID, addressReq("Work", nil))
+	if work.IsDefault {
+		t.Fatal("second address should not become default implicitly")
+	}
+
+	// Creating with is_default moves the default
+	parents, _ := svc.Create(ctx, userID, addressReq("Parents", boolPtr(true)))
+	if got := repo.defaults(userID); len(got) != 1 || got[0] != parents.ID {
+		t.Fatalf("expected Parents to be the only default, got %v", got)
+	}
+
+	// Updating with is_default moves it again
+	if _, err := svc.Update(ctx, userID, work.ID, addressReq("Work", boolPtr(true))); err != nil {
+		t.Fatalf("update: %v", err)
+	}
+	if got := repo.defaults(userID); len(got) != 1 || got[0] != work.ID || repo.shippingID[userID] != work.ID {
+		t.Fatalf("expected Work to be the only default, got %v", got)
+	}
+
+	// Deleting the default promotes the most recent remaining address
+	if err := svc.Delete(ctx, userID, work.ID); err != nil {
+		t.Fatalf("delete: %v", err)
+	}
+	if got := repo.defaults(userID); len(got) != 1 || got[0] != parents.ID {
+		t.Fatalf("expected Parents to be promoted, got %v (home=%s)", got, home.ID)
+	}
+}
@@ -0,0 +1,81 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+	"user-service/models"
+	"user-service/repository"
+
+	"github.com/google/uuid"
+)
+
+// ExportProfile is the profile section of a data export. It leaves out the
+// password hash and internal foreign keys.
+type ExportProfile struct {
+	ID          uuid.UUID `json:"id"`
+	Name        string    `json:"name"`
+	Email       string    `json:"email"`
+	PhoneNumber *string   `json:"phone_number"`
+	Role        string    `json:"role"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// UserDataExport is everything the platform holds about one user.
+type UserDataExport struct {
+	ExportedAt time.Time         `json:"exported_at"`
+	Profile    ExportProfile     `json:"profile"`
+	Addresses  []models.Address  `json:"addresses"`
+	Orders     []json.RawMessage `json:"orders"`
+}
+
+// ExportService builds a user's data export from user-service's own tables
+// and order-service.
+type ExportService struct {
+	users     repository.UserRepository
+	addresses repository.AddressRepository
+	orders    OrderLister
+	now       func() time.Time
+}
+
+func NewExportService(users repository.UserRepository, addresses repository.AddressRepository, orders OrderLister) *ExportService {
+	return &ExportService{users: users, addresses: addresses, orders: orders, now: time.Now}
+}
+
+// Export gathers the data of userID only; every lookup is scoped to it.
+func (s *ExportService) Export(ctx context.Context, userID uuid.UUID) (*UserDataExport, error) {
+	user, err := s.users.FindByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	addrs, err := s.addresses.ListByUser(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("list addresses: %w", err)
+	}
+	if addrs == nil {
+		addrs = []models.Address{}
+	}
+
+	orders, err := s.orders.ListUserOrders(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("list orders: %w", err)
+	}
+
+	return &UserDataExport{
+		ExportedAt: s.now().UTC(),
+		Profile: ExportProfile{
+			ID:          user.ID,
+			Name:        user.Name,
+			Email:       user.Email,
+			PhoneNumber: user.PhoneNumber,
+			Role:        user.Role,
+			CreatedAt:   user.CreatedAt,
+			UpdatedAt:   user.UpdatedAt,
+		},
+		Addresses: addrs,
+		Orders:    orders,
+	}, nil
+}
@@ -0,0 +1,146 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+	"user-service/models"
+	"user-service/repository"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type memUserRepo map[uuid.UUID]models.User
+
+func (r memUserRepo) FindByID(ctx context.Context, id uuid.UUID) (*models.User, error) {
+	u, ok := r[id]
+	if !ok || u.DeletedAt.Valid {
+		return nil, repository.ErrUserNotFound
+	}
+	return &u, nil
+}
+
+func (r memUserRepo) UpdateRole(ctx context.Context, id uuid.UUID, role string) error {
+	u, ok := r[id]
+	if !ok {
+		return repository.ErrUserNotFound
+	}
+	u.Role = role
+	r[id] = u
+	return nil
+}
+
+func (r memUserRepo) RevokeRefreshTokens(ctx context.Context, id uuid.UUID) error {
+	if _, ok := r[id]; !ok {
+		return repository.ErrUserNotFound
+	}
+	return nil
+}
+
+func (r memUserRepo) Deactivate(ctx context.Context, id uuid.UUID) error {
+	u, ok := r[id]
+	if !ok || u.DeletedAt.Valid {
+		return repository.ErrUserNotFound
+	}
+	u.DeletedAt = gorm.DeletedAt{Time: time.Now(), Valid: true}
+	r[id] = u
+	return nil
+}
+
+// fakeOrderLister serves canned orders per user and records who was asked for.
+type fakeOrderLister struct {
+	byUser    map[uuid.UUID][]json.RawMessage
+	requested []uuid.UUID
+}
+
+func (f *fakeOrderLister) ListUserOrders(ctx context.Context, userID uuid.UUID) ([]json.RawMessage, error) {
+	f.requested = append(f.requested, userID)
+	return f.byUser[userID], nil
+}
+
+func TestExportService_AggregatesOnlyRequestingUser(t *testing.T) {
+	ctx := context.Background()
+	me, other := uuid.New(), uuid.New()
+	users := memUserRepo{
+		me:    {ID: me, Name: "Asha", Email: "asha@example.com", Password: "hash", Role: "user"},
+		other: {ID: other, Name: "Ravi", Email: "ravi@example.com", Password: "hash", Role: "user"},
+	}
+	addrs := newMemAddressRepo()
+	addrSvc := NewAddressService(addrs)
+	addrSvc.Create(ctx, me, addressReq("12 MG Road", nil))
+	addrSvc.Create(ctx, other, addressReq("7 FC Road", nil))
+	orders := &fakeOrderLister{byUser: map[uuid.UUID][]json.RawMessage{
+		me:    {json.RawMessage(`{"ID":"o-1"}`)},
+		other: {json.RawMessage(`{"ID":"o-2"}`)},
+	}}
+
+	svc := NewExportService(users, addrs, orders)
+	svc.now = func() time.Time { return time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC) }
+
+	export, err := svc.Export(ctx, me)
+	if err != nil {
+		t.Fatalf("export: %v", err)
+	}
+	if export.Profile.ID != me || export.Profile.Email != "asha@example.com" {
+		t.Fatalf("unexpected profile %+v", export.Profile)
+	}
+	if len(export.Addresses) != 1 || export.Addresses[0].Street != "12 MG Road" {
+		t.Fatalf("expected only the caller's address, got %+v", export.Addresses)
+	}
+	if len(export.Orders) != 1 || string(export.Orders[0]) != `{"ID":"o-1"}` {
+		t.Fatalf("expected only the caller's order, got %s", export.Orders)
+	}
+	if len(orders.requested) != 1 || orders.requested[0] != me {
+		t.Fatalf("orders fetched for %v, want only %s", orders.requested, me)
+	}
+
+	raw, _ := json.Marshal(export)
+	var doc map[string]json.RawMessage
+	json.Unmarshal(raw, &doc)
+	for _, key := range []string{"exported_at", "profile", "addresses", "orders"} {
+		if _, ok := doc[key]; !ok {
+			t.Errorf("export is missing %q: %s", key, raw)
+		}
+	}
+	var profile map[string]any
+	json.Unmarshal(doc["profile"], &profile)
+	if _, leaked := profile["password"]; leaked {
+		t.Fatalf("profile must not include the password hash: %s", doc["profile"])
+	}
+}
+
+func TestExportService_UnknownUser(t *testing.T) {
+	svc := NewExportService(memUserRepo{}, newMemAddressRepo(), &fakeOrderLister{})
+
+	if _, err := svc.Export(context.Background(), uuid.New()); !errors.Is(err, repository.ErrUserNotFound) {
+		t.Fatalf("expected ErrUserNotFound, got %v", err)
+	}
+}
+
+func TestOrderClient_PagesAsUser(t *testing.T) {
+	userID := uuid.New()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("X-User-ID"); got != userID.String() {
+			t.Errorf("expected X-User-ID %s, got %q", userID, got)
+		}
+		if r.URL.Query().Get("page") == "1" {
+			w.Write([]byte(`{"orders":[{"ID":"a"}],"meta":{"has_more":true}}`))
+			return
+		}
+		w.Write([]byte(`{"orders":[{"ID":"b"}],"meta":{"has_more":false}}`))
+	}))
+	defer srv.Close()
+
+	orders, err := NewOrderClient(srv.URL).ListUserOrders(context.Background(), userID)
+	if err != nil {
+		t.Fatalf("list orders: %v", err)
+	}
+	if len(orders) != 2 {
+		t.Fatalf("expected orders from both pages, got %s", orders)
+	}
+}
@@ -0,0 +1,73 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// OrderLister returns every order a user has placed.
+type OrderLister interface {
+	ListUserOrders(ctx context.Context, userID uuid.UUID) ([]json.RawMessage, error)
+}
+
+// orderPageSize is the page size requested from order-service; it matches the
+// service's maximum so an export needs as few round trips as possible.
+const orderPageSize = 100
+
+// OrderClient reads orders from order-service on behalf of a user.
+type OrderClient struct {
+	BaseURL string
+	HTTP    *http.Client
+}
+
+func NewOrderClient(baseURL string) *OrderClient {
+	return &OrderClient{
+		BaseURL: baseURL,
+		HTTP:    &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// ListUserOrders pages through GET /orders as the given user. Orders are
+// returned as order-service encodes them so the export mirrors that API.
+func (c *OrderClient) ListUserOrders(ctx context.Context, userID uuid.UUID) ([]json.RawMessage, error) {
+	orders := []json.RawMessage{}
+	for page := 1; ; page++ {
+		url := fmt.Sprintf("%s/orders/?page=%d&limit=%d", c.BaseURL, page, orderPageSize)
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("X-User-ID", userID.String())
+
+		resp, err := c.HTTP.Do(req)
+		if err != nil {
+			return nil, err
+		}
+
+		var body struct {
+			Orders []json.RawMessage `json:"orders"`
+			Meta   struct {
+				HasMore bool `json:"has_more"`
+			} `json:"meta"`
+		}
+		if resp.StatusCode != http.StatusOK {
+			resp.Body.Close()
+			return nil, fmt.Errorf("order service returned %d", resp.StatusCode)
+		}
+		err = json.NewDecoder(resp.Body).Decode(&body)
+		resp.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+
+		orders = append(orders, body.Orders...)
+		if !body.Meta.HasMore || len(body.Orders) == 0 {
+			return orders, nil
+		}
+	}
+}
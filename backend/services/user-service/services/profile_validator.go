@@ -0,0 +1,124 @@
+package services
+
+import (
+	"encoding/json"
+	"regexp"
+	"strings"
+)
+
+const maxNameLength = 100
+
+// immutableProfileFields can't be changed through a profile update. Email and
+// role changes go through their own flows.
+var immutableProfileFields = []string{"id", "email", "role", "password"}
+
+var (
+	phonePattern       = regexp.MustCompile(`^\+?[0-9]{7,15}$`)
+	countryCodePattern = regexp.MustCompile(`^[A-Z]{2}$`)
+)
+
+// AddressInput is an address as submitted by a client.
+type AddressInput struct {
+	Street     string `json:"street"`
+	City       string `json:"city"`
+	State      string `json:"state"`
+	PostalCode string `json:"postal_code"`
+	Country    string `json:"country"` // ISO 3166-1 alpha-2
+}
+
+// ProfileUpdate holds the validated, normalized fields of a profile update.
+// Nil fields were not supplied.
+type ProfileUpdate struct {
+	Name           *string
+	PhoneNumber    *string
+	DefaultAddress *AddressInput
+}
+
+// ValidateProfileUpdate parses a raw profile update body. It returns a
+// field -> message map when any field is invalid or immutable.
+func ValidateProfileUpdate(body []byte) (*ProfileUpdate, map[string]string) {
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(body, &raw); err != nil {
+		return nil, map[string]string{"body": "must be a JSON object"}
+	}
+
+	fieldErrs := map[string]string{}
+	for _, field := range immutableProfileFields {
+		if _, ok := raw[field]; ok {
+			fieldErrs[field] = "cannot be changed"
+		}
+	}
+
+	var req struct {
+		Name           *string       `json:"name"`
+		PhoneNumber    *string       `json:"phone_number"`
+		DefaultAddress *AddressInput `json:"default_address"`
+	}
+	if err := json.Unmarshal(body, &req); err != nil {
+		return nil, map[string]string{"body": "has a field of the wrong type"}
+	}
+
+	update := &ProfileUpdate{}
+	if req.Name != nil {
+		name := strings.TrimSpace(*req.Name)
+		switch {
+		case name == "":
+			fieldErrs["name"] = "must not be empty"
+		case len([]rune(name)) > maxNameLength:
+			fieldErrs["name"] = "must be at most 100 characters"
+		default:
+			update.Name = &name
+		}
+	}
+	if req.PhoneNumber != nil {
+		phone := strings.NewReplacer(" ", "", "-", "", "(", "", ")", "").Replace(*req.PhoneNumber)
+		if !phonePattern.MatchString(phone) {
+			fieldErrs["phone_number"] = "must be 7-15 digits, optionally prefixed with +"
+		} else {
+			update.PhoneNumber = &phone
+		}
+	}
+	if req.DefaultAddress != nil {
+		addr, errs := ValidateAddress(*req.DefaultAddress)
+		for field, msg := range errs {
+			fieldErrs["default_address."+field] = msg
+		}
+		if len(errs) == 0 {
+			update.DefaultAddress = &addr
+		}
+	}
+
+	if len(fieldErrs) > 0 {
+		return nil, fieldErrs
+	}
+	return update, nil
+}
+
+// ValidateAddress trims an address and checks its required fields. The
+// country code is upper-cased.
+func ValidateAddress(in AddressInput) (AddressInput, map[string]string) {
+	addr := AddressInput{
+		Street:     strings.TrimSpace(in.Street),
+		City:       strings.TrimSpace(in.City),
+		State:      strings.TrimSpace(in.State),
+		PostalCode: strings.TrimSpace(in.PostalCode),
+		Country:    strings.ToUpper(strings.TrimSpace(in.Country)),
+	}
+
+	errs := map[string]string{}
+	required := map[string]string{
+		"street":      addr.Street,
+		"city":        addr.City,
+		"state":       addr.State,
+		"postal_code": addr.PostalCode,
+	}
+	for field, value := range required {
+		if value == "" {
+			errs[field] = "is required"
+		}
+	}
+	if !countryCodePattern.MatchString(addr.Country) {
+		errs["country"] = "must be a two-letter ISO country code"
+	}
+	return addr, errs
+}
@@ -0,0 +1,63 @@
+package services
+
+import "testing"
+
+func TestValidateProfileUpdate_Valid(t *testing.T) {
+	body := []byte(`{
+		"name": "  Asha Rao ",
+		"phone_number": "+91 98765-43210",
+		"default_address": {"street": "12 MG Road", "city": "Pune", "state": "MH", "postal_code": "411001", "country": "in"}
+	}`)
+
+	update, errs := ValidateProfileUpdate(body)
+	if errs != nil {
+		t.Fatalf("unexpected validation errors: %v", errs)
+	}
+	if update.Name == nil || *update.Name != "Asha Rao" {
+		t.Fatalf("expected trimmed name, got %v", update.Name)
+	}
+	if update.PhoneNumber == nil || *update.PhoneNumber != "+919876543210" {
+		t.Fatalf("expected normalized phone, got %v", update.PhoneNumber)
+	}
+	if update.DefaultAddress == nil || update.DefaultAddress.Country != "IN" {
+		t.Fatalf("expected address with upper-cased country, got %+v", update.DefaultAddress)
+	}
+}
+
+func TestValidateProfileUpdate_PartialUpdate(t *testing.T) {
+	update, errs := ValidateProfileUpdate([]byte(`{"name": "Asha"}`))
+	if errs != nil {
+		t.Fatalf("unexpected validation errors: %v", errs)
+	}
+	if update.PhoneNumber != nil || update.DefaultAddress != nil {
+		t.Fatalf("expected only name to be set, got %+v", update)
+	}
+}
+
+func TestValidateProfileUpdate_RejectsImmutableFields(t *testing.T) {
+	_, errs := ValidateProfileUpdate([]byte(`{"name": "Asha", "email": "new@example.com", "role": "admin"}`))
+
+	if errs["email"] == "" || errs["role"] == "" {
+		t.Fatalf("expected email and role to be rejected, got %v", errs)
+	}
+}
+
+func TestValidateProfileUpdate_FieldErrors(t *testing.T) {
+	_, errs := ValidateProfileUpdate([]byte(`{
+		"name": "   ",
+		"phone_number": "call me",
+		"default_address": {"street": "12 MG Road", "city": "", "state": "MH", "postal_code": "411001", "country": "India"}
+	}`))
+
+	for _, field := range []string{"name", "phone_number", "default_address.city", "default_address.country"} {
+		if errs[field] == "" {
+			t.Errorf("expected an error for %s, got %v", field, errs)
+		}
+	}
+}
+
+func TestValidateProfileUpdate_NotAnObject(t *testing.T) {
+	if _, errs := ValidateProfileUpdate([]byte(`["name"]`)); errs["body"] == "" {
+		t.Fatalf("expected body error, got %v", errs)
+	}
+}
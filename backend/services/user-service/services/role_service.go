@@ -0,0 +1,82 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"time"
+	"user-service/models"
+	"user-service/repository"
+
+	aws_pkg "github.com/yashrajoria/E-Commerce-backend/backend/pkg/aws"
+
+	"github.com/google/uuid"
+)
+
+// AllowedRoles is the set of roles an admin may assign.
+var AllowedRoles = map[string]bool{
+	"user":  true,
+	"admin": true,
+}
+
+var ErrInvalidRole = errors.New("invalid role")
+
+// RoleService changes user roles and announces each change on SNS.
+type RoleService struct {
+	users       repository.UserRepository
+	snsClient   aws_pkg.SNSPublisher
+	snsTopicArn string
+	now         func() time.Time
+}
+
+func NewRoleService(users repository.UserRepository, snsClient aws_pkg.SNSPublisher, snsTopicArn string) *RoleService {
+	return &RoleService{users: users, snsClient: snsClient, snsTopicArn: snsTopicArn, now: time.Now}
+}
+
+// ChangeRole sets userID's role. The database is the source of truth, so a
+// failed publish is logged rather than undoing the change.
+func (s *RoleService) ChangeRole(ctx context.Context, actorID string, userID uuid.UUID, role string) (*models.UserRoleChangedEvent, error) {
+	if !AllowedRoles[role] {
+		return nil, ErrInvalidRole
+	}
+
+	user, err := s.users.FindByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	event := &models.UserRoleChangedEvent{
+		Type:      "user_role_changed",
+		UserID:    userID.String(),
+		OldRole:   user.Role,
+		NewRole:   role,
+		ChangedBy: actorID,
+		Timestamp: s.now().UTC(),
+	}
+	if user.Role == role {
+		return event, nil
+	}
+
+	if err := s.users.UpdateRole(ctx, userID, role); err != nil {
+		return nil, err
+	}
+	// Outstanding refresh tokens still carry the old role's session; revoke
+	// them so the user has to log in again under the new role.
+	if err := s.users.RevokeRefreshTokens(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	if s.snsClient == nil || s.snsTopicArn == "" {
+		log.Printf("[RoleService] SNS not configured, user_role_changed for %s not published", userID)
+		return event, nil
+	}
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.snsClient.Publish(ctx, s.snsTopicArn, payload); err != nil {
+		log.Printf("[RoleService] failed to publish user_role_changed for %s: %v", userID, err)
+	}
+	return event, nil
+}
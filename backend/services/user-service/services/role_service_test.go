@@ -0,0 +1,63 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"user-service/models"
+
+	"github.com/google/uuid"
+)
+
+type recordingSNS struct {
+	topic string
+	msgs  [][]byte
+}
+
+func (r *recordingSNS) Publish(ctx context.Context, topicArn string, msg []byte) error {
+	r.topic = topicArn
+	r.msgs = append(r.msgs, msg)
+	return nil
+}
+
+func TestRoleService_ChangeRolePublishesEvent(t *testing.T) {
+	userID, adminID := uuid.New(), uuid.New()
+	users := memUserRepo{userID: {ID: userID, Role: "user"}}
+	sns := &recordingSNS{}
+	svc := NewRoleService(users, sns, "arn:user-events")
+
+	if _, err := svc.ChangeRole(context.Background(), adminID.String(), userID, "admin"); err != nil {
+		t.Fatalf("change role: %v", err)
+	}
+	if users[userID].Role != "admin" {
+		t.Fatalf("expected role to be stored, got %q", users[userID].Role)
+	}
+	if len(sns.msgs) != 1 || sns.topic != "arn:user-events" {
+		t.Fatalf("expected one event on arn:user-events, got %d on %q", len(sns.msgs), sns.topic)
+	}
+
+	var event models.UserRoleChangedEvent
+	if err := json.Unmarshal(sns.msgs[0], &event); err != nil {
+		t.Fatalf("decode event: %v", err)
+	}
+	if event.Type != "user_role_changed" || event.UserID != userID.String() ||
+		event.OldRole != "user" || event.NewRole != "admin" || event.ChangedBy != adminID.String() {
+		t.Fatalf("unexpected event %+v", event)
+	}
+}
+
+func TestRoleService_RejectsUnknownRole(t *testing.T) {
+	userID := uuid.New()
+	users := memUserRepo{userID: {ID: userID, Role: "user"}}
+	sns := &recordingSNS{}
+	svc := NewRoleService(users, sns, "arn:user-events")
+
+	_, err := svc.ChangeRole(context.Background(), uuid.New().String(), userID, "superuser")
+	if !errors.Is(err, ErrInvalidRole) {
+		t.Fatalf("expected ErrInvalidRole, got %v", err)
+	}
+	if users[userID].Role != "user" || len(sns.msgs) != 0 {
+		t.Fatalf("invalid role must not be stored or published")
+	}
+}